@@ -0,0 +1,175 @@
+// Copyright 2018 The Pouch Robot Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package assigner
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/pouchcontainer/pouchrobot/config"
+	"github.com/pouchcontainer/pouchrobot/gh"
+
+	"github.com/google/go-github/github"
+	"github.com/sirupsen/logrus"
+)
+
+// DefaultCount is the default number of reviewers a pull request should have.
+const DefaultCount = 2
+
+// vacationLayout is the date layout of Vacation.Until.
+const vacationLayout = "2006-01-02"
+
+// Source finds candidate reviewers of changed files. It returns affinity of
+// each candidate, which is usually the number of files candidate owns or
+// has touched.
+type Source func(files []string) map[string]int
+
+// Assigner requests reviewers for pull requests. Candidates are collected from
+// sources and ranked by their affinity to changed files and their current
+// number of open review requests in repository.
+type Assigner struct {
+	client    *gh.Client
+	sources   []Source
+	count     int
+	vacations map[string]time.Time
+}
+
+// New initializes a brand new assigner.
+func New(client *gh.Client, cfg config.ReviewersConfig, sources ...Source) (*Assigner, error) {
+	assigner := &Assigner{
+		client:    client,
+		sources:   sources,
+		count:     cfg.Count,
+		vacations: map[string]time.Time{},
+	}
+	if assigner.count <= 0 {
+		assigner.count = DefaultCount
+	}
+
+	for _, vacation := range cfg.Vacations {
+		// zero time means unavailable indefinitely.
+		var until time.Time
+		if vacation.Until != "" {
+			day, err := time.Parse(vacationLayout, vacation.Until)
+			if err != nil {
+				return nil, fmt.Errorf("invalid vacation of %s: %v", vacation.Login, err)
+			}
+			until = day.AddDate(0, 0, 1)
+		}
+		assigner.vacations[strings.ToLower(vacation.Login)] = until
+	}
+	return assigner, nil
+}
+
+// AddSource adds a source of candidate reviewers.
+func (a *Assigner) AddSource(source Source) {
+	a.sources = append(a.sources, source)
+}
+
+// Assign requests reviewers for pull request num until it has enough
+// requested reviewers, and returns the newly requested ones.
+func (a *Assigner) Assign(num int, author string, files []string) ([]string, error) {
+	requested, err := a.client.ListRequestedReviewers(num)
+	if err != nil {
+		return nil, err
+	}
+	need := a.count - len(requested)
+	if need <= 0 {
+		return nil, nil
+	}
+
+	excluded := map[string]bool{strings.ToLower(author): true}
+	for _, user := range requested {
+		excluded[strings.ToLower(user)] = true
+	}
+
+	affinity := map[string]int{}
+	for _, source := range a.sources {
+		for user, score := range source(files) {
+			if excluded[strings.ToLower(user)] || !a.IsAvailable(user, time.Now()) {
+				continue
+			}
+			affinity[user] += score
+		}
+	}
+	if len(affinity) == 0 {
+		logrus.Infof("no candidate reviewers found for pull request %d", num)
+		return nil, nil
+	}
+
+	loads := map[string]int{}
+	for user := range affinity {
+		load, err := a.reviewLoad(user)
+		if err != nil {
+			return nil, err
+		}
+		loads[user] = load
+	}
+
+	reviewers := Rank(affinity, loads)
+	if len(reviewers) > need {
+		reviewers = reviewers[:need]
+	}
+	if err := a.client.RequestReviewers(num, reviewers); err != nil {
+		return nil, err
+	}
+	return reviewers, nil
+}
+
+// IsAvailable returns false if user is on vacation at now.
+func (a *Assigner) IsAvailable(user string, now time.Time) bool {
+	until, ok := a.vacations[strings.ToLower(user)]
+	if !ok {
+		return true
+	}
+	return !until.IsZero() && !now.Before(until)
+}
+
+// reviewLoad returns the number of open pull requests in repository which
+// are waiting for review of user.
+func (a *Assigner) reviewLoad(user string) (int, error) {
+	query := fmt.Sprintf("repo:%s/%s is:pr is:open review-requested:%s", a.client.Owner(), a.client.Repo(), user)
+	result, err := a.client.SearchIssues(query, &github.SearchOptions{ListOptions: github.ListOptions{PerPage: 1}}, false)
+	if err != nil {
+		return 0, err
+	}
+	return result.GetTotal(), nil
+}
+
+// Rank sorts candidates by affinity weighted by their review loads.
+// Candidates with the same weight are sorted by lower load and then login.
+func Rank(affinity map[string]int, loads map[string]int) []string {
+	weight := func(user string) float64 {
+		return float64(affinity[user]) / float64(loads[user]+1)
+	}
+
+	var users []string
+	for user := range affinity {
+		users = append(users, user)
+	}
+	sort.Slice(users, func(i, j int) bool {
+		wi, wj := weight(users[i]), weight(users[j])
+		if wi != wj {
+			return wi > wj
+		}
+		if loads[users[i]] != loads[users[j]] {
+			return loads[users[i]] < loads[users[j]]
+		}
+		return users[i] < users[j]
+	})
+	return users
+}
@@ -0,0 +1,66 @@
+// Copyright 2018 The Pouch Robot Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package assigner
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/pouchcontainer/pouchrobot/config"
+)
+
+func TestRank(t *testing.T) {
+	affinity := map[string]int{"foo": 4, "bar": 4, "baz": 1, "qux": 2}
+	loads := map[string]int{"foo": 3, "bar": 0, "baz": 0, "qux": 1}
+
+	want := []string{"bar", "baz", "qux", "foo"}
+	if got := Rank(affinity, loads); !reflect.DeepEqual(got, want) {
+		t.Errorf("Rank() = %v, want %v", got, want)
+	}
+}
+
+func TestIsAvailable(t *testing.T) {
+	a, err := New(nil, config.ReviewersConfig{
+		Vacations: []config.Vacation{
+			{Login: "Foo"},
+			{Login: "bar", Until: "2018-10-01"},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		user string
+		now  string
+		want bool
+	}{
+		{user: "foo", now: "2030-01-01", want: false},
+		{user: "bar", now: "2018-10-01", want: false},
+		{user: "bar", now: "2018-10-02", want: true},
+		{user: "baz", now: "2018-10-01", want: true},
+	}
+	for _, tt := range tests {
+		now, _ := time.Parse(vacationLayout, tt.now)
+		if got := a.IsAvailable(tt.user, now); got != tt.want {
+			t.Errorf("IsAvailable(%s, %s) = %v, want %v", tt.user, tt.now, got, tt.want)
+		}
+	}
+
+	if _, err := New(nil, config.ReviewersConfig{Vacations: []config.Vacation{{Login: "foo", Until: "tomorrow"}}}); err == nil {
+		t.Errorf("New() expected error for invalid vacation")
+	}
+}
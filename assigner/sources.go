@@ -0,0 +1,100 @@
+// Copyright 2018 The Pouch Robot Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package assigner
+
+import (
+	"strings"
+
+	"github.com/pouchcontainer/pouchrobot/codeowners"
+	"github.com/pouchcontainer/pouchrobot/gh"
+	"github.com/pouchcontainer/pouchrobot/owners"
+)
+
+const (
+	// historyFiles is the maximum number of changed files to look up history of.
+	historyFiles = 20
+
+	// historyCommits is the number of latest commits to look up for each file.
+	historyCommits = 10
+)
+
+// OwnersSource finds candidates from OWNERS files. Every reviewer and
+// approver of an area gains affinity of the number of files in the area.
+func OwnersSource(loader *owners.Loader) Source {
+	return func(files []string) map[string]int {
+		affinity := map[string]int{}
+		for _, area := range loader.Areas(files) {
+			for _, user := range append(area.Reviewers, area.Approvers...) {
+				affinity[user] += len(area.Files)
+			}
+		}
+		return affinity
+	}
+}
+
+// HistorySource finds candidates from authors of latest commits touching
+// changed files, which works as blame data of files.
+func HistorySource(client *gh.Client) Source {
+	return func(files []string) map[string]int {
+		if len(files) > historyFiles {
+			files = files[:historyFiles]
+		}
+
+		affinity := map[string]int{}
+		for _, file := range files {
+			authors, err := client.ListFileAuthors(file, historyCommits)
+			if err != nil {
+				continue
+			}
+			for _, author := range authors {
+				affinity[author]++
+			}
+		}
+		return affinity
+	}
+}
+
+// CodeOwnersSource finds candidates from CODEOWNERS file. Members of owner
+// teams are candidates as well, owners in form of email are ignored.
+func CodeOwnersSource(loader *codeowners.Loader, client *gh.Client) Source {
+	return func(files []string) map[string]int {
+		affinity := map[string]int{}
+		teams := map[string][]string{}
+		for _, fileOwners := range loader.Owners(files) {
+			for _, owner := range fileOwners {
+				if !strings.HasPrefix(owner, "@") {
+					continue
+				}
+				owner = strings.TrimPrefix(owner, "@")
+
+				parts := strings.SplitN(owner, "/", 2)
+				if len(parts) == 1 {
+					affinity[owner]++
+					continue
+				}
+
+				members, ok := teams[owner]
+				if !ok {
+					members, _ = client.ListTeamMembers(parts[0], parts[1])
+					teams[owner] = members
+				}
+				for _, member := range members {
+					affinity[member]++
+				}
+			}
+		}
+		return affinity
+	}
+}
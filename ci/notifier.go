@@ -23,6 +23,7 @@ import (
 
 	"github.com/pouchcontainer/pouchrobot/gh"
 	"github.com/pouchcontainer/pouchrobot/utils"
+	"github.com/pouchcontainer/pouchrobot/utils/templates"
 
 	"github.com/google/go-github/github"
 )
@@ -30,24 +31,26 @@ import (
 // Notifier is a processor that receives notification from CI system
 // and act to the messages to periodically get elements from github
 type Notifier struct {
-	client *gh.Client
-	owner  string
-	repo   string
+	client    *gh.Client
+	templates *templates.Renderer
+	owner     string
+	repo      string
 }
 
 // New initializes a brand new notifier
-func New(client *gh.Client, owner string, repo string) *Notifier {
+func New(client *gh.Client, renderer *templates.Renderer, owner string, repo string) *Notifier {
 	return &Notifier{
-		client: client,
-		owner:  owner,
-		repo:   repo,
+		client:    client,
+		templates: renderer,
+		owner:     owner,
+		repo:      repo,
 	}
 }
 
 // Process gets the json string and acts to these messages from CI system, such as travisCI.
 func (n *Notifier) Process(input string) error {
 	input = strings.Replace(input, `\"`, `"`, -1)
-	logrus.Debugf(input)
+	logrus.Debug(input)
 	var wh Webhook
 	if err := json.Unmarshal([]byte(input), &wh); err != nil {
 		return err
@@ -62,16 +65,12 @@ func (n *Notifier) Process(input string) error {
 
 	// if the status is passed, we need to remove failure comment
 	if wh.State == "passed" {
-		return n.client.RmCommentsViaStr(prNum, utils.CIFailsCommentSubStr)
+		return n.client.RemoveMarkedComments(prNum, utils.CIFailsCommentID)
 	}
 
-	// if the status is failure, we need to do steps by:
-	// 1. remove failure comments if there are any;
-	// 2. add new failure comments to show failure state.
+	// if the status is failure, we need to add a failure comment or
+	// update the existing one to show the latest failure state.
 	if wh.State == "failed" {
-		// first remove failure comments if there are any.
-		n.client.RmCommentsViaStr(prNum, utils.CIFailsCommentSubStr)
-
 		pr, err := n.client.GetSinglePR(prNum)
 		if err != nil {
 			return err
@@ -81,7 +80,7 @@ func (n *Notifier) Process(input string) error {
 			// we only consider pr which are open
 			return nil
 		}
-		// add new failure comments
+		// add or update failure comments
 		return n.addCIFailureComments(pr, wh)
 	}
 
@@ -89,10 +88,17 @@ func (n *Notifier) Process(input string) error {
 }
 
 func (n *Notifier) addCIFailureComments(pr *github.PullRequest, wh Webhook) error {
-	// add a brand new one CI failure comments
-	body := fmt.Sprintf(utils.CIFailsComment, *(pr.User.Login), n.owner, n.repo)
-	detailsStr := fmt.Sprintf("build url: %s\nbuild duration: %ds\n", wh.BuildURL, wh.Duration)
-	body = body + "\n" + detailsStr
+	// add a CI failure comment, or update the existing one in place
+	body, err := n.templates.Render(utils.CIFailsCommentID, templates.Data{
+		Author:   *(pr.User.Login),
+		Owner:    n.owner,
+		Repo:     n.repo,
+		BuildURL: wh.BuildURL,
+		Duration: wh.Duration,
+	})
+	if err != nil {
+		return err
+	}
 
-	return n.client.RmCommentsViaStrAndAttach(*(pr.Number), utils.CIFailsCommentSubStr, body)
+	return n.client.UpsertComment(*(pr.Number), utils.CIFailsCommentID, body)
 }
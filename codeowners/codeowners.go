@@ -0,0 +1,90 @@
+// Copyright 2018 The Pouch Robot Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package codeowners
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/pouchcontainer/pouchrobot/utils/glob"
+)
+
+// Rule is a line of CODEOWNERS file which assigns owners to files matching pattern.
+type Rule struct {
+	// Pattern is the file pattern as written in CODEOWNERS file.
+	Pattern string
+
+	// Owners are owners of matching files, like "@user", "@org/team" or an email.
+	// Matching files are unowned if it is empty.
+	Owners []string
+
+	regex *regexp.Regexp
+}
+
+// File is a parsed CODEOWNERS file.
+type File struct {
+	Rules []*Rule
+}
+
+// Parse parses content of a CODEOWNERS file.
+func Parse(data []byte) (*File, error) {
+	file := &File{}
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for lineNum := 1; scanner.Scan(); lineNum++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		fields := strings.Fields(line)
+		var owners []string
+		for _, owner := range fields[1:] {
+			if strings.HasPrefix(owner, "#") {
+				break
+			}
+			owners = append(owners, owner)
+		}
+
+		regex, err := glob.Compile(fields[0])
+		if err != nil {
+			return nil, fmt.Errorf("invalid pattern %q in line %d of CODEOWNERS: %v", fields[0], lineNum, err)
+		}
+		file.Rules = append(file.Rules, &Rule{Pattern: fields[0], Owners: owners, regex: regex})
+	}
+	return file, nil
+}
+
+// Match returns the rule which decides owners of path. As GitHub does,
+// the last matching rule takes precedence. It returns nil if no rule matches.
+func (f *File) Match(path string) *Rule {
+	path = strings.TrimPrefix(path, "/")
+	for i := len(f.Rules) - 1; i >= 0; i-- {
+		if f.Rules[i].regex.MatchString(path) {
+			return f.Rules[i]
+		}
+	}
+	return nil
+}
+
+// Owners returns owners of path.
+func (f *File) Owners(path string) []string {
+	if rule := f.Match(path); rule != nil {
+		return rule.Owners
+	}
+	return nil
+}
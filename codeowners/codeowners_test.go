@@ -0,0 +1,60 @@
+// Copyright 2018 The Pouch Robot Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package codeowners
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestMatch(t *testing.T) {
+	file, err := Parse([]byte(`# default owners
+*       @global-owner
+
+*.js    @js-owner # inline comment
+docs/*  docs@example.com
+apps/   @octocat
+/build/logs/ @doctocat
+**/logs @logs-owner
+/scripts/ @doctocat @octocat
+/apps/github
+`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		path string
+		want []string
+	}{
+		{path: "main.go", want: []string{"@global-owner"}},
+		{path: "web/app.js", want: []string{"@js-owner"}},
+		{path: "docs/getting-started.md", want: []string{"docs@example.com"}},
+		{path: "docs/build-app/troubleshooting.md", want: []string{"@global-owner"}},
+		{path: "src/apps/main.go", want: []string{"@octocat"}},
+		{path: "build/logs/out.log", want: []string{"@logs-owner"}},
+		{path: "deeply/nested/logs/out.log", want: []string{"@logs-owner"}},
+		{path: "scripts/build.sh", want: []string{"@doctocat", "@octocat"}},
+		{path: "src/scripts/build.sh", want: []string{"@global-owner"}},
+		{path: "apps/github/index.js", want: nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			if got := file.Owners(tt.path); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Owners(%s) = %v, want %v", tt.path, got, tt.want)
+			}
+		})
+	}
+}
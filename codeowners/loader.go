@@ -0,0 +1,147 @@
+// Copyright 2018 The Pouch Robot Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package codeowners
+
+import (
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pouchcontainer/pouchrobot/gh"
+
+	"github.com/sirupsen/logrus"
+)
+
+// DefaultPaths are paths GitHub looks up CODEOWNERS file in order.
+var DefaultPaths = []string{".github/CODEOWNERS", "CODEOWNERS", "docs/CODEOWNERS"}
+
+// CacheDuration is how long a loaded CODEOWNERS file is cached.
+const CacheDuration = 30 * time.Minute
+
+// AreaLabelPrefix is the prefix of area labels derived from code owners.
+const AreaLabelPrefix = "areas/"
+
+// Loader loads CODEOWNERS file from the default branch of repository and
+// caches it.
+type Loader struct {
+	sync.Mutex
+
+	client *gh.Client
+	paths  []string
+
+	// areas maps lower cased owners to areas.
+	areas map[string]string
+
+	file     *File
+	loadedAt time.Time
+}
+
+// NewLoader initializes a brand new CODEOWNERS loader. DefaultPaths are
+// looked up if path is empty. areas maps owners like "@org/team" to areas
+// like "network", which are turned into labels like "areas/network".
+func NewLoader(client *gh.Client, path string, areas map[string]string) *Loader {
+	paths := DefaultPaths
+	if path != "" {
+		paths = []string{path}
+	}
+
+	lowerAreas := map[string]string{}
+	for owner, area := range areas {
+		lowerAreas[strings.ToLower(owner)] = area
+	}
+	return &Loader{
+		client: client,
+		paths:  paths,
+		areas:  lowerAreas,
+	}
+}
+
+// Get gets CODEOWNERS file of repository. It returns nil if there is none.
+func (l *Loader) Get() *File {
+	l.Lock()
+	defer l.Unlock()
+
+	if !l.loadedAt.IsZero() && time.Since(l.loadedAt) < CacheDuration {
+		return l.file
+	}
+
+	for _, path := range l.paths {
+		data, err := l.client.GetFileContent(path)
+		if err != nil {
+			if gh.IsNotFound(err) {
+				continue
+			}
+			// keep the stale one, loading would be retried next time.
+			return l.file
+		}
+
+		file, err := Parse(data)
+		if err != nil {
+			logrus.Warnf("failed to load %s: %v", path, err)
+			break
+		}
+		l.file, l.loadedAt = file, time.Now()
+		return l.file
+	}
+
+	l.file, l.loadedAt = nil, time.Now()
+	return nil
+}
+
+// Owners returns owners of each file, files without owners are omitted.
+func (l *Loader) Owners(files []string) map[string][]string {
+	file := l.Get()
+	if file == nil {
+		return nil
+	}
+
+	owners := map[string][]string{}
+	for _, path := range files {
+		if fileOwners := file.Owners(path); len(fileOwners) != 0 {
+			owners[path] = fileOwners
+		}
+	}
+	return owners
+}
+
+// AreaLabels returns all area labels which could be derived from owners.
+func (l *Loader) AreaLabels() []string {
+	var labels []string
+	for _, area := range l.areas {
+		labels = append(labels, AreaLabelPrefix+strings.TrimPrefix(area, AreaLabelPrefix))
+	}
+	sort.Strings(labels)
+	return labels
+}
+
+// Labels returns area labels of files derived from their owners.
+func (l *Loader) Labels(files []string) []string {
+	set := map[string]bool{}
+	for _, fileOwners := range l.Owners(files) {
+		for _, owner := range fileOwners {
+			if area, ok := l.areas[strings.ToLower(owner)]; ok {
+				set[AreaLabelPrefix+strings.TrimPrefix(area, AreaLabelPrefix)] = true
+			}
+		}
+	}
+
+	var labels []string
+	for label := range set {
+		labels = append(labels, label)
+	}
+	sort.Strings(labels)
+	return labels
+}
@@ -0,0 +1,185 @@
+// Copyright 2018 The Pouch Robot Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/pouchcontainer/pouchrobot/config"
+	"github.com/pouchcontainer/pouchrobot/gh"
+	"github.com/pouchcontainer/pouchrobot/utils/translators"
+
+	"github.com/spf13/cobra"
+)
+
+// configCmd is the parent of all config related commands.
+var configCmd = &cobra.Command{
+	Use:   "config",
+	Short: "Manage config file of robot",
+	Args:  cobra.NoArgs,
+}
+
+// ConfigValidateCommand is used to implement 'config validate' command.
+type ConfigValidateCommand struct {
+	cmd *cobra.Command
+
+	// skipRemote skips checks which connect to GitHub and translator.
+	skipRemote bool
+
+	// failures is the number of failed checks.
+	failures int
+}
+
+func init() {
+	validateCommand := &ConfigValidateCommand{}
+	validateCommand.cmd = &cobra.Command{
+		Use:   "validate",
+		Short: "Validate config file and check services and paths it refers to",
+		Long: `Validate config file and check services and paths it refers to.
+
+Unknown fields, missing required fields and values out of range are reported
+as errors. Then robot checks whether the access token is accepted by GitHub
+and can read the repo, whether the translator accepts its credentials, and
+whether paths of doc generator exist.`,
+		Args:          cobra.NoArgs,
+		SilenceErrors: true,
+		SilenceUsage:  true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return validateCommand.runValidate(args)
+		},
+	}
+	validateCommand.addFlags()
+
+	configCmd.AddCommand(validateCommand.cmd)
+	rootCmd.AddCommand(configCmd)
+}
+
+// addFlags adds flags for specific command.
+func (v *ConfigValidateCommand) addFlags() {
+	flagSet := v.cmd.Flags()
+
+	flagSet.BoolVar(&v.skipRemote, "skip-remote", false, "skip checks connecting to GitHub and translator")
+}
+
+func (v *ConfigValidateCommand) runValidate(args []string) error {
+	cfg, err := config.Load(cmdCfg.ConfigFilePath)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("ok   config file %s is valid\n", cmdCfg.ConfigFilePath)
+
+	if !v.skipRemote {
+		v.checkGitHub(cfg)
+		v.checkTranslator(cfg.TranslatorConfig)
+	}
+	v.checkDocGenerator(cfg.DocGenerateConfig)
+
+	if v.failures != 0 {
+		return fmt.Errorf("%d checks failed", v.failures)
+	}
+	return nil
+}
+
+// checkGitHub checks whether access token is accepted and repo is accessible.
+func (v *ConfigValidateCommand) checkGitHub(cfg config.Config) {
+	client := gh.NewClient(cfg.Owner, cfg.Repo, cfg.AccessToken)
+
+	login, err := client.Login()
+	if err != nil {
+		v.fail("github: failed to authenticate with access token: %v", err)
+		return
+	}
+	v.ok("github: authenticated as %s", login)
+
+	if _, err := client.GetRepository(); err != nil {
+		v.fail("github: failed to get repo %s/%s: %v", cfg.Owner, cfg.Repo, err)
+		return
+	}
+	v.ok("github: repo %s/%s is accessible", cfg.Owner, cfg.Repo)
+}
+
+// checkTranslator checks whether translator accepts its credentials.
+func (v *ConfigValidateCommand) checkTranslator(cfg config.TranslatorConfig) {
+	if cfg.BaiduConfig.AppID == "" || config.IsPlaceholder(cfg.BaiduConfig.AppID) {
+		v.skip("translator: baidu appID is not set")
+		return
+	}
+
+	translator := translators.NewBaiduTranslator(translators.BaiduTranslatorOptions{
+		Appid: cfg.BaiduConfig.AppID,
+		Key:   cfg.BaiduConfig.Key,
+	})
+	checker, ok := translator.(translators.Checker)
+	if !ok {
+		v.skip("translator: baidu translator could not be checked")
+		return
+	}
+	if err := checker.Check(); err != nil {
+		v.fail("translator: failed to translate sample text: %v", err)
+		return
+	}
+	v.ok("translator: baidu translator works")
+}
+
+// checkDocGenerator checks whether paths of doc generator exist.
+func (v *ConfigValidateCommand) checkDocGenerator(cfg config.DocGenerateConfig) {
+	if cfg.RootDir == "" {
+		v.skip("docGenerator: rootDir is not set")
+		return
+	}
+
+	if info, err := os.Stat(cfg.RootDir); err != nil {
+		v.fail("docGenerator: rootDir: %v", err)
+		return
+	} else if !info.IsDir() {
+		v.fail("docGenerator: rootDir %s is not a directory", cfg.RootDir)
+		return
+	}
+	v.ok("docGenerator: rootDir %s exists", cfg.RootDir)
+
+	swaggerPath := filepath.Join(cfg.RootDir, cfg.SwaggerPath)
+	if info, err := os.Stat(swaggerPath); err != nil {
+		v.fail("docGenerator: swaggerPath: %v", err)
+	} else if info.IsDir() {
+		v.fail("docGenerator: swaggerPath %s is a directory", swaggerPath)
+	} else {
+		v.ok("docGenerator: swaggerPath %s exists", swaggerPath)
+	}
+
+	// API doc is generated by robot, so only its directory has to exist.
+	apiDocDir := filepath.Dir(filepath.Join(cfg.RootDir, cfg.APIDocPath))
+	if info, err := os.Stat(apiDocDir); err != nil {
+		v.fail("docGenerator: APIDocPath: %v", err)
+	} else if !info.IsDir() {
+		v.fail("docGenerator: directory of APIDocPath %s is not a directory", apiDocDir)
+	} else {
+		v.ok("docGenerator: directory of APIDocPath %s exists", apiDocDir)
+	}
+}
+
+func (v *ConfigValidateCommand) ok(format string, args ...interface{}) {
+	fmt.Printf("ok   "+format+"\n", args...)
+}
+
+func (v *ConfigValidateCommand) skip(format string, args ...interface{}) {
+	fmt.Printf("skip "+format+"\n", args...)
+}
+
+func (v *ConfigValidateCommand) fail(format string, args ...interface{}) {
+	v.failures++
+	fmt.Printf("FAIL "+format+"\n", args...)
+}
@@ -0,0 +1,29 @@
+// Copyright 2018 The Pouch Robot Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+// CodeOwnersConfig refers to config of CODEOWNERS file
+type CodeOwnersConfig struct {
+	// Enabled enables deriving area labels and candidate reviewers from CODEOWNERS file.
+	Enabled bool `json:"enabled"`
+
+	// File is the path of CODEOWNERS file in repo. ".github/CODEOWNERS",
+	// "CODEOWNERS" and "docs/CODEOWNERS" are looked up in order if it is empty.
+	File string `json:"file"`
+
+	// Areas maps owners like "@org/team" to areas like "network".
+	// Pull requests changing files owned by an owner get the label "areas/network".
+	Areas map[string]string `json:"areas"`
+}
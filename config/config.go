@@ -37,6 +37,10 @@ type Config struct {
 	// AccessToken is identify which github user this robot plays the role.
 	AccessToken string `json:"accessToken"`
 
+	// AccessTokenFile is the path of a file containing access token, like a
+	// mounted secret. It is used when AccessToken is empty.
+	AccessTokenFile string `json:"accessTokenFile"`
+
 	// FetcherConfig is configs for fetcher module
 	FetcherConfig FetcherConfig `json:"fetcher"`
 
@@ -48,6 +52,58 @@ type Config struct {
 
 	// WeeklyReportConfig is configs for weekly report module
 	WeeklyReportConfig WeeklyReportConfig `json:"weeklyReport"`
+
+	// SchedulerConfig is configs for scheduler of periodical jobs
+	SchedulerConfig SchedulerConfig `json:"scheduler"`
+
+	// StoreConfig is configs for persistent store of robot bookkeeping
+	StoreConfig StoreConfig `json:"store"`
+
+	// TemplatesConfig is configs for comment templates
+	TemplatesConfig TemplatesConfig `json:"templates"`
+
+	// TemplateCheckConfig is configs for checking issues and pull requests against templates in repo
+	TemplateCheckConfig TemplateCheckConfig `json:"templateCheck"`
+
+	// LabelsConfig is configs for label taxonomy
+	LabelsConfig LabelsConfig `json:"labels"`
+
+	// MaintainersConfig is configs for loading maintainers
+	MaintainersConfig MaintainersConfig `json:"maintainers"`
+
+	// OwnersConfig is configs for directory scoped OWNERS files
+	OwnersConfig OwnersConfig `json:"owners"`
+
+	// CodeOwnersConfig is configs for CODEOWNERS file
+	CodeOwnersConfig CodeOwnersConfig `json:"codeOwners"`
+
+	// ReviewersConfig is configs for automatic reviewer assignment
+	ReviewersConfig ReviewersConfig `json:"reviewers"`
+
+	// LifecycleConfig is configs for stale issue and pull request lifecycle
+	LifecycleConfig LifecycleConfig `json:"lifecycle"`
+
+	// MoreInfoConfig is configs for following up issues which need more information
+	MoreInfoConfig MoreInfoConfig `json:"moreInfo"`
+
+	// IssueLinksConfig is configs for linking pull requests with issues they fix
+	IssueLinksConfig IssueLinksConfig `json:"issueLinks"`
+
+	// MergeConfig is configs for actions after a pull request is merged
+	MergeConfig MergeConfig `json:"merge"`
+
+	// SizeConfig is configs for pull request size labels
+	SizeConfig SizeConfig `json:"size"`
+
+	// RulesConfig is configs for declarative automation rules
+	RulesConfig RulesConfig `json:"rules"`
+
+	// Plugins are external plugins receiving events and returning actions for robot to take.
+	Plugins []PluginConfig `json:"plugins"`
+
+	// LabelRules are rules to generate labels for issues and pull requests.
+	// Built-in rules are used if it is empty.
+	LabelRules []LabelRule `json:"labelRules"`
 }
 
 // NewConfig creates a brand new Config instance
@@ -32,5 +32,5 @@ type DocGenerateConfig struct {
 
 	// CliDocGeneratorCmd represents the command users input to generate cli
 	// related document.
-	CliDocGeneratorCmd string
+	CliDocGeneratorCmd string `json:"cliDocGeneratorCmd"`
 }
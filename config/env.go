@@ -0,0 +1,139 @@
+// Copyright 2018 The Pouch Robot Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// EnvPrefix is the prefix of environment variables overriding fields of config.
+const EnvPrefix = "POUCHROBOT_"
+
+// EnvName returns the environment variable overriding the field in path of
+// json names, like POUCHROBOT_WEEKLY_REPORT_REPORT_HOUR for
+// "weeklyReport.reportHour".
+func EnvName(path ...string) string {
+	words := make([]string, 0, len(path))
+	for _, name := range path {
+		words = append(words, upperSnake(name))
+	}
+	return EnvPrefix + strings.Join(words, "_")
+}
+
+// ApplyEnv overrides fields of config with environment variables returned by
+// lookup, which is usually os.LookupEnv. Strings are taken as they are,
+// numbers and booleans are parsed, and lists and maps are parsed as JSON.
+func (c *Config) ApplyEnv(lookup func(string) (string, bool)) error {
+	return applyEnv(reflect.ValueOf(c).Elem(), nil, lookup)
+}
+
+func applyEnv(v reflect.Value, path []string, lookup func(string) (string, bool)) error {
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if field.PkgPath != "" {
+			continue
+		}
+
+		// fields of embedded structs are promoted in JSON.
+		if field.Anonymous && field.Type.Kind() == reflect.Struct {
+			if err := applyEnv(v.Field(i), path, lookup); err != nil {
+				return err
+			}
+			continue
+		}
+
+		name := jsonName(field)
+		if name == "-" {
+			continue
+		}
+		fieldPath := append(append([]string{}, path...), name)
+
+		if field.Type.Kind() == reflect.Struct {
+			if err := applyEnv(v.Field(i), fieldPath, lookup); err != nil {
+				return err
+			}
+			continue
+		}
+
+		env := EnvName(fieldPath...)
+		value, ok := lookup(env)
+		if !ok {
+			continue
+		}
+		if err := setValue(v.Field(i), value); err != nil {
+			return fmt.Errorf("invalid environment variable %s: %v", env, err)
+		}
+	}
+	return nil
+}
+
+// setValue sets value in text to v according to its kind.
+func setValue(v reflect.Value, text string) error {
+	switch v.Kind() {
+	case reflect.String:
+		v.SetString(text)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		i, err := strconv.ParseInt(text, 10, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+		v.SetInt(i)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(text)
+		if err != nil {
+			return err
+		}
+		v.SetBool(b)
+	default:
+		ptr := reflect.New(v.Type())
+		if err := json.Unmarshal([]byte(text), ptr.Interface()); err != nil {
+			return err
+		}
+		v.Set(ptr.Elem())
+	}
+	return nil
+}
+
+// jsonName returns the name of field in JSON.
+func jsonName(field reflect.StructField) string {
+	name := strings.Split(field.Tag.Get("json"), ",")[0]
+	if name == "" {
+		return field.Name
+	}
+	return name
+}
+
+// upperSnake converts a camel case name like "APIDocPath" to "API_DOC_PATH".
+func upperSnake(name string) string {
+	runes := []rune(name)
+	var b strings.Builder
+	for i, r := range runes {
+		if i > 0 && unicode.IsUpper(r) {
+			prev := runes[i-1]
+			nextIsLower := i+1 < len(runes) && unicode.IsLower(runes[i+1])
+			if unicode.IsLower(prev) || unicode.IsDigit(prev) || (unicode.IsUpper(prev) && nextIsLower) {
+				b.WriteByte('_')
+			}
+		}
+		b.WriteRune(unicode.ToUpper(r))
+	}
+	return b.String()
+}
@@ -0,0 +1,89 @@
+// Copyright 2018 The Pouch Robot Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestEnvName(t *testing.T) {
+	tests := []struct {
+		path []string
+		want string
+	}{
+		{path: []string{"accessToken"}, want: "POUCHROBOT_ACCESS_TOKEN"},
+		{path: []string{"weeklyReport", "reportHour"}, want: "POUCHROBOT_WEEKLY_REPORT_REPORT_HOUR"},
+		{path: []string{"docGenerator", "APIDocPath"}, want: "POUCHROBOT_DOC_GENERATOR_API_DOC_PATH"},
+		{path: []string{"translator", "baidu", "appID"}, want: "POUCHROBOT_TRANSLATOR_BAIDU_APP_ID"},
+		{path: []string{"size", "thresholds", "XL"}, want: "POUCHROBOT_SIZE_THRESHOLDS_XL"},
+	}
+	for _, tt := range tests {
+		if got := EnvName(tt.path...); got != tt.want {
+			t.Errorf("EnvName(%v) = %s, want %s", tt.path, got, tt.want)
+		}
+	}
+}
+
+func TestApplyEnv(t *testing.T) {
+	env := map[string]string{
+		"POUCHROBOT_ACCESS_TOKEN":               "token",
+		"POUCHROBOT_WEEKLY_REPORT_REPORT_HOUR":  "9",
+		"POUCHROBOT_LIFECYCLE_ENABLED":          "true",
+		"POUCHROBOT_LIFECYCLE_STALE_DAYS":       "60",
+		"POUCHROBOT_LIFECYCLE_EXEMPT_LABELS":    `["lifecycle/frozen"]`,
+		"POUCHROBOT_CODE_OWNERS_AREAS":          `{"@org/network": "network"}`,
+		"POUCHROBOT_TRANSLATOR_BAIDU_KEY_FILE":  "/secrets/key",
+		"POUCHROBOT_DOC_GENERATOR_API_DOC_PATH": "docs/api.md",
+	}
+	lookup := func(name string) (string, bool) {
+		value, ok := env[name]
+		return value, ok
+	}
+
+	cfg := Config{AccessToken: "old", WeeklyReportConfig: WeeklyReportConfig{ReportDay: "Friday", ReportHour: 17}}
+	if err := cfg.ApplyEnv(lookup); err != nil {
+		t.Fatalf("ApplyEnv() error = %v", err)
+	}
+
+	want := Config{
+		AccessToken:        "token",
+		WeeklyReportConfig: WeeklyReportConfig{ReportDay: "Friday", ReportHour: 9},
+		LifecycleConfig: LifecycleConfig{
+			Enabled:         true,
+			LifecycleStages: LifecycleStages{StaleDays: 60},
+			ExemptLabels:    []string{"lifecycle/frozen"},
+		},
+		CodeOwnersConfig:  CodeOwnersConfig{Areas: map[string]string{"@org/network": "network"}},
+		TranslatorConfig:  TranslatorConfig{BaiduConfig: BaiduTranslateConfig{KeyFile: "/secrets/key"}},
+		DocGenerateConfig: DocGenerateConfig{APIDocPath: "docs/api.md"},
+	}
+	if !reflect.DeepEqual(cfg, want) {
+		t.Errorf("ApplyEnv() = %+v, want %+v", cfg, want)
+	}
+}
+
+func TestApplyEnvInvalid(t *testing.T) {
+	lookup := func(name string) (string, bool) {
+		return "seventeen", name == "POUCHROBOT_WEEKLY_REPORT_REPORT_HOUR"
+	}
+
+	cfg := Config{}
+	err := cfg.ApplyEnv(lookup)
+	if err == nil || !strings.Contains(err.Error(), "POUCHROBOT_WEEKLY_REPORT_REPORT_HOUR") {
+		t.Errorf("ApplyEnv() error = %v, want invalid POUCHROBOT_WEEKLY_REPORT_REPORT_HOUR", err)
+	}
+}
@@ -0,0 +1,26 @@
+// Copyright 2018 The Pouch Robot Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+// IssueLinksConfig refers to config of linking pull requests with issues
+// they fix via closing keywords like "fixes #123".
+type IssueLinksConfig struct {
+	// Enabled enables commenting and labeling issues referenced by pull requests.
+	Enabled bool `json:"enabled"`
+
+	// CloseOnMerge closes referenced issues once pull request is merged.
+	// Otherwise issues are labeled status/fixed and left open.
+	CloseOnMerge bool `json:"closeOnMerge"`
+}
@@ -0,0 +1,46 @@
+// Copyright 2018 The Pouch Robot Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+// LabelRule refers to a rule which attaches Label to an issue or a pull request
+// when any of its patterns matches the text in its scopes.
+type LabelRule struct {
+	// Label is the label to attach when the rule matches.
+	Label string `json:"label" yaml:"label"`
+
+	// Scopes specifies which parts the rule applies to.
+	// Valid values are "title", "body" and "files". Default is "title",
+	// or "files" if the rule only has paths.
+	Scopes []string `json:"scopes" yaml:"scopes"`
+
+	// Keywords are case-insensitive sub strings.
+	Keywords []string `json:"keywords" yaml:"keywords"`
+
+	// Words are case-insensitive whole words or phrases, which means that
+	// word "ci" matches "fix ci" while it does not match "specific".
+	Words []string `json:"words" yaml:"words"`
+
+	// Regexes are regular expressions in RE2 syntax.
+	// Add flag (?i) to make them case-insensitive.
+	Regexes []string `json:"regexes" yaml:"regexes"`
+
+	// Paths are gitignore style patterns of changed files like "network/**"
+	// or "*.md". They only apply to "files" scope.
+	Paths []string `json:"paths" yaml:"paths"`
+
+	// Excludes are regular expressions in RE2 syntax. Rule does not apply to
+	// the text if any of them matches, even if other patterns match.
+	Excludes []string `json:"excludes" yaml:"excludes"`
+}
@@ -0,0 +1,23 @@
+// Copyright 2018 The Pouch Robot Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+// LabelsConfig refers to label taxonomy config
+type LabelsConfig struct {
+	// File is the path of a JSON or YAML file declaring labels of the repo,
+	// including name, color, description and aliases of each label.
+	// Labels robot attaches by itself are always declared.
+	File string `json:"file"`
+}
@@ -0,0 +1,53 @@
+// Copyright 2018 The Pouch Robot Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+// LifecycleConfig refers to config of stale issue and pull request lifecycle
+type LifecycleConfig struct {
+	// Enabled enables marking inactive issues and pull requests stale,
+	// rotten and closing them at last.
+	Enabled bool `json:"enabled"`
+
+	// LifecycleStages are default durations of each stage.
+	LifecycleStages
+
+	// ExemptLabels are labels which exempt issues and pull requests from lifecycle.
+	// Default is "priority/P1" and "lifecycle/frozen".
+	ExemptLabels []string `json:"exemptLabels"`
+
+	// LabelStages override durations of stages for issues and pull requests
+	// with a label. The first matching one takes effect.
+	LabelStages []LabelLifecycleStages `json:"labelStages"`
+}
+
+// LifecycleStages are durations of lifecycle stages. Zero means default.
+type LifecycleStages struct {
+	// StaleDays is days without activity before becoming stale. Default is 90.
+	StaleDays int `json:"staleDays"`
+
+	// RottenDays is days of being stale before becoming rotten. Default is 30.
+	RottenDays int `json:"rottenDays"`
+
+	// CloseDays is days of being rotten before being closed. Default is 30.
+	CloseDays int `json:"closeDays"`
+}
+
+// LabelLifecycleStages are durations of lifecycle stages for a label.
+type LabelLifecycleStages struct {
+	// Label is the label these stages apply to.
+	Label string `json:"label"`
+
+	LifecycleStages
+}
@@ -0,0 +1,264 @@
+// Copyright 2018 The Pouch Robot Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/pouchcontainer/pouchrobot/scheduler"
+	"github.com/pouchcontainer/pouchrobot/utils"
+
+	"gopkg.in/yaml.v2"
+)
+
+// vacationLayout is the date layout of Vacation.Until.
+const vacationLayout = "2006-01-02"
+
+// Load reads, parses and validates the config file in path. The file is
+// parsed as YAML if its extension is .yaml or .yml, otherwise as JSON. Fields
+// are then overridden by POUCHROBOT_* environment variables, and secrets are
+// read from files set by *File fields.
+func Load(path string) (Config, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return Config{}, err
+	}
+
+	parse := Parse
+	if ext := filepath.Ext(path); ext == ".yaml" || ext == ".yml" {
+		parse = ParseYAML
+	}
+	cfg, err := parse(data)
+	if err != nil {
+		return cfg, fmt.Errorf("failed to parse config file %s: %v", path, err)
+	}
+
+	if err := cfg.ApplyEnv(os.LookupEnv); err != nil {
+		return cfg, err
+	}
+	if err := cfg.ReadSecretFiles(); err != nil {
+		return cfg, err
+	}
+	if err := cfg.Validate(); err != nil {
+		return cfg, fmt.Errorf("invalid config file %s: %v", path, err)
+	}
+	return cfg, nil
+}
+
+// Parse parses config in JSON data. Unknown fields are rejected, since they
+// are likely typos which would otherwise be ignored silently.
+func Parse(data []byte) (Config, error) {
+	cfg := NewConfig()
+
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&cfg); err != nil {
+		return cfg, err
+	}
+	return cfg, nil
+}
+
+// ParseYAML parses config in YAML data. Fields are named the same as in JSON.
+func ParseYAML(data []byte) (Config, error) {
+	var doc interface{}
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return NewConfig(), err
+	}
+
+	jsonDoc, err := toJSONValue(doc)
+	if err != nil {
+		return NewConfig(), err
+	}
+	jsonData, err := json.Marshal(jsonDoc)
+	if err != nil {
+		return NewConfig(), err
+	}
+	return Parse(jsonData)
+}
+
+// toJSONValue converts maps decoded from YAML, whose keys could be any type,
+// to maps with string keys which could be encoded in JSON.
+func toJSONValue(v interface{}) (interface{}, error) {
+	switch v := v.(type) {
+	case map[interface{}]interface{}:
+		// keys are sorted to report the same error on every run.
+		keys := make([]interface{}, 0, len(v))
+		for key := range v {
+			keys = append(keys, key)
+		}
+		sort.Slice(keys, func(i, j int) bool { return fmt.Sprint(keys[i]) < fmt.Sprint(keys[j]) })
+
+		m := make(map[string]interface{}, len(v))
+		for _, key := range keys {
+			k, ok := key.(string)
+			if !ok {
+				return nil, fmt.Errorf("key %v is not a string", key)
+			}
+			converted, err := toJSONValue(v[key])
+			if err != nil {
+				return nil, err
+			}
+			m[k] = converted
+		}
+		return m, nil
+	case []interface{}:
+		for i, value := range v {
+			converted, err := toJSONValue(value)
+			if err != nil {
+				return nil, err
+			}
+			v[i] = converted
+		}
+		return v, nil
+	}
+	return v, nil
+}
+
+// Validate checks required fields, types and ranges of values in config.
+func (c Config) Validate() error {
+	for _, field := range []struct {
+		name  string
+		value string
+	}{
+		{"owner", c.Owner},
+		{"repo", c.Repo},
+		{"accessToken", c.AccessToken},
+	} {
+		if field.value == "" {
+			return fmt.Errorf("%s is required", field.name)
+		}
+		if IsPlaceholder(field.value) {
+			return fmt.Errorf("%s is still the placeholder %q in config template", field.name, field.value)
+		}
+	}
+
+	if c.HTTPListen != "" {
+		if _, _, err := net.SplitHostPort(c.HTTPListen); err != nil {
+			return fmt.Errorf("httpListen must be an address like 0.0.0.0:6789: %v", err)
+		}
+	}
+
+	if day := c.WeeklyReportConfig.ReportDay; day != "" && !utils.IsWeekday(day) {
+		return fmt.Errorf("weeklyReport.reportDay must be a weekday like Friday, got %q", day)
+	}
+	if hour := c.WeeklyReportConfig.ReportHour; hour < 0 || hour > 23 {
+		return fmt.Errorf("weeklyReport.reportHour must be in 0-23, got %d", hour)
+	}
+	if hour := c.DocGenerateConfig.GenerationHour; hour < 0 || hour > 23 {
+		return fmt.Errorf("docGenerator.generationHour must be in 0-23, got %d", hour)
+	}
+
+	if _, err := time.LoadLocation(c.SchedulerConfig.TimeZone); err != nil {
+		return fmt.Errorf("scheduler.timeZone must be an IANA time zone like Asia/Shanghai: %v", err)
+	}
+	jobs := make([]string, 0, len(c.SchedulerConfig.Jobs))
+	for name := range c.SchedulerConfig.Jobs {
+		jobs = append(jobs, name)
+	}
+	sort.Strings(jobs)
+	for _, name := range jobs {
+		switch name {
+		case "fetcher", "weekly-report", "doc-generator", "lifecycle", "more-info", "maintainers":
+		default:
+			return fmt.Errorf("scheduler.jobs has unknown job %q, valid jobs are fetcher, weekly-report, doc-generator, lifecycle, more-info and maintainers", name)
+		}
+		if _, err := scheduler.Parse(c.SchedulerConfig.Jobs[name]); err != nil {
+			return fmt.Errorf("scheduler.jobs.%s: %v", name, err)
+		}
+	}
+
+	type nonNegative struct {
+		name  string
+		value int
+	}
+	nonNegatives := []nonNegative{
+		{"fetcher.commitsGap", c.FetcherConfig.CommitsGap},
+		{"scheduler.jitterSeconds", c.SchedulerConfig.JitterSeconds},
+		{"maintainers.refreshMinutes", c.MaintainersConfig.RefreshMinutes},
+		{"reviewers.count", c.ReviewersConfig.Count},
+		{"moreInfo.remindDays", c.MoreInfoConfig.RemindDays},
+		{"moreInfo.closeDays", c.MoreInfoConfig.CloseDays},
+		{"lifecycle.staleDays", c.LifecycleConfig.StaleDays},
+		{"lifecycle.rottenDays", c.LifecycleConfig.RottenDays},
+		{"lifecycle.closeDays", c.LifecycleConfig.CloseDays},
+		{"size.thresholds.XS", c.SizeConfig.Thresholds.XS},
+		{"size.thresholds.S", c.SizeConfig.Thresholds.S},
+		{"size.thresholds.M", c.SizeConfig.Thresholds.M},
+		{"size.thresholds.L", c.SizeConfig.Thresholds.L},
+		{"size.thresholds.XL", c.SizeConfig.Thresholds.XL},
+	}
+	for i, stages := range c.LifecycleConfig.LabelStages {
+		if stages.Label == "" {
+			return fmt.Errorf("lifecycle.labelStages[%d].label is required", i)
+		}
+		nonNegatives = append(nonNegatives, nonNegative{fmt.Sprintf("lifecycle.labelStages[%d].staleDays", i), stages.StaleDays})
+		nonNegatives = append(nonNegatives, nonNegative{fmt.Sprintf("lifecycle.labelStages[%d].rottenDays", i), stages.RottenDays})
+		nonNegatives = append(nonNegatives, nonNegative{fmt.Sprintf("lifecycle.labelStages[%d].closeDays", i), stages.CloseDays})
+	}
+	for i, plugin := range c.Plugins {
+		nonNegatives = append(nonNegatives, nonNegative{fmt.Sprintf("plugins[%d].timeoutSeconds", i), plugin.TimeoutSeconds})
+		nonNegatives = append(nonNegatives, nonNegative{fmt.Sprintf("plugins[%d].retries", i), plugin.Retries})
+	}
+	for _, field := range nonNegatives {
+		if field.value < 0 {
+			return fmt.Errorf("%s must not be negative, got %d", field.name, field.value)
+		}
+	}
+
+	switch c.MaintainersConfig.Format {
+	case "", "toml", "yaml", "plain":
+	default:
+		return fmt.Errorf("maintainers.format must be one of toml, yaml and plain, got %q", c.MaintainersConfig.Format)
+	}
+	for i, login := range c.MaintainersConfig.Fallback {
+		if strings.TrimSpace(login) == "" {
+			return fmt.Errorf("maintainers.fallback[%d] must not be empty", i)
+		}
+	}
+
+	for i, vacation := range c.ReviewersConfig.Vacations {
+		if vacation.Login == "" {
+			return fmt.Errorf("reviewers.vacations[%d].login is required", i)
+		}
+		if vacation.Until == "" {
+			continue
+		}
+		if _, err := time.Parse(vacationLayout, vacation.Until); err != nil {
+			return fmt.Errorf("reviewers.vacations[%d].until must be a date like 2006-01-02, got %q", i, vacation.Until)
+		}
+	}
+
+	for i, rule := range c.LabelRules {
+		if rule.Label == "" {
+			return fmt.Errorf("labelRules[%d].label is required", i)
+		}
+	}
+	return nil
+}
+
+// IsPlaceholder returns whether value is a placeholder like "%Your github repo%"
+// in config template, which should be replaced with a real value.
+func IsPlaceholder(value string) bool {
+	return len(value) > 1 && strings.HasPrefix(value, "%") && strings.HasSuffix(value, "%")
+}
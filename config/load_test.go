@@ -0,0 +1,184 @@
+// Copyright 2018 The Pouch Robot Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestParseTemplate(t *testing.T) {
+	data, err := ioutil.ReadFile("../config_template.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cfg, err := Parse(data)
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	if cfg.DocGenerateConfig.CliDocGeneratorCmd == "" || cfg.DocGenerateConfig.GenerationHour != 6 {
+		t.Errorf("Parse() docGenerator = %+v", cfg.DocGenerateConfig)
+	}
+
+	// template is valid once placeholders are filled.
+	cfg.Owner, cfg.Repo, cfg.AccessToken = "pouchcontainer", "pouch", "token"
+	if err := cfg.Validate(); err != nil {
+		t.Errorf("Validate() error = %v", err)
+	}
+}
+
+func TestParseUnknownField(t *testing.T) {
+	_, err := Parse([]byte(`{"owner": "a", "weeklyReport": {"reportDays": "Friday"}}`))
+	if err == nil || !strings.Contains(err.Error(), "reportDays") {
+		t.Errorf("Parse() error = %v, want unknown field reportDays", err)
+	}
+}
+
+func TestValidate(t *testing.T) {
+	valid := func() Config {
+		return Config{Owner: "pouchcontainer", Repo: "pouch", AccessToken: "token", HTTPListen: "0.0.0.0:6789"}
+	}
+
+	tests := []struct {
+		name   string
+		modify func(c *Config)
+		err    string
+	}{
+		{name: "valid", modify: func(c *Config) {}},
+		{name: "missing owner", modify: func(c *Config) { c.Owner = "" }, err: "owner is required"},
+		{name: "placeholder", modify: func(c *Config) { c.AccessToken = "%Your github access token%" }, err: "accessToken"},
+		{name: "listen", modify: func(c *Config) { c.HTTPListen = "6789" }, err: "httpListen"},
+		{name: "weekday", modify: func(c *Config) { c.WeeklyReportConfig.ReportDay = "friday" }, err: "reportDay"},
+		{name: "report hour", modify: func(c *Config) { c.WeeklyReportConfig.ReportHour = 24 }, err: "reportHour"},
+		{name: "generation hour", modify: func(c *Config) { c.DocGenerateConfig.GenerationHour = -1 }, err: "generationHour"},
+		{name: "negative", modify: func(c *Config) { c.FetcherConfig.CommitsGap = -1 }, err: "commitsGap"},
+		{name: "first of missing fields", modify: func(c *Config) { c.Owner, c.Repo, c.AccessToken = "", "", "" }, err: "owner is required"},
+		{name: "first of negatives", modify: func(c *Config) {
+			c.FetcherConfig.CommitsGap, c.ReviewersConfig.Count, c.SizeConfig.Thresholds.XL = -1, -1, -1
+		}, err: "fetcher.commitsGap must not be negative"},
+		{name: "first of invalid jobs", modify: func(c *Config) {
+			c.SchedulerConfig.Jobs = map[string]string{"more-info": "bad", "fetcher": "bad", "lifecycle": "bad"}
+		}, err: "scheduler.jobs.fetcher"},
+		{name: "label stage", modify: func(c *Config) {
+			c.LifecycleConfig.LabelStages = []LabelLifecycleStages{{Label: "kind/question", LifecycleStages: LifecycleStages{StaleDays: -1}}}
+		}, err: "labelStages[0].staleDays"},
+		{name: "format", modify: func(c *Config) { c.MaintainersConfig.Format = "json" }, err: "maintainers.format"},
+		{name: "fallback", modify: func(c *Config) { c.MaintainersConfig.Fallback = []string{"allencloud", " "} }, err: "maintainers.fallback[1]"},
+		{name: "vacation", modify: func(c *Config) {
+			c.ReviewersConfig.Vacations = []Vacation{{Login: "a", Until: "2018/01/02"}}
+		}, err: "vacations[0].until"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := valid()
+			tt.modify(&c)
+			err := c.Validate()
+			if tt.err == "" {
+				if err != nil {
+					t.Errorf("Validate() error = %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.err) {
+				t.Errorf("Validate() error = %v, want %q", err, tt.err)
+			}
+		})
+	}
+}
+
+func TestParseYAML(t *testing.T) {
+	data := `
+owner: pouchcontainer
+repo: pouch
+weeklyReport:
+  reportDay: Friday
+  reportHour: 17
+codeOwners:
+  areas:
+    "@pouchcontainer/network": network
+plugins:
+- name: dco
+  endpoint: http://localhost:8080
+  events: ["pull_request"]
+`
+	cfg, err := ParseYAML([]byte(data))
+	if err != nil {
+		t.Fatalf("ParseYAML() error = %v", err)
+	}
+	if cfg.Owner != "pouchcontainer" || cfg.WeeklyReportConfig.ReportHour != 17 ||
+		cfg.CodeOwnersConfig.Areas["@pouchcontainer/network"] != "network" ||
+		len(cfg.Plugins) != 1 || cfg.Plugins[0].Events[0] != "pull_request" {
+		t.Errorf("ParseYAML() = %+v", cfg)
+	}
+
+	if _, err := ParseYAML([]byte("weeklyReport:\n  reportDays: Friday\n")); err == nil || !strings.Contains(err.Error(), "reportDays") {
+		t.Errorf("ParseYAML() error = %v, want unknown field reportDays", err)
+	}
+}
+
+func TestLoadSecretFiles(t *testing.T) {
+	dir, err := ioutil.TempDir("", "config")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	tokenFile := filepath.Join(dir, "token")
+	if err := ioutil.WriteFile(tokenFile, []byte("secret-token\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	path := filepath.Join(dir, "config.yaml")
+	data := "owner: pouchcontainer\nrepo: pouch\naccessTokenFile: " + tokenFile + "\n"
+	if err := ioutil.WriteFile(path, []byte(data), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if cfg.AccessToken != "secret-token" {
+		t.Errorf("Load() accessToken = %q, want secret-token", cfg.AccessToken)
+	}
+
+	cfg = Config{AccessToken: "token", AccessTokenFile: tokenFile}
+	if err := cfg.ReadSecretFiles(); err == nil {
+		t.Errorf("ReadSecretFiles() succeeded with both accessToken and accessTokenFile")
+	}
+}
+
+func TestRedacted(t *testing.T) {
+	cfg := Config{
+		Owner:            "pouchcontainer",
+		AccessToken:      "secret-token",
+		TranslatorConfig: TranslatorConfig{BaiduConfig: BaiduTranslateConfig{AppID: "secret-appid"}},
+		Plugins:          []PluginConfig{{Name: "dco", Secret: "secret-plugin"}},
+	}
+
+	printed := cfg.String()
+	if strings.Contains(printed, "secret-") {
+		t.Errorf("String() leaks secrets: %s", printed)
+	}
+	if !strings.Contains(printed, "pouchcontainer") {
+		t.Errorf("String() = %s, want owner printed", printed)
+	}
+	if cfg.AccessToken != "secret-token" || cfg.Plugins[0].Secret != "secret-plugin" {
+		t.Errorf("Redacted() modifies original config")
+	}
+}
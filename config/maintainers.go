@@ -0,0 +1,34 @@
+// Copyright 2018 The Pouch Robot Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+// MaintainersConfig refers to config of loading maintainers from repository
+type MaintainersConfig struct {
+	// File is the path of maintainers file in the default branch of repo.
+	// Default is "MAINTAINERS".
+	File string `json:"file"`
+
+	// Format is the format of maintainers file, "toml", "yaml" or "plain".
+	// It is detected from file name and content if empty.
+	Format string `json:"format"`
+
+	// RefreshMinutes is the interval in minutes to reload maintainers file.
+	// Default is 60.
+	RefreshMinutes int `json:"refreshMinutes"`
+
+	// Fallback is a list of GitHub IDs treated as maintainers until
+	// maintainers file is loaded successfully.
+	Fallback []string `json:"fallback"`
+}
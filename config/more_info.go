@@ -0,0 +1,30 @@
+// Copyright 2018 The Pouch Robot Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+// MoreInfoConfig refers to config of following up issues which need more
+// information from their authors
+type MoreInfoConfig struct {
+	// Enabled enables reminding authors of issues labeled
+	// status/more-info-needed and closing these issues at last.
+	Enabled bool `json:"enabled"`
+
+	// RemindDays is days without response from author before a reminder. Default is 7.
+	RemindDays int `json:"remindDays"`
+
+	// CloseDays is days without response from author after the reminder
+	// before closing issue. Default is 7.
+	CloseDays int `json:"closeDays"`
+}
@@ -0,0 +1,26 @@
+// Copyright 2018 The Pouch Robot Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+// OwnersConfig refers to config of directory scoped OWNERS files
+type OwnersConfig struct {
+	// Enabled enables checking approvals of pull requests against OWNERS
+	// files in directories of repo.
+	Enabled bool `json:"enabled"`
+
+	// RequestReviewers requests reviewers listed in OWNERS files when a
+	// pull request is opened. Otherwise they are only suggested in comment.
+	RequestReviewers bool `json:"requestReviewers"`
+}
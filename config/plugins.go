@@ -0,0 +1,48 @@
+// Copyright 2018 The Pouch Robot Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+// PluginConfig refers to config of an external plugin, which is an HTTP
+// endpoint receiving events and returning actions for robot to take
+type PluginConfig struct {
+	// Name identifies plugin in logs and in markers of its comments.
+	Name string `json:"name"`
+
+	// Endpoint is the URL robot posts events to.
+	Endpoint string `json:"endpoint"`
+
+	// Events are webhook event types plugin subscribes to. "*" subscribes to all events.
+	Events []string `json:"events"`
+
+	// Permissions are types of actions plugin is allowed to take, like
+	// "addLabels", "removeLabels", "comment", "assign" and "close".
+	// Actions of other types are rejected.
+	Permissions []string `json:"permissions"`
+
+	// Secret signs requests to plugin with HMAC SHA256 in header
+	// X-Pouchrobot-Signature if it is not empty.
+	Secret string `json:"secret"`
+
+	// SecretFile is the path of a file containing secret. It is used when
+	// Secret is empty.
+	SecretFile string `json:"secretFile"`
+
+	// TimeoutSeconds is the timeout of each request to plugin. Default is 10.
+	TimeoutSeconds int `json:"timeoutSeconds"`
+
+	// Retries is times to retry a request which fails with a network error
+	// or a 5xx status. Default is 0.
+	Retries int `json:"retries"`
+}
@@ -0,0 +1,37 @@
+// Copyright 2018 The Pouch Robot Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+// ReviewersConfig refers to config of automatic reviewer assignment
+type ReviewersConfig struct {
+	// Enabled enables requesting reviewers when a pull request is opened.
+	Enabled bool `json:"enabled"`
+
+	// Count is the number of reviewers a pull request should have. Default is 2.
+	Count int `json:"count"`
+
+	// Vacations are users who are not available to review.
+	Vacations []Vacation `json:"vacations"`
+}
+
+// Vacation means a user is not available to review until a date.
+type Vacation struct {
+	// Login is the GitHub ID of user.
+	Login string `json:"login"`
+
+	// Until is the last day of vacation in format of "2006-01-02".
+	// User is unavailable indefinitely if it is empty.
+	Until string `json:"until"`
+}
@@ -0,0 +1,23 @@
+// Copyright 2018 The Pouch Robot Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+// RulesConfig refers to config of declarative automation rules
+type RulesConfig struct {
+	// File is the path of a JSON or YAML file declaring rules. Each rule takes
+	// actions on issues and pull requests matching its event and conditions.
+	// The file is reloaded once it is modified. No rules are applied if it is empty.
+	File string `json:"file"`
+}
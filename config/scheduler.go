@@ -0,0 +1,34 @@
+// Copyright 2018 The Pouch Robot Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+// SchedulerConfig refers to config of scheduler running periodical jobs
+type SchedulerConfig struct {
+	// TimeZone is the IANA time zone which schedules are in, like "Asia/Shanghai".
+	// Default is the local time zone of robot.
+	TimeZone string `json:"timeZone"`
+
+	// JitterSeconds delays each run of jobs by a random duration up to it.
+	JitterSeconds int `json:"jitterSeconds"`
+
+	// Jobs override schedules of jobs with cron expressions, keyed by job
+	// name, which is "fetcher", "weekly-report", "doc-generator", "lifecycle",
+	// "more-info" or "maintainers". By default fetcher runs every 3 minutes,
+	// weekly report runs at weeklyReport.reportHour on weeklyReport.reportDay,
+	// doc generator runs at docGenerator.generationHour every day, lifecycle
+	// and more info checks run every hour and maintainers are refreshed every
+	// maintainers.refreshMinutes.
+	Jobs map[string]string `json:"jobs"`
+}
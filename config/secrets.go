@@ -0,0 +1,93 @@
+// Copyright 2018 The Pouch Robot Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"strings"
+)
+
+// redacted replaces secrets when config is printed.
+const redacted = "<redacted>"
+
+// secretField is a secret in config and the file it could be read from.
+type secretField struct {
+	name  string
+	value *string
+	file  string
+}
+
+// ReadSecretFiles reads secrets from files set by *File fields. It is an error
+// to set both a secret and its file, since it is unclear which one is wanted.
+func (c *Config) ReadSecretFiles() error {
+	secrets := []secretField{
+		{"accessToken", &c.AccessToken, c.AccessTokenFile},
+		{"translator.baidu.appID", &c.TranslatorConfig.BaiduConfig.AppID, c.TranslatorConfig.BaiduConfig.AppIDFile},
+		{"translator.baidu.key", &c.TranslatorConfig.BaiduConfig.Key, c.TranslatorConfig.BaiduConfig.KeyFile},
+	}
+	for i := range c.Plugins {
+		plugin := &c.Plugins[i]
+		secrets = append(secrets, secretField{fmt.Sprintf("plugins[%d].secret", i), &plugin.Secret, plugin.SecretFile})
+	}
+
+	for _, secret := range secrets {
+		if secret.file == "" {
+			continue
+		}
+		if *secret.value != "" {
+			return fmt.Errorf("only one of %s and %sFile could be set", secret.name, secret.name)
+		}
+		data, err := ioutil.ReadFile(secret.file)
+		if err != nil {
+			return fmt.Errorf("failed to read %sFile: %v", secret.name, err)
+		}
+		*secret.value = strings.TrimSpace(string(data))
+	}
+	return nil
+}
+
+// Redacted returns a copy of config with secrets replaced, which is safe to print.
+func (c Config) Redacted() Config {
+	redact := func(secret *string) {
+		if *secret != "" {
+			*secret = redacted
+		}
+	}
+
+	redact(&c.AccessToken)
+	redact(&c.TranslatorConfig.BaiduConfig.AppID)
+	redact(&c.TranslatorConfig.BaiduConfig.Key)
+
+	plugins := make([]PluginConfig, len(c.Plugins))
+	copy(plugins, c.Plugins)
+	for i := range plugins {
+		redact(&plugins[i].Secret)
+	}
+	if c.Plugins != nil {
+		c.Plugins = plugins
+	}
+	return c
+}
+
+// String returns config in JSON with secrets redacted.
+func (c Config) String() string {
+	data, err := json.MarshalIndent(c.Redacted(), "", "    ")
+	if err != nil {
+		return fmt.Sprintf("failed to marshal config: %v", err)
+	}
+	return string(data)
+}
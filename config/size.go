@@ -0,0 +1,39 @@
+// Copyright 2018 The Pouch Robot Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+// SizeConfig refers to config of pull request size labels
+type SizeConfig struct {
+	// Thresholds are the maximum changed lines of each size.
+	// Pull requests changing more lines than XL are XXL.
+	Thresholds SizeThresholds `json:"thresholds"`
+
+	// Ignore are gitignore style patterns of files which are not counted in
+	// size, like vendored or generated files. Default ignores vendor/**,
+	// *.pb.go, generated go files and docs/api/**.
+	Ignore []string `json:"ignore"`
+
+	// Comment enables a comment which breaks the size down by code, tests and docs.
+	Comment bool `json:"comment"`
+}
+
+// SizeThresholds are the maximum changed lines of each size. Zero means default.
+type SizeThresholds struct {
+	XS int `json:"XS"`
+	S  int `json:"S"`
+	M  int `json:"M"`
+	L  int `json:"L"`
+	XL int `json:"XL"`
+}
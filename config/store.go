@@ -0,0 +1,24 @@
+// Copyright 2018 The Pouch Robot Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+// StoreConfig refers to config of persistent store, which keeps bookkeeping of
+// robot across restarts, like repository stats of the last weekly report and
+// last run times of scheduled jobs
+type StoreConfig struct {
+	// File is the path of the file store is persisted to. Bookkeeping is
+	// kept in memory and lost on restart if it is empty.
+	File string `json:"file"`
+}
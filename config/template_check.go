@@ -0,0 +1,35 @@
+// Copyright 2018 The Pouch Robot Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+// TemplateCheckConfig refers to config of checking issues and pull requests against templates in repo
+type TemplateCheckConfig struct {
+	// Issues enables checking issue descriptions against .github/ISSUE_TEMPLATE.md
+	// and templates in .github/ISSUE_TEMPLATE. Only length of description
+	// is checked if it is disabled or repo has no issue templates.
+	Issues bool `json:"issues"`
+
+	// PullRequests enables checking pull request descriptions against
+	// .github/PULL_REQUEST_TEMPLATE.md. Result is reported in a comment and
+	// a commit status. Only length of description is checked if it is
+	// disabled or repo has no pull request template.
+	PullRequests bool `json:"pullRequests"`
+
+	// OptionalSections are titles of sections which could be left empty.
+	// A section is optional if its title contains any of them.
+	// Default is "Anything else we need to know", "Additional context",
+	// "Screenshots" and "Special notes for reviews".
+	OptionalSections []string `json:"optionalSections"`
+}
@@ -0,0 +1,27 @@
+// Copyright 2018 The Pouch Robot Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+// TemplatesConfig refers to comment templates config
+type TemplatesConfig struct {
+	// Dir is the directory to load comment templates from.
+	// A template file is named after the comment it renders with suffix .tmpl,
+	// and per-language variants are put in a sub directory named by the language,
+	// like <dir>/zh/pr-conflict.tmpl. Built-in defaults are used for missing ones.
+	Dir string `json:"dir"`
+
+	// Language selects the language variant of comments for the repo, like "en" or "zh".
+	Language string `json:"language"`
+}
@@ -27,4 +27,10 @@ type BaiduTranslateConfig struct {
 
 	// Key is the appid for baidu translator init
 	Key string `json:"key"`
+
+	// AppIDFile is the path of a file containing appid. It is used when AppID is empty.
+	AppIDFile string `json:"appIDFile"`
+
+	// KeyFile is the path of a file containing key. It is used when Key is empty.
+	KeyFile string `json:"keyFile"`
 }
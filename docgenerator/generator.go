@@ -18,6 +18,7 @@ import (
 	"fmt"
 	"os/exec"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/pouchcontainer/pouchrobot/gh"
@@ -31,6 +32,9 @@ var ErrNothingChanged = fmt.Errorf("nothing to commit")
 
 // Generator is a processor that periodically auto generated cli and api docs for github repo.
 type Generator struct {
+	// RWMutex protects settings below, which could be reloaded.
+	sync.RWMutex
+
 	client *gh.Client
 
 	// Owner is the organization of open source project.
@@ -64,8 +68,8 @@ func New(client *gh.Client,
 	rootdir, swaggerPath, apiDocPath string,
 	generationHour int,
 	cliDocGeneratorCmd string) (*Generator, error) {
-	if generationHour < 0 || generationHour > 23 {
-		return nil, fmt.Errorf("flag doc-generation-hour must be in range [0, 23]")
+	if err := CheckGenerationHour(generationHour); err != nil {
+		return nil, err
 	}
 	g := &Generator{
 		client:             client,
@@ -80,28 +84,58 @@ func New(client *gh.Client,
 	return g, nil
 }
 
-// Run starts periodical work of doc generator.
-// currently generator generates doc every day.
-func (g *Generator) Run() error {
-	logrus.Infof("start to run doc generator")
-	for {
-		// Break the loop if the time passes one clock
-		// Since time zone container maybe has a delta 8 hours from Beijing Time.
-		hour, _, _ := time.Now().Clock()
-		logrus.Infof("DocGenerator: now it is %d o'clock.", hour)
-		if hour == g.GenerationHour {
-			break
-		}
-		time.Sleep(30 * time.Minute)
+// CheckGenerationHour returns an error if generationHour is not an hour of day.
+func CheckGenerationHour(generationHour int) error {
+	if generationHour < 0 || generationHour > 23 {
+		return fmt.Errorf("flag doc-generation-hour must be in range [0, 23]")
 	}
+	return nil
+}
+
+// Reload replaces settings of generator. generationHour should have been
+// checked by CheckGenerationHour.
+func (g *Generator) Reload(rootdir, swaggerPath, apiDocPath string, generationHour int, cliDocGeneratorCmd string) {
+	g.Lock()
+	g.RootDir = rootdir
+	g.SwaggerPath = swaggerPath
+	g.APIDocPath = apiDocPath
+	g.GenerationHour = generationHour
+	g.CliDocGeneratorCmd = cliDocGeneratorCmd
+	g.Unlock()
+}
 
-	for {
-		go g.generateDoc()
-		// generate cli and api docs every day.
-		time.Sleep(24 * time.Hour)
+// snapshot returns a generator with settings in effect, which are not
+// changed by reloading while docs are being generated.
+func (g *Generator) snapshot() *Generator {
+	g.RLock()
+	defer g.RUnlock()
+	return &Generator{
+		client:             g.client,
+		owner:              g.owner,
+		repo:               g.repo,
+		RootDir:            g.RootDir,
+		SwaggerPath:        g.SwaggerPath,
+		APIDocPath:         g.APIDocPath,
+		GenerationHour:     g.GenerationHour,
+		CliDocGeneratorCmd: g.CliDocGeneratorCmd,
 	}
 }
 
+// Schedule returns the cron expression of doc generation in effect.
+// Docs are generated at GenerationHour every day.
+func (g *Generator) Schedule() string {
+	g.RLock()
+	defer g.RUnlock()
+	return fmt.Sprintf("0 %d * * *", g.GenerationHour)
+}
+
+// Generate generates cli and api docs and submits them in a pull request.
+// It is run by scheduler, and settings reloaded meanwhile take effect in the
+// next generation.
+func (g *Generator) Generate() error {
+	return g.snapshot().generateDoc()
+}
+
 // generateDoc starts to generate all docs.
 func (g *Generator) generateDoc() error {
 	newBranchName := generatenewBranchNameName()
@@ -230,38 +264,45 @@ func (g *Generator) sumbitPR(branch string) error {
 	title := fmt.Sprintf("docs: auto generate %s cli/api/contributors docs via code", g.repo)
 	head := fmt.Sprintf("pouchrobot:%s", branch)
 	base := "master"
-	body := fmt.Sprintf(`Signed-off-by: pouchrobot <pouch-dev@alibaba-inc.com>
+	body := pullRequestBody(g.CliDocGeneratorCmd)
 
-**1.Describe what this PR did**
-This PR is automatically done by AI-based collaborating [robot](https://github.com/pouchcontainer/pouchrobot).
-Pouchrobot will auto-generate cli/api document via https://github.com/spf13/cobra/tree/master/doc every day.
+	newPR := &github.NewPullRequest{
+		Title: &title,
+		Head:  &head,
+		Base:  &base,
+		Body:  &body,
+	}
 
-**2.Does this pull request fix one issue?**
-None
+	_, err := g.client.CreatePR(newPR)
+	return err
+}
+
+// pullRequestBody returns description of pull request of generated docs,
+// which follows pull request template of repo.
+func pullRequestBody(cliDocGeneratorCmd string) string {
+	return fmt.Sprintf(`Signed-off-by: pouchrobot <pouch-dev@alibaba-inc.com>
 
-**3.Describe how you did it**
+### Ⅰ. Describe what this PR did
+This PR is automatically done by AI-based collaborating [robot](https://github.com/pouchcontainer/pouchrobot).
+Pouchrobot will auto-generate cli/api document via https://github.com/spf13/cobra/tree/master/doc every day.
 We use the following user input CLI document generating command in pouchrobot to generate CLI doc: 
 %s
 
 For API part, we use a tool swagger2markup to make it.
 
-**4.Describe how to verify it**
+### Ⅱ. Does this pull request fix one issue?
+NONE
+
+### Ⅲ. Why don't you add test cases (unit test/integration test)? (你真的觉得不需要加测试吗？)
+Only generated documents are changed.
+
+### Ⅳ. Describe how to verify it
 None
 
-**5.Special notes for reviews**
+### Ⅴ. Special notes for reviews
 The cli/api doc must be automatically generated.`,
-		g.CliDocGeneratorCmd,
+		cliDocGeneratorCmd,
 	)
-
-	newPR := &github.NewPullRequest{
-		Title: &title,
-		Head:  &head,
-		Base:  &base,
-		Body:  &body,
-	}
-
-	_, err := g.client.CreatePR(newPR)
-	return err
 }
 
 func generatenewBranchNameName() string {
@@ -0,0 +1,37 @@
+// Copyright 2018 The Pouch Robot Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package docgenerator
+
+import (
+	"io/ioutil"
+	"testing"
+
+	"github.com/pouchcontainer/pouchrobot/repotemplates"
+)
+
+func TestPullRequestBody(t *testing.T) {
+	data, err := ioutil.ReadFile("../.github/PULL_REQUEST_TEMPLATE.md")
+	if err != nil {
+		t.Fatal(err)
+	}
+	tmpl := repotemplates.ParseMarkdown(".github/PULL_REQUEST_TEMPLATE.md", data)
+	tmpl.SetOptional(repotemplates.DefaultOptionalSections)
+
+	for _, cmd := range []string{"", "make cli-doc"} {
+		if missing := tmpl.Missing(pullRequestBody(cmd)); len(missing) != 0 {
+			t.Errorf("pull request of generated docs with command %q misses sections %q", cmd, missing)
+		}
+	}
+}
@@ -15,10 +15,8 @@
 package fetcher
 
 import (
-	"fmt"
-	"strings"
-
 	"github.com/pouchcontainer/pouchrobot/utils"
+	"github.com/pouchcontainer/pouchrobot/utils/templates"
 
 	"github.com/google/go-github/github"
 	"github.com/sirupsen/logrus"
@@ -54,14 +52,14 @@ func (f *Fetcher) checkPRConflict(p *github.PullRequest) error {
 		if f.client.IssueHasLabel(*(pr.Number), utils.PRConflictLabel) {
 			f.client.RemoveLabelForIssue(*(pr.Number), utils.PRConflictLabel)
 		}
-		f.client.RmCommentsViaStr(*(pr.Number), utils.PRConflictSubStr)
+		f.client.RemoveMarkedComments(*(pr.Number), utils.PRConflictCommentID)
 		return nil
 	}
 
 	logrus.Debugf("PR %d: found conflict", *(pr.Number))
 	// remove LGTM label if conflict happens
-	if f.client.IssueHasLabel(*(pr.Number), "LGTM") {
-		f.client.RemoveLabelForIssue(*(pr.Number), "LGTM")
+	if f.client.IssueHasLabel(*(pr.Number), utils.LGTMLabel) {
+		f.client.RemoveLabelForIssue(*(pr.Number), utils.LGTMLabel)
 	}
 
 	// attach a label and add comments
@@ -76,50 +74,18 @@ func (f *Fetcher) checkPRConflict(p *github.PullRequest) error {
 }
 
 // AddConflictCommentToPR adds conflict comments to specific pull request.
+// If there is already one, it is updated in place.
 func (f *Fetcher) AddConflictCommentToPR(pr *github.PullRequest) error {
 	if pr.User == nil || pr.User.Login == nil {
 		logrus.Infof("failed to get user from PR %d: empty User", *(pr.Number))
 		return nil
 	}
 
-	comments, err := f.client.ListComments(*(pr.Number))
+	body, err := f.templates.Render(utils.PRConflictCommentID, templates.Data{
+		Author: *(pr.User.Login),
+	})
 	if err != nil {
 		return err
 	}
-	//logrus.Infof("PR %d: There are %d comments", *(pr.Number), len(comments))
-
-	body := fmt.Sprintf(utils.PRConflictComment, *(pr.User.Login))
-	newComment := &github.IssueComment{
-		Body: &body,
-	}
-
-	if len(comments) == 0 {
-		return f.client.AddCommentToIssue(*(pr.Number), newComment)
-	}
-
-	latestComment := comments[len(comments)-1]
-	if strings.Contains(*(latestComment.Body), utils.PRConflictSubStr) {
-		//logrus.Infof("PR %d: latest comment %s \nhas\n %s", *(pr.Number), *(latestComment.Body), utils.PRConflictSubStr)
-		// remove all existing conflict comments
-		for _, comment := range comments[:(len(comments) - 1)] {
-			if strings.Contains(*(comment.Body), utils.PRConflictSubStr) {
-				if err := f.client.RemoveComment(*(comment.ID)); err != nil {
-					continue
-				}
-			}
-		}
-		return nil
-	}
-
-	// remove all existing conflict comments
-	for _, comment := range comments {
-		if strings.Contains(*(comment.Body), utils.PRConflictSubStr) {
-			if err := f.client.RemoveComment(*(comment.ID)); err != nil {
-				continue
-			}
-		}
-	}
-
-	// add a brand new conflict comment
-	return f.client.AddCommentToIssue(*(pr.Number), newComment)
+	return f.client.UpsertComment(*(pr.Number), utils.PRConflictCommentID, body)
 }
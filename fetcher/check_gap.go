@@ -21,7 +21,9 @@ import (
 	"strconv"
 	"strings"
 
+	"github.com/pouchcontainer/pouchrobot/store"
 	"github.com/pouchcontainer/pouchrobot/utils"
+	"github.com/pouchcontainer/pouchrobot/utils/templates"
 
 	"github.com/google/go-github/github"
 	"github.com/sirupsen/logrus"
@@ -50,41 +52,91 @@ func (f *Fetcher) CheckPRsGap() error {
 	}
 	logrus.Infof("get log info of master branch done")
 
+	open := map[string]bool{}
 	for _, pr := range prs {
 		logrus.Info("start to check prs")
+		open[strconv.Itoa(pr.GetNumber())] = true
 		if err := f.checkPRGap(pr, msLogString); err != nil {
 			logrus.Errorf("failed to check pull request %d gap: %v", *pr.Number, err)
 		}
 	}
-	return nil
+
+	// forget gaps of pull requests which are not open any more.
+	return f.Store.Batch(func(tx store.Store) error {
+		nums, err := tx.Keys(GapBucket)
+		if err != nil {
+			return err
+		}
+		for _, num := range nums {
+			if !open[num] {
+				tx.Delete(GapBucket, num)
+			}
+		}
+		return nil
+	})
 }
 
-func (f *Fetcher) checkPRGap(p *github.PullRequest, msLogString string) error {
-	pr, err := f.client.GetSinglePR(*(p.Number))
-	logrus.Infof("start to check pr %d", *(p.Number))
-	if err != nil {
-		return err
+// gapState is the gap of a pull request checked before, which is still valid
+// if neither head of pull request nor head of master changes.
+type gapState struct {
+	HeadSHA    string `json:"headSHA"`
+	MasterHead string `json:"masterHead"`
+	Gap        int    `json:"gap"`
+}
+
+// prGap returns how many commits pull request is behind master. The gap is
+// reused from store if neither head of pull request nor head of master
+// changes since last check, which saves fetching pull request branch.
+func (f *Fetcher) prGap(pr *github.PullRequest, msLogString string) (int, error) {
+	prNum := strconv.Itoa(pr.GetNumber())
+	masterHead := strings.SplitN(msLogString, "\n", 2)[0]
+
+	var state gapState
+	if found, err := f.Store.Get(GapBucket, prNum, &state); err != nil {
+		logrus.Errorf("failed to get gap of pull request %s checked before: %v", prNum, err)
+	} else if found && state.HeadSHA == pr.Head.GetSHA() && state.MasterHead == masterHead {
+		logrus.Infof("reuse gap of pr %s checked before", prNum)
+		return state.Gap, nil
 	}
 
 	// get pr branch info
-	prNum := strconv.Itoa(*p.Number)
-
-	if err = preparePrBranchEnv(prNum); err != nil {
+	if err := preparePrBranchEnv(prNum); err != nil {
 		handlePrConflict()
-		return fmt.Errorf("failed to prepare pr branch: %v", err)
+		return 0, fmt.Errorf("failed to prepare pr branch: %v", err)
 	}
-	logrus.Infof("prepare pr branch env done :pr %d", *(p.Number))
+	logrus.Infof("prepare pr branch env done :pr %s", prNum)
 
 	prBrLogString, err := getLogInfo("new-" + prNum)
 	if err != nil {
 		logrus.Errorf("failed to get master log info: %v", err)
-		return err
+		return 0, err
 	}
-	logrus.Infof("get pr log info done :pr %d", *(p.Number))
+	logrus.Infof("get pr log info done :pr %s", prNum)
 
 	gap := compareAndgetGap(msLogString, prBrLogString)
+	if err := f.Store.Put(GapBucket, prNum, gapState{
+		HeadSHA:    pr.Head.GetSHA(),
+		MasterHead: masterHead,
+		Gap:        gap,
+	}); err != nil {
+		logrus.Errorf("failed to save gap of pull request %s: %v", prNum, err)
+	}
+	return gap, nil
+}
+
+func (f *Fetcher) checkPRGap(p *github.PullRequest, msLogString string) error {
+	pr, err := f.client.GetSinglePR(*(p.Number))
+	logrus.Infof("start to check pr %d", *(p.Number))
+	if err != nil {
+		return err
+	}
+
+	gap, err := f.prGap(pr, msLogString)
+	if err != nil {
+		return err
+	}
 	logrus.Infof("the gap is %d", gap)
-	if gap < f.gapCommits {
+	if gap < f.commitsGap() {
 		return nil
 	}
 
@@ -92,8 +144,8 @@ func (f *Fetcher) checkPRGap(p *github.PullRequest, msLogString string) error {
 	logrus.Infof("PR %d: found gap %d", *(pr.Number), gap)
 
 	// remove LGTM label if gap happens
-	if f.client.IssueHasLabel(*(pr.Number), "LGTM") {
-		f.client.RemoveLabelForIssue(*(pr.Number), "LGTM")
+	if f.client.IssueHasLabel(*(pr.Number), utils.LGTMLabel) {
+		f.client.RemoveLabelForIssue(*(pr.Number), utils.LGTMLabel)
 	}
 
 	// attach a label and add comments
@@ -108,50 +160,22 @@ func (f *Fetcher) checkPRGap(p *github.PullRequest, msLogString string) error {
 }
 
 // AddGapCommentToPR adds gap comments to specific pull request.
+// If there is already one, it is updated in place with the latest gap.
 func (f *Fetcher) AddGapCommentToPR(pr *github.PullRequest, gap int) error {
 	if pr.User == nil || pr.User.Login == nil {
 		logrus.Infof("failed to get user from PR %d: empty User", *(pr.Number))
 		return nil
 	}
 
-	comments, err := f.client.ListComments(*(pr.Number))
+	body, err := f.templates.Render(utils.PRGapCommentID, templates.Data{
+		Author:    *(pr.User.Login),
+		Gap:       gap,
+		Threshold: f.commitsGap(),
+	})
 	if err != nil {
 		return err
 	}
-
-	body := fmt.Sprintf(utils.PRGapComment, *(pr.User.Login), strconv.Itoa(gap))
-	newComment := &github.IssueComment{
-		Body: &body,
-	}
-
-	if len(comments) == 0 {
-		return f.client.AddCommentToIssue(*(pr.Number), newComment)
-	}
-
-	latestComment := comments[len(comments)-1]
-	if strings.Contains(*(latestComment.Body), utils.PRGapSubStr) {
-		// remove all existing gap comments
-		for _, comment := range comments[:(len(comments) - 1)] {
-			if strings.Contains(*(comment.Body), utils.PRGapSubStr) {
-				if err := f.client.RemoveComment(*(comment.ID)); err != nil {
-					continue
-				}
-			}
-		}
-		return nil
-	}
-
-	// remove all existing gap comments
-	for _, comment := range comments {
-		if strings.Contains(*(comment.Body), utils.PRGapSubStr) {
-			if err := f.client.RemoveComment(*(comment.ID)); err != nil {
-				continue
-			}
-		}
-	}
-
-	// add a brand new gap comment
-	return f.client.AddCommentToIssue(*(pr.Number), newComment)
+	return f.client.UpsertComment(*(pr.Number), utils.PRGapCommentID, body)
 }
 
 func prepareMasterEnv() error {
@@ -15,15 +15,18 @@
 package fetcher
 
 import (
-	"time"
+	"sync"
 
 	"github.com/pouchcontainer/pouchrobot/gh"
+	"github.com/pouchcontainer/pouchrobot/repoconfig"
+	"github.com/pouchcontainer/pouchrobot/store"
+	"github.com/pouchcontainer/pouchrobot/utils/templates"
 
 	"github.com/sirupsen/logrus"
 )
 
-// FetchInterval refers the interval of fetch action
-const FetchInterval = 3 * time.Minute
+// DefaultSchedule is the default cron expression of fetch action.
+const DefaultSchedule = "*/3 * * * *"
 
 // DefaultCommitGap refers default gap commit number.
 // If the PR is hehind the master branch more DefaultCommitGap commits,
@@ -32,15 +35,31 @@ const DefaultCommitGap = 20
 
 // Fetcher is a worker to periodically get elements from github.
 type Fetcher struct {
+	sync.RWMutex
+
 	client     *gh.Client
+	templates  *templates.Renderer
 	gapCommits int
+
+	// RepoConfig provides commits gap from per repository config file.
+	// Commits gap of daemon is used if it is nil.
+	RepoConfig *repoconfig.Loader
+
+	// Store keeps gaps of pull requests checked before across restarts.
+	Store store.Store
 }
 
+// GapBucket is the bucket of store keeping gaps of pull requests checked
+// before, keyed by pull request number.
+const GapBucket = "fetcher-gap"
+
 // New initializes a brand new fetch.
-func New(client *gh.Client, CommitsGap int) *Fetcher {
+func New(client *gh.Client, renderer *templates.Renderer, CommitsGap int) *Fetcher {
 	fetcher := &Fetcher{
 		client:     client,
+		templates:  renderer,
 		gapCommits: CommitsGap,
+		Store:      store.NewMemory(),
 	}
 	if CommitsGap == 0 {
 		fetcher.gapCommits = DefaultCommitGap
@@ -48,13 +67,36 @@ func New(client *gh.Client, CommitsGap int) *Fetcher {
 	return fetcher
 }
 
-// Run starts periodical work
-func (f *Fetcher) Run() {
-	logrus.Info("start to run fetcher")
+// SetCommitsGap replaces commits gap of daemon. Zero means default.
+func (f *Fetcher) SetCommitsGap(commitsGap int) {
+	if commitsGap == 0 {
+		commitsGap = DefaultCommitGap
+	}
+
+	f.Lock()
+	defer f.Unlock()
+	f.gapCommits = commitsGap
+}
+
+// commitsGap returns the number of commits a pull request could be behind
+// master before a rebase is requested.
+func (f *Fetcher) commitsGap() int {
+	if f.RepoConfig != nil && f.RepoConfig.Current().CommitsGap > 0 {
+		return f.RepoConfig.Current().CommitsGap
+	}
+
+	f.RLock()
+	defer f.RUnlock()
+	return f.gapCommits
+}
+
+// Fetch checks conflicts and commits gap of all open pull requests.
+// It is run periodically by scheduler.
+func (f *Fetcher) Fetch() error {
+	logrus.Info("start to fetch pull requests")
 
-	for {
-		f.CheckPRsConflict()
-		f.CheckPRsGap()
-		time.Sleep(FetchInterval)
+	if err := f.CheckPRsConflict(); err != nil {
+		logrus.Errorf("failed to check conflicts of pull requests: %v", err)
 	}
+	return f.CheckPRsGap()
 }
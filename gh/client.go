@@ -29,6 +29,10 @@ type Client struct {
 	*github.Client
 	owner string
 	repo  string
+
+	// login is the GitHub login of the account behind the access token.
+	// It is lazily fetched and cached by Login.
+	login string
 }
 
 // NewClient constructs a new instance of Client.
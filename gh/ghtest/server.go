@@ -0,0 +1,215 @@
+// Copyright 2018 The Pouch Robot Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ghtest
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync"
+
+	"github.com/pouchcontainer/pouchrobot/gh"
+
+	"github.com/google/go-github/github"
+)
+
+// Login is the login of robot on Server.
+const Login = "pouchrobot"
+
+// Request is a request which changes repository on Server.
+type Request struct {
+	// Method is the HTTP method of request.
+	Method string
+
+	// Path is the URL path of request, like "/repos/o/r/statuses/sha".
+	Path string
+
+	// Body is the JSON body of request.
+	Body []byte
+}
+
+// Server is a fake GitHub API serving a repository for tests of code using
+// *gh.Client. It serves files and comments, and records other requests.
+type Server struct {
+	*httptest.Server
+	sync.Mutex
+
+	// Files are contents of files in default branch keyed by path.
+	Files map[string]string
+
+	// FilesAt are contents of files at refs keyed by ref and then path.
+	// Files are served for a ref absent here.
+	FilesAt map[string]map[string]string
+
+	// Issues are issues and pull requests as issues keyed by number.
+	// Labels of issue are served as its labels.
+	Issues map[int]*github.Issue
+
+	// PullRequests are pull requests keyed by number.
+	PullRequests map[int]*github.PullRequest
+
+	// Commits are commits of pull requests keyed by number.
+	Commits map[int][]*github.RepositoryCommit
+
+	// Reviews are reviews of pull requests keyed by number.
+	Reviews map[int][]*github.PullRequestReview
+
+	// Comments are comments of issues and pull requests keyed by number.
+	Comments map[int][]*github.IssueComment
+
+	// Errors are statuses answered to GET requests keyed by path, like
+	// 500 for "/repos/o/r/contents/README.md".
+	Errors map[string]int
+
+	// Requests are requests other than GETs in order.
+	Requests []Request
+}
+
+// NewServer starts a fake GitHub API. It should be closed after use.
+func NewServer() *Server {
+	s := &Server{
+		Files:        map[string]string{},
+		FilesAt:      map[string]map[string]string{},
+		Issues:       map[int]*github.Issue{},
+		PullRequests: map[int]*github.PullRequest{},
+		Commits:      map[int][]*github.RepositoryCommit{},
+		Reviews:      map[int][]*github.PullRequestReview{},
+		Comments:     map[int][]*github.IssueComment{},
+		Errors:       map[string]int{},
+	}
+	s.Server = httptest.NewServer(http.HandlerFunc(s.serve))
+	return s
+}
+
+// Client returns a client of repo owner/repo on server.
+func (s *Server) Client(owner, repo string) *gh.Client {
+	client := gh.NewClient(owner, repo, "")
+	client.BaseURL, _ = url.Parse(s.URL + "/")
+	return client
+}
+
+// Recorded returns recorded requests in form of "METHOD path".
+func (s *Server) Recorded() []string {
+	s.Lock()
+	defer s.Unlock()
+
+	var recorded []string
+	for _, request := range s.Requests {
+		recorded = append(recorded, request.Method+" "+request.Path)
+	}
+	return recorded
+}
+
+func (s *Server) serve(w http.ResponseWriter, r *http.Request) {
+	s.Lock()
+	defer s.Unlock()
+
+	w.Header().Set("Content-Type", "application/json")
+	if r.Method != http.MethodGet {
+		body, _ := ioutil.ReadAll(r.Body)
+		s.Requests = append(s.Requests, Request{Method: r.Method, Path: r.URL.Path, Body: body})
+		w.WriteHeader(http.StatusCreated)
+		// GitHub answers changes of labels with labels of issue.
+		if strings.HasSuffix(r.URL.Path, "/labels") {
+			fmt.Fprint(w, "[]")
+			return
+		}
+		fmt.Fprint(w, "{}")
+		return
+	}
+
+	if status, ok := s.Errors[r.URL.Path]; ok {
+		w.WriteHeader(status)
+		fmt.Fprintf(w, `{"message": "%s"}`, http.StatusText(status))
+		return
+	}
+
+	parts := strings.SplitN(strings.TrimPrefix(r.URL.Path, "/"), "/", 5)
+	switch {
+	case r.URL.Path == "/user":
+		json.NewEncoder(w).Encode(&github.User{Login: github.String(Login)})
+		return
+	case len(parts) == 5 && parts[0] == "repos" && parts[3] == "contents":
+		files := s.Files
+		if filesAt, ok := s.FilesAt[r.URL.Query().Get("ref")]; ok {
+			files = filesAt
+		}
+		if content, ok := files[parts[4]]; ok {
+			json.NewEncoder(w).Encode(&github.RepositoryContent{
+				Type:     github.String("file"),
+				Path:     github.String(parts[4]),
+				Encoding: github.String("base64"),
+				Content:  github.String(base64.StdEncoding.EncodeToString([]byte(content))),
+			})
+			return
+		}
+	case len(parts) == 5 && parts[0] == "repos":
+		if resource, ok := s.resource(parts[3], parts[4]); ok {
+			json.NewEncoder(w).Encode(resource)
+			return
+		}
+	}
+	w.WriteHeader(http.StatusNotFound)
+	fmt.Fprint(w, `{"message": "Not Found"}`)
+}
+
+// resource returns the resource of kind at path in repository, like kind
+// "issues" and path "12/comments".
+func (s *Server) resource(kind, path string) (interface{}, bool) {
+	var num int
+	var sub string
+	if n, _ := fmt.Sscanf(strings.Replace(path, "/", " ", 1), "%d %s", &num, &sub); n == 0 {
+		return nil, false
+	}
+
+	switch kind + "/" + sub {
+	case "issues/":
+		issue, ok := s.Issues[num]
+		return issue, ok
+	case "issues/labels":
+		labels := []github.Label{}
+		if issue, ok := s.Issues[num]; ok {
+			labels = append(labels, issue.Labels...)
+		}
+		return labels, true
+	case "issues/comments":
+		comments := s.Comments[num]
+		if comments == nil {
+			comments = []*github.IssueComment{}
+		}
+		return comments, true
+	case "pulls/":
+		pr, ok := s.PullRequests[num]
+		return pr, ok
+	case "pulls/commits":
+		commits := s.Commits[num]
+		if commits == nil {
+			commits = []*github.RepositoryCommit{}
+		}
+		return commits, true
+	case "pulls/reviews":
+		reviews := s.Reviews[num]
+		if reviews == nil {
+			reviews = []*github.PullRequestReview{}
+		}
+		return reviews, true
+	}
+	return nil, false
+}
@@ -16,24 +16,85 @@ package gh
 
 import (
 	"context"
+	"fmt"
 	"strings"
 
+	"github.com/pouchcontainer/pouchrobot/utils"
+
 	"github.com/google/go-github/github"
 	"github.com/sirupsen/logrus"
 )
 
+// commentMarkerFormat is the format of a hidden HTML marker which is attached
+// to every comment robot posts, so that robot is able to find it again later
+// no matter how the comment text changes.
+const commentMarkerFormat = "<!-- pouchrobot:%s -->"
+
+// commentMarkerPrefix is the prefix of every comment marker.
+const commentMarkerPrefix = "<!-- pouchrobot:"
+
+// legacyCommentTexts are texts which identify comments robot posted before
+// markers were introduced, keyed by comment id. A legacy comment contains
+// every text of its id.
+var legacyCommentTexts = map[string][]string{
+	utils.IssueTitleTooShortID:       {"While we thought **ISSUE TITLE** could be more specific"},
+	utils.IssueDescriptionTooShortID: {"While we thought **ISSUE DESCRIPTION** could be more specific"},
+	utils.PRTitleTooShortID:          {"While we thought **PR TITLE** could be more specific"},
+	utils.PRDescriptionTooShortID:    {"While we thought **PR Description** could be more specific"},
+	utils.PRNeedsSignOffID:           {"Please sign off in each of your commits."},
+	utils.IssueNeedP1CommentID:       {"This is a **priority/P1** issue which is highest."},
+	utils.FirstCommitCommentID:       {"We really appreciate it.", "you have read the contribution guide"},
+	utils.PRConflictCommentID:        {"Conflict happens after merging a previous commit."},
+	utils.PRGapCommentID:             {"We found that this PR is", "commits, behind master."},
+	utils.CIFailsCommentID:           {"CI fails according integration system."},
+}
+
+// isLegacyComment returns true if body is a comment of comment id which
+// robot posted before markers were introduced.
+func isLegacyComment(id, body string) bool {
+	texts, ok := legacyCommentTexts[id]
+	if !ok || strings.Contains(body, commentMarkerPrefix) {
+		return false
+	}
+	for _, text := range texts {
+		if !strings.Contains(body, text) {
+			return false
+		}
+	}
+	return true
+}
+
+// CommentMarker returns the hidden marker for comment id.
+func CommentMarker(id string) string {
+	return fmt.Sprintf(commentMarkerFormat, id)
+}
+
 // ListComments lists all comments in an issue including pull request.
 func (c *Client) ListComments(num int) ([]*github.IssueComment, error) {
 	c.Mutex.Lock()
 	defer c.Mutex.Unlock()
 
-	comments, _, err := c.Client.Issues.ListComments(context.Background(), c.owner, c.repo, num, nil)
-	if err != nil {
-		logrus.Errorf("failed to list comment in issue(pr) %d: %v", num, err)
-		return nil, err
+	opt := &github.IssueListCommentsOptions{
+		ListOptions: github.ListOptions{
+			PerPage: 100,
+		},
+	}
+
+	var allComments []*github.IssueComment
+	for {
+		comments, resp, err := c.Client.Issues.ListComments(context.Background(), c.owner, c.repo, num, opt)
+		if err != nil {
+			logrus.Errorf("failed to list comment in issue(pr) %d: %v", num, err)
+			return nil, err
+		}
+		allComments = append(allComments, comments...)
+		if resp.NextPage == 0 {
+			break
+		}
+		opt.Page = resp.NextPage
 	}
 	logrus.Debugf("succeed in listing comments for issue(pr) %d", num)
-	return comments, nil
+	return allComments, nil
 }
 
 // AddCommentToIssue adds comment to an issue.
@@ -49,6 +110,22 @@ func (c *Client) AddCommentToIssue(num int, comment *github.IssueComment) error
 	return nil
 }
 
+// EditComment replaces the body of an existing issue comment.
+func (c *Client) EditComment(id int, body string) error {
+	c.Mutex.Lock()
+	defer c.Mutex.Unlock()
+
+	comment := &github.IssueComment{
+		Body: &body,
+	}
+	if _, _, err := c.Client.Issues.EditComment(context.Background(), c.owner, c.repo, id, comment); err != nil {
+		logrus.Errorf("failed to edit comment %d: %v", id, err)
+		return err
+	}
+	logrus.Debugf("succeed in editing comment %d", id)
+	return nil
+}
+
 // RemoveComment removes a comment for an issue.
 func (c *Client) RemoveComment(id int) error {
 	c.Mutex.Lock()
@@ -58,64 +135,98 @@ func (c *Client) RemoveComment(id int) error {
 		logrus.Errorf("failed to remove comment %d: %v", id, err)
 		return err
 	}
-	logrus.Debugf("succeed in removing comment %s for issue", id)
+	logrus.Debugf("succeed in removing comment %d for issue", id)
 	return nil
 }
 
-// RmCommentsViaStr removes a comment in an issue via given string
-func (c *Client) RmCommentsViaStr(num int, str string) error {
+// ListMarkedComments lists comments in an issue which are authored by robot
+// itself and carry the hidden marker of comment id. A comment of comment id
+// posted before markers were introduced is recognized by its text, and is
+// tagged with the marker in place, so that it is found by marker later.
+func (c *Client) ListMarkedComments(num int, id string) ([]*github.IssueComment, error) {
+	login, err := c.Login()
+	if err != nil {
+		return nil, err
+	}
+
 	comments, err := c.ListComments(num)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
+	marker := CommentMarker(id)
+	result := []*github.IssueComment{}
 	for _, comment := range comments {
-		if comment.Body != nil && strings.Contains(*(comment.Body), str) {
-			return c.RemoveComment(*(comment.ID))
+		if comment.ID == nil || comment.User == nil {
+			continue
+		}
+		if !strings.EqualFold(comment.User.GetLogin(), login) {
+			continue
 		}
+		if !strings.Contains(comment.GetBody(), marker) {
+			if !isLegacyComment(id, comment.GetBody()) {
+				continue
+			}
+			body := marker + "\n" + comment.GetBody()
+			if err := c.EditComment(*(comment.ID), body); err != nil {
+				logrus.Warnf("failed to tag legacy comment %d with marker %s: %v", *(comment.ID), marker, err)
+			} else {
+				comment.Body = &body
+			}
+		}
+		result = append(result, comment)
 	}
-	return nil
+	return result, nil
 }
 
-// RmCommentsViaStrAndAttach removes all comments contains the string str and
-// attaches a brand new commnet constructed by body.
-// In this robot, many cases needs this actions to fresh the comments.
-func (c *Client) RmCommentsViaStrAndAttach(num int, str string, body string) error {
-	comments, err := c.ListComments(num)
+// HasMarkedComment returns the comment ID and true if robot has already
+// posted a comment carrying the marker of comment id in the issue.
+func (c *Client) HasMarkedComment(num int, id string) (int, bool) {
+	comments, err := c.ListMarkedComments(num, id)
+	if err != nil || len(comments) == 0 {
+		return -1, false
+	}
+	return *(comments[0].ID), true
+}
+
+// UpsertComment makes sure there is exactly one robot comment tagged with
+// comment id in the issue, and that its body equals body.
+// An existing comment is edited in place instead of being deleted and posted
+// again, which avoids spamming notifications to participants.
+func (c *Client) UpsertComment(num int, id string, body string) error {
+	comments, err := c.ListMarkedComments(num, id)
 	if err != nil {
 		return err
 	}
 
-	// remove all the existing CI failure comments
-	for _, comment := range comments {
-		if comment.Body == nil {
-			continue
-		}
+	body = CommentMarker(id) + "\n" + body
 
-		if !strings.Contains(*(comment.Body), str) {
-			continue
-		}
+	if len(comments) == 0 {
+		return c.AddCommentToIssue(num, &github.IssueComment{Body: &body})
+	}
 
+	// remove duplicated ones and keep the earliest comment only.
+	for _, comment := range comments[1:] {
 		c.RemoveComment(*(comment.ID))
 	}
 
-	newComment := &github.IssueComment{
-		Body: &body,
+	if comments[0].GetBody() == body {
+		return nil
 	}
-
-	return c.AddCommentToPR(num, newComment)
+	return c.EditComment(*(comments[0].ID), body)
 }
 
-// IssueHasComment returns true if the issue contains a commnet who has substring of 'elment'
-func (c *Client) IssueHasComment(num int, element string) (int, bool) {
-	comments, err := c.ListComments(num)
+// RemoveMarkedComments removes all robot comments tagged with comment id in the issue.
+func (c *Client) RemoveMarkedComments(num int, id string) error {
+	comments, err := c.ListMarkedComments(num, id)
 	if err != nil {
-		return -1, false
+		return err
 	}
+
 	for _, comment := range comments {
-		if comment.Body != nil && strings.Contains(*(comment.Body), element) {
-			return *(comment.ID), true
+		if err := c.RemoveComment(*(comment.ID)); err != nil {
+			return err
 		}
 	}
-	return -1, false
+	return nil
 }
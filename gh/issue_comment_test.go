@@ -0,0 +1,120 @@
+// Copyright 2018 The Pouch Robot Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gh_test
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/pouchcontainer/pouchrobot/gh"
+	"github.com/pouchcontainer/pouchrobot/gh/ghtest"
+	"github.com/pouchcontainer/pouchrobot/utils"
+
+	"github.com/google/go-github/github"
+)
+
+func TestListMarkedComments(t *testing.T) {
+	const conflict = "ping @contributor \nConflict happens after merging a previous commit.\nPlease rebase the branch against master and push it back again. Thanks a lot.\n"
+	comment := func(id int, user, body string) *github.IssueComment {
+		return &github.IssueComment{
+			ID:   github.Int(id),
+			User: &github.User{Login: github.String(user)},
+			Body: github.String(body),
+		}
+	}
+
+	tests := []struct {
+		name     string
+		comments []*github.IssueComment
+		want     []int
+		tagged   []string
+	}{
+		{
+			name:     "marked",
+			comments: []*github.IssueComment{comment(1, ghtest.Login, gh.CommentMarker(utils.PRConflictCommentID)+"\nconflict")},
+			want:     []int{1},
+		},
+		{
+			name:     "legacy",
+			comments: []*github.IssueComment{comment(2, ghtest.Login, conflict)},
+			want:     []int{2},
+			tagged:   []string{"PATCH /repos/pouchcontainer/pouch/issues/comments/2"},
+		},
+		{
+			name: "legacy text of others",
+			comments: []*github.IssueComment{
+				comment(3, "contributor", conflict),
+				comment(4, ghtest.Login, "Conflict happens after merging a previous commit.\n"+gh.CommentMarker(utils.PRGapCommentID)),
+				comment(5, ghtest.Login, "ping @contributor \nCI fails according integration system."),
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := ghtest.NewServer()
+			defer server.Close()
+			server.Comments[12] = tt.comments
+
+			comments, err := server.Client("pouchcontainer", "pouch").ListMarkedComments(12, utils.PRConflictCommentID)
+			if err != nil {
+				t.Fatalf("ListMarkedComments() error = %v", err)
+			}
+			var got []int
+			for _, comment := range comments {
+				got = append(got, comment.GetID())
+				if !strings.HasPrefix(comment.GetBody(), gh.CommentMarker(utils.PRConflictCommentID)) {
+					t.Errorf("comment %d is not tagged with marker: %q", comment.GetID(), comment.GetBody())
+				}
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ListMarkedComments() = %v, want %v", got, tt.want)
+			}
+			if recorded := server.Recorded(); !reflect.DeepEqual(recorded, tt.tagged) {
+				t.Fatalf("ListMarkedComments() requests = %q, want %q", recorded, tt.tagged)
+			}
+			if len(tt.tagged) == 0 {
+				return
+			}
+
+			var edited github.IssueComment
+			if err := json.Unmarshal(server.Requests[0].Body, &edited); err != nil {
+				t.Fatal(err)
+			}
+			if want := gh.CommentMarker(utils.PRConflictCommentID) + "\n" + conflict; edited.GetBody() != want {
+				t.Errorf("tagged body = %q, want %q", edited.GetBody(), want)
+			}
+		})
+	}
+}
+
+func TestRemoveLegacyComment(t *testing.T) {
+	server := ghtest.NewServer()
+	defer server.Close()
+	server.Comments[12] = []*github.IssueComment{{
+		ID:   github.Int(7),
+		User: &github.User{Login: github.String(ghtest.Login)},
+		Body: github.String("@contributor  Thanks for your contribution. 🍻\nPlease sign off in each of your commits."),
+	}}
+
+	if err := server.Client("pouchcontainer", "pouch").RemoveMarkedComments(12, utils.PRNeedsSignOffID); err != nil {
+		t.Fatalf("RemoveMarkedComments() error = %v", err)
+	}
+	want := []string{"PATCH /repos/pouchcontainer/pouch/issues/comments/7", "DELETE /repos/pouchcontainer/pouch/issues/comments/7"}
+	if got := server.Recorded(); !reflect.DeepEqual(got, want) {
+		t.Errorf("RemoveMarkedComments() requests = %q, want %q", got, want)
+	}
+}
@@ -16,6 +16,7 @@ package gh
 
 import (
 	"context"
+	"time"
 
 	"github.com/pouchcontainer/pouchrobot/utils"
 
@@ -37,6 +38,85 @@ func (c *Client) GetIssues(opt *github.IssueListByRepoOptions) ([]*github.Issue,
 	return issues, nil
 }
 
+// ListOpenIssues lists all open issues and pull requests of a repo.
+func (c *Client) ListOpenIssues() ([]*github.Issue, error) {
+	c.Mutex.Lock()
+	defer c.Mutex.Unlock()
+
+	opt := &github.IssueListByRepoOptions{
+		State: "open",
+		ListOptions: github.ListOptions{
+			PerPage: 100,
+		},
+	}
+
+	var allIssues []*github.Issue
+	for {
+		issues, resp, err := c.Client.Issues.ListByRepo(context.Background(), c.owner, c.repo, opt)
+		if err != nil {
+			logrus.Errorf("failed to list open issues in repo %s: %v", c.repo, err)
+			return nil, err
+		}
+		allIssues = append(allIssues, issues...)
+		if resp.NextPage == 0 {
+			break
+		}
+		opt.Page = resp.NextPage
+	}
+	logrus.Debugf("succeed in listing open issues in repo %s", c.repo)
+	return allIssues, nil
+}
+
+// LabeledAt returns when label is added to issue for the last time.
+// The second return value is false if label is never added.
+func (c *Client) LabeledAt(num int, label string) (time.Time, bool, error) {
+	c.Mutex.Lock()
+	defer c.Mutex.Unlock()
+
+	opt := &github.ListOptions{
+		PerPage: 100,
+	}
+
+	var labeledAt time.Time
+	found := false
+	for {
+		events, resp, err := c.Client.Issues.ListIssueEvents(context.Background(), c.owner, c.repo, num, opt)
+		if err != nil {
+			logrus.Errorf("failed to list events of issue %d: %v", num, err)
+			return time.Time{}, false, err
+		}
+		for _, event := range events {
+			if event.GetEvent() == "labeled" && event.Label.GetName() == label {
+				labeledAt, found = event.GetCreatedAt(), true
+			}
+		}
+		if resp.NextPage == 0 {
+			break
+		}
+		opt.Page = resp.NextPage
+	}
+	return labeledAt, found, nil
+}
+
+// GetIssue gets a single issue or pull request from repo.
+func (c *Client) GetIssue(num int) (*github.Issue, error) {
+	c.Mutex.Lock()
+	defer c.Mutex.Unlock()
+
+	issue, _, err := c.Client.Issues.Get(context.Background(), c.owner, c.repo, num)
+	if err != nil {
+		logrus.Errorf("failed to get issue %d in repo %s: %v", num, c.repo, err)
+		return nil, err
+	}
+	logrus.Debugf("succeed in getting issue %d in repo %s", num, c.repo)
+	return issue, nil
+}
+
+// CloseIssue closes an issue or a pull request.
+func (c *Client) CloseIssue(num int) error {
+	return c.EditIssue(num, &github.IssueRequest{State: github.String("closed")})
+}
+
 // CreateIssue creates a brand new issue in repo's issue list.
 func (c *Client) CreateIssue(title, body string) error {
 	c.Mutex.Lock()
@@ -120,6 +200,23 @@ func (c *Client) RemoveLabelForIssue(num int, label string) error {
 	return nil
 }
 
+// RemoveExistingLabels removes those of labels which are attached to an issue.
+func (c *Client) RemoveExistingLabels(num int, labels []string) error {
+	existing, err := c.GetStrLabelsInIssue(num)
+	if err != nil {
+		return err
+	}
+	for _, label := range labels {
+		if !utils.SliceContainsElement(existing, label) {
+			continue
+		}
+		if err := c.RemoveLabelForIssue(num, label); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 // ReplaceLabelsForIssue replaces all labels for an issue.
 func (c *Client) ReplaceLabelsForIssue(num int, labels []string) error {
 	if _, _, err := c.Client.Issues.ReplaceLabelsForIssue(context.Background(), c.owner, c.repo, num, labels); err != nil {
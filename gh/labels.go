@@ -0,0 +1,166 @@
+// Copyright 2018 The Pouch Robot Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gh
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+
+	"github.com/google/go-github/github"
+	"github.com/sirupsen/logrus"
+)
+
+// mediaTypeLabelDescriptionPreview is the media type required to read and
+// write descriptions of labels.
+const mediaTypeLabelDescriptionPreview = "application/vnd.github.symmetra-preview+json"
+
+// RepoLabel represents a label of a repository with its description.
+// go-github does not support label descriptions yet, so raw requests are used.
+type RepoLabel struct {
+	// Name is the name of label.
+	Name string `json:"name"`
+
+	// NewName is used to rename a label when editing it.
+	NewName string `json:"new_name,omitempty"`
+
+	// Color is the hex color code of label without leading "#".
+	Color string `json:"color"`
+
+	// Description is a short description of label.
+	Description string `json:"description"`
+}
+
+// ListRepoLabels lists all labels with description in a repo.
+func (c *Client) ListRepoLabels() ([]*RepoLabel, error) {
+	c.Mutex.Lock()
+	defer c.Mutex.Unlock()
+
+	var allLabels []*RepoLabel
+	page := 1
+	for {
+		u := fmt.Sprintf("repos/%s/%s/labels?per_page=100&page=%d", c.owner, c.repo, page)
+		req, err := c.Client.NewRequest("GET", u, nil)
+		if err != nil {
+			return nil, err
+		}
+		req.Header.Set("Accept", mediaTypeLabelDescriptionPreview)
+
+		var labels []*RepoLabel
+		resp, err := c.Client.Do(context.Background(), req, &labels)
+		if err != nil {
+			logrus.Errorf("failed to list labels in repo %s: %v", c.repo, err)
+			return nil, err
+		}
+		allLabels = append(allLabels, labels...)
+		if resp.NextPage == 0 {
+			break
+		}
+		page = resp.NextPage
+	}
+	logrus.Debugf("succeed in listing labels in repo %s", c.repo)
+	return allLabels, nil
+}
+
+// CreateRepoLabel creates a label in a repo.
+func (c *Client) CreateRepoLabel(label *RepoLabel) error {
+	c.Mutex.Lock()
+	defer c.Mutex.Unlock()
+
+	u := fmt.Sprintf("repos/%s/%s/labels", c.owner, c.repo)
+	req, err := c.Client.NewRequest("POST", u, label)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", mediaTypeLabelDescriptionPreview)
+
+	if _, err := c.Client.Do(context.Background(), req, nil); err != nil {
+		logrus.Errorf("failed to create label %s in repo %s: %v", label.Name, c.repo, err)
+		return err
+	}
+	logrus.Debugf("succeed in creating label %s in repo %s", label.Name, c.repo)
+	return nil
+}
+
+// EditRepoLabel updates a label named name in a repo.
+// If label.NewName is set, the label is renamed on all issues and pull requests as well.
+func (c *Client) EditRepoLabel(name string, label *RepoLabel) error {
+	c.Mutex.Lock()
+	defer c.Mutex.Unlock()
+
+	u := fmt.Sprintf("repos/%s/%s/labels/%s", c.owner, c.repo, url.PathEscape(name))
+	req, err := c.Client.NewRequest("PATCH", u, label)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", mediaTypeLabelDescriptionPreview)
+
+	if _, err := c.Client.Do(context.Background(), req, nil); err != nil {
+		logrus.Errorf("failed to edit label %s in repo %s: %v", name, c.repo, err)
+		return err
+	}
+	logrus.Debugf("succeed in editing label %s in repo %s", name, c.repo)
+	return nil
+}
+
+// DeleteRepoLabel deletes a label from a repo.
+func (c *Client) DeleteRepoLabel(name string) error {
+	c.Mutex.Lock()
+	defer c.Mutex.Unlock()
+
+	u := fmt.Sprintf("repos/%s/%s/labels/%s", c.owner, c.repo, url.PathEscape(name))
+	req, err := c.Client.NewRequest("DELETE", u, nil)
+	if err != nil {
+		return err
+	}
+
+	if _, err := c.Client.Do(context.Background(), req, nil); err != nil {
+		logrus.Errorf("failed to delete label %s in repo %s: %v", name, c.repo, err)
+		return err
+	}
+	logrus.Debugf("succeed in deleting label %s in repo %s", name, c.repo)
+	return nil
+}
+
+// ListIssuesWithLabel lists all issues and pull requests in any state which
+// are attached with label.
+func (c *Client) ListIssuesWithLabel(label string) ([]*github.Issue, error) {
+	c.Mutex.Lock()
+	defer c.Mutex.Unlock()
+
+	opt := &github.IssueListByRepoOptions{
+		State:  "all",
+		Labels: []string{label},
+		ListOptions: github.ListOptions{
+			PerPage: 100,
+		},
+	}
+
+	var allIssues []*github.Issue
+	for {
+		issues, resp, err := c.Client.Issues.ListByRepo(context.Background(), c.owner, c.repo, opt)
+		if err != nil {
+			logrus.Errorf("failed to list issues with label %s: %v", label, err)
+			return nil, err
+		}
+		allIssues = append(allIssues, issues...)
+		if resp.NextPage == 0 {
+			break
+		}
+		opt.Page = resp.NextPage
+	}
+	logrus.Debugf("succeed in listing issues with label %s", label)
+	return allIssues, nil
+}
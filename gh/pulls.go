@@ -16,6 +16,7 @@ package gh
 
 import (
 	"context"
+	"sync"
 
 	"github.com/google/go-github/github"
 	"github.com/sirupsen/logrus"
@@ -90,6 +91,72 @@ func (c *Client) ListCommits(num int) ([]*github.RepositoryCommit, error) {
 	return commits, nil
 }
 
+// ListFiles lists all files changed in a pull request.
+func (c *Client) ListFiles(num int) ([]*github.CommitFile, error) {
+	c.Mutex.Lock()
+	defer c.Mutex.Unlock()
+
+	opt := &github.ListOptions{
+		PerPage: 100,
+	}
+
+	var allFiles []*github.CommitFile
+	for {
+		files, resp, err := c.PullRequests.ListFiles(context.Background(), c.owner, c.repo, num, opt)
+		if err != nil {
+			logrus.Errorf("failed to list files in pull request %d: %v", num, err)
+			return nil, err
+		}
+		allFiles = append(allFiles, files...)
+		if resp.NextPage == 0 {
+			break
+		}
+		opt.Page = resp.NextPage
+	}
+	logrus.Debugf("succeed in listing files in pull request %d", num)
+	return allFiles, nil
+}
+
+// FileLister lists files changed in a pull request. It is satisfied by
+// *Client and *FilesCache.
+type FileLister interface {
+	ListFiles(num int) ([]*github.CommitFile, error)
+}
+
+// FilesCache lists files changed in a pull request at most once. A cache is
+// created for each webhook event, so that handlers of the event share one
+// listing, while the next event sees files of the new head.
+type FilesCache struct {
+	sync.Mutex
+
+	lister FileLister
+	files  map[int][]*github.CommitFile
+	errs   map[int]error
+}
+
+// NewFilesCache creates an empty cache listing files with lister.
+func NewFilesCache(lister FileLister) *FilesCache {
+	return &FilesCache{
+		lister: lister,
+		files:  map[int][]*github.CommitFile{},
+		errs:   map[int]error{},
+	}
+}
+
+// ListFiles lists files changed in pull request num, or returns the result
+// listed before, including a failure.
+func (c *FilesCache) ListFiles(num int) ([]*github.CommitFile, error) {
+	c.Lock()
+	defer c.Unlock()
+
+	if files, ok := c.files[num]; ok {
+		return files, c.errs[num]
+	}
+	files, err := c.lister.ListFiles(num)
+	c.files[num], c.errs[num] = files, err
+	return files, err
+}
+
 // ListPRReviews lists all reviews on a pull request.
 func (c *Client) ListPRReviews(num int) ([]*github.PullRequestReview, error) {
 	c.Mutex.Lock()
@@ -117,3 +184,35 @@ func (c *Client) CreatePR(newPR *github.NewPullRequest) (*github.PullRequest, er
 	logrus.Debug("succeed in creating pull request")
 	return pullRequest, nil
 }
+
+// RequestReviewers requests users to review a pull request.
+func (c *Client) RequestReviewers(num int, reviewers []string) error {
+	c.Mutex.Lock()
+	defer c.Mutex.Unlock()
+
+	if _, _, err := c.PullRequests.RequestReviewers(context.Background(), c.owner, c.repo, num, github.ReviewersRequest{Reviewers: reviewers}); err != nil {
+		logrus.Errorf("failed to request reviewers %v for pull request %d: %v", reviewers, num, err)
+		return err
+	}
+	logrus.Debugf("succeed in requesting reviewers %v for pull request %d", reviewers, num)
+	return nil
+}
+
+// ListRequestedReviewers lists logins of users requested to review a pull request.
+func (c *Client) ListRequestedReviewers(num int) ([]string, error) {
+	c.Mutex.Lock()
+	defer c.Mutex.Unlock()
+
+	reviewers, _, err := c.PullRequests.ListReviewers(context.Background(), c.owner, c.repo, num, nil)
+	if err != nil {
+		logrus.Errorf("failed to list requested reviewers of pull request %d: %v", num, err)
+		return nil, err
+	}
+
+	var users []string
+	for _, user := range reviewers.Users {
+		users = append(users, user.GetLogin())
+	}
+	logrus.Debugf("succeed in listing requested reviewers of pull request %d", num)
+	return users, nil
+}
@@ -0,0 +1,65 @@
+// Copyright 2018 The Pouch Robot Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gh
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/google/go-github/github"
+)
+
+// countingLister counts calls to ListFiles.
+type countingLister struct {
+	calls int
+	err   error
+}
+
+func (l *countingLister) ListFiles(num int) ([]*github.CommitFile, error) {
+	l.calls++
+	if l.err != nil {
+		return nil, l.err
+	}
+	return []*github.CommitFile{{Filename: github.String(fmt.Sprintf("file%d.go", num))}}, nil
+}
+
+func TestFilesCache(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+	}{
+		{name: "listed"},
+		{name: "failed", err: fmt.Errorf("rate limited")},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			lister := &countingLister{err: tt.err}
+			cache := NewFilesCache(lister)
+			for i := 0; i < 3; i++ {
+				files, err := cache.ListFiles(12)
+				if err != tt.err {
+					t.Fatalf("ListFiles() error = %v, want %v", err, tt.err)
+				}
+				if tt.err == nil && (len(files) != 1 || files[0].GetFilename() != "file12.go") {
+					t.Errorf("ListFiles() = %v, want file12.go", files)
+				}
+			}
+			cache.ListFiles(34)
+			if lister.calls != 2 {
+				t.Errorf("ListFiles() is called %d times, want once per pull request", lister.calls)
+			}
+		})
+	}
+}
@@ -16,6 +16,8 @@ package gh
 
 import (
 	"context"
+	"fmt"
+	"net/http"
 
 	"github.com/google/go-github/github"
 	"github.com/sirupsen/logrus"
@@ -47,3 +49,122 @@ func (c *Client) ListContributors(opt *github.ListContributorsOptions) ([]*githu
 	}
 	return contributors, nil
 }
+
+// GetFileContent gets content of a file in the default branch of repository.
+func (c *Client) GetFileContent(path string) ([]byte, error) {
+	return c.GetFileContentAt(path, "")
+}
+
+// GetFileContentAt gets content of a file at ref of repository, which is a
+// commit, branch or tag. Default branch is used if ref is empty.
+func (c *Client) GetFileContentAt(path, ref string) ([]byte, error) {
+	c.Mutex.Lock()
+	defer c.Mutex.Unlock()
+
+	var opt *github.RepositoryContentGetOptions
+	if ref != "" {
+		opt = &github.RepositoryContentGetOptions{Ref: ref}
+	}
+	fileContent, _, _, err := c.Repositories.GetContents(context.Background(), c.owner, c.repo, path, opt)
+	if err != nil {
+		if !IsNotFound(err) {
+			logrus.Errorf("failed to get file %s from repository %s: %v", path, c.repo, err)
+		}
+		return nil, err
+	}
+	if fileContent == nil {
+		return nil, fmt.Errorf("path %s in repository %s is not a file", path, c.repo)
+	}
+
+	content, err := fileContent.GetContent()
+	if err != nil {
+		return nil, err
+	}
+	logrus.Debugf("succeed in getting file %s from repository %s", path, c.repo)
+	return []byte(content), nil
+}
+
+// ListDirectory lists paths of files in a directory in the default branch of repository.
+func (c *Client) ListDirectory(path string) ([]string, error) {
+	c.Mutex.Lock()
+	defer c.Mutex.Unlock()
+
+	_, directoryContent, _, err := c.Repositories.GetContents(context.Background(), c.owner, c.repo, path, nil)
+	if err != nil {
+		if !IsNotFound(err) {
+			logrus.Errorf("failed to list directory %s in repository %s: %v", path, c.repo, err)
+		}
+		return nil, err
+	}
+
+	var files []string
+	for _, content := range directoryContent {
+		if content.GetType() == "file" {
+			files = append(files, content.GetPath())
+		}
+	}
+	logrus.Debugf("succeed in listing directory %s in repository %s", path, c.repo)
+	return files, nil
+}
+
+// IsNotFound returns true if err is a 404 response from GitHub.
+func IsNotFound(err error) bool {
+	errResp, ok := err.(*github.ErrorResponse)
+	return ok && errResp.Response != nil && errResp.Response.StatusCode == http.StatusNotFound
+}
+
+// maxStatusDescription is the maximum characters of description of a commit status.
+const maxStatusDescription = 140
+
+// CreateStatus creates a commit status of context on ref.
+// State could be "pending", "success", "error" or "failure". A description
+// longer than GitHub accepts is truncated.
+func (c *Client) CreateStatus(ref, state, statusContext, description string) error {
+	c.Mutex.Lock()
+	defer c.Mutex.Unlock()
+
+	if runes := []rune(description); len(runes) > maxStatusDescription {
+		description = string(runes[:maxStatusDescription-3]) + "..."
+	}
+
+	status := &github.RepoStatus{
+		State:       github.String(state),
+		Context:     github.String(statusContext),
+		Description: github.String(description),
+	}
+	if _, _, err := c.Repositories.CreateStatus(context.Background(), c.owner, c.repo, ref, status); err != nil {
+		logrus.Errorf("failed to create status %s of %s on %s: %v", state, statusContext, ref, err)
+		return err
+	}
+	logrus.Debugf("succeed in creating status %s of %s on %s", state, statusContext, ref)
+	return nil
+}
+
+// ListFileAuthors lists logins of authors of the latest count commits
+// touching path in the default branch, with duplicates.
+func (c *Client) ListFileAuthors(path string, count int) ([]string, error) {
+	c.Mutex.Lock()
+	defer c.Mutex.Unlock()
+
+	opt := &github.CommitsListOptions{
+		Path: path,
+		ListOptions: github.ListOptions{
+			PerPage: count,
+		},
+	}
+	commits, _, err := c.Repositories.ListCommits(context.Background(), c.owner, c.repo, opt)
+	if err != nil {
+		logrus.Errorf("failed to list commits of %s in repository %s: %v", path, c.repo, err)
+		return nil, err
+	}
+
+	var authors []string
+	for _, commit := range commits {
+		// commits whose author email is not bound to a GitHub account have no author.
+		if login := commit.Author.GetLogin(); login != "" {
+			authors = append(authors, login)
+		}
+	}
+	logrus.Debugf("succeed in listing commits of %s in repository %s", path, c.repo)
+	return authors, nil
+}
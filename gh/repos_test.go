@@ -0,0 +1,57 @@
+// Copyright 2018 The Pouch Robot Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gh_test
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/pouchcontainer/pouchrobot/gh/ghtest"
+
+	"github.com/google/go-github/github"
+)
+
+func TestCreateStatus(t *testing.T) {
+	tests := []struct {
+		name        string
+		description string
+		want        string
+	}{
+		{name: "short", description: "description follows pull request template", want: "description follows pull request template"},
+		{name: "limit", description: strings.Repeat("a", 140), want: strings.Repeat("a", 140)},
+		{name: "long", description: strings.Repeat("缺", 141), want: strings.Repeat("缺", 137) + "..."},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := ghtest.NewServer()
+			defer server.Close()
+
+			if err := server.Client("pouchcontainer", "pouch").CreateStatus("0123abc", "failure", "pouchrobot/test", tt.description); err != nil {
+				t.Fatalf("CreateStatus() error = %v", err)
+			}
+			if len(server.Requests) != 1 {
+				t.Fatalf("CreateStatus() requests = %q, want one", server.Recorded())
+			}
+			var status github.RepoStatus
+			if err := json.Unmarshal(server.Requests[0].Body, &status); err != nil {
+				t.Fatal(err)
+			}
+			if status.GetDescription() != tt.want {
+				t.Errorf("description = %q, want %q", status.GetDescription(), tt.want)
+			}
+		})
+	}
+}
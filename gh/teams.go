@@ -0,0 +1,84 @@
+// Copyright 2018 The Pouch Robot Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gh
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/google/go-github/github"
+	"github.com/sirupsen/logrus"
+)
+
+// ListTeamMembers lists logins of all members in team slug of organization org.
+func (c *Client) ListTeamMembers(org, slug string) ([]string, error) {
+	c.Mutex.Lock()
+	defer c.Mutex.Unlock()
+
+	teamID, err := c.findTeamID(org, slug)
+	if err != nil {
+		return nil, err
+	}
+
+	opt := &github.OrganizationListTeamMembersOptions{
+		ListOptions: github.ListOptions{
+			PerPage: 100,
+		},
+	}
+
+	var members []string
+	for {
+		users, resp, err := c.Organizations.ListTeamMembers(context.Background(), teamID, opt)
+		if err != nil {
+			logrus.Errorf("failed to list members of team %s/%s: %v", org, slug, err)
+			return nil, err
+		}
+		for _, user := range users {
+			members = append(members, user.GetLogin())
+		}
+		if resp.NextPage == 0 {
+			break
+		}
+		opt.Page = resp.NextPage
+	}
+	logrus.Debugf("succeed in listing members of team %s/%s", org, slug)
+	return members, nil
+}
+
+// findTeamID finds ID of team slug in organization org.
+// Caller must hold the lock of client.
+func (c *Client) findTeamID(org, slug string) (int, error) {
+	opt := &github.ListOptions{
+		PerPage: 100,
+	}
+	for {
+		teams, resp, err := c.Organizations.ListTeams(context.Background(), org, opt)
+		if err != nil {
+			logrus.Errorf("failed to list teams of organization %s: %v", org, err)
+			return 0, err
+		}
+		for _, team := range teams {
+			if strings.EqualFold(team.GetSlug(), slug) {
+				return team.GetID(), nil
+			}
+		}
+		if resp.NextPage == 0 {
+			break
+		}
+		opt.Page = resp.NextPage
+	}
+	return 0, fmt.Errorf("team %s not found in organization %s", slug, org)
+}
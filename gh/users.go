@@ -0,0 +1,46 @@
+// Copyright 2018 The Pouch Robot Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gh
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/sirupsen/logrus"
+)
+
+// Login returns the GitHub login of the robot account which owns the access token.
+// The result is cached after the first successful call.
+func (c *Client) Login() (string, error) {
+	c.Mutex.Lock()
+	defer c.Mutex.Unlock()
+
+	if c.login != "" {
+		return c.login, nil
+	}
+
+	user, _, err := c.Client.Users.Get(context.Background(), "")
+	if err != nil {
+		logrus.Errorf("failed to get authenticated user: %v", err)
+		return "", err
+	}
+	if user.GetLogin() == "" {
+		return "", fmt.Errorf("authenticated user has an empty login")
+	}
+
+	c.login = user.GetLogin()
+	logrus.Debugf("succeed in getting authenticated user %s", c.login)
+	return c.login, nil
+}
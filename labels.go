@@ -0,0 +1,234 @@
+// Copyright 2018 The Pouch Robot Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/pouchcontainer/pouchrobot/config"
+	"github.com/pouchcontainer/pouchrobot/gh"
+	"github.com/pouchcontainer/pouchrobot/labels"
+	"github.com/pouchcontainer/pouchrobot/utils"
+	"github.com/pouchcontainer/pouchrobot/utils/matcher"
+
+	"github.com/spf13/cobra"
+)
+
+// labelsCmd is the parent of all label related commands.
+var labelsCmd = &cobra.Command{
+	Use:   "labels",
+	Short: "Manage labels and label rules of robot",
+	Args:  cobra.NoArgs,
+}
+
+// LabelsValidateCommand is used to implement 'labels validate' command.
+type LabelsValidateCommand struct {
+	cmd *cobra.Command
+
+	// scope is the scope which samples are matched in.
+	scope string
+
+	// samples are sample texts given in command line.
+	samples []string
+
+	// sampleFile is a file containing samples, one sample per line.
+	sampleFile string
+}
+
+// LabelsSyncCommand is used to implement 'labels sync' command.
+type LabelsSyncCommand struct {
+	cmd *cobra.Command
+
+	// dryRun only prints actions instead of applying them.
+	dryRun bool
+}
+
+func init() {
+	syncCommand := &LabelsSyncCommand{}
+	syncCommand.cmd = &cobra.Command{
+		Use:   "sync",
+		Short: "Sync labels in repo with labels declared in label file",
+		Long: `Sync labels in repo with labels declared in label file.
+
+Missing labels are created, colors and descriptions of existing labels are
+updated, and labels named by aliases are renamed or merged into declared ones.`,
+		Args:          cobra.NoArgs,
+		SilenceErrors: true,
+		SilenceUsage:  true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return syncCommand.runSync(args)
+		},
+	}
+	syncCommand.addFlags()
+
+	validateCommand := &LabelsValidateCommand{}
+	validateCommand.cmd = &cobra.Command{
+		Use:   "validate",
+		Short: "Validate label rules in config and test them against sample titles",
+		Long: `Validate label rules in config and test them against sample titles.
+
+Each sample is a text optionally followed by "=>" and the expected labels
+separated by commas, like "fix ci for network => areas/network, areas/test".
+A sample file contains one sample per line, and lines starting with "#" are ignored.
+Built-in rules are validated if there is no label rule in config.`,
+		Args:          cobra.NoArgs,
+		SilenceErrors: true,
+		SilenceUsage:  true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return validateCommand.runValidate(args)
+		},
+	}
+	validateCommand.addFlags()
+
+	labelsCmd.AddCommand(syncCommand.cmd, validateCommand.cmd)
+	rootCmd.AddCommand(labelsCmd)
+}
+
+// addFlags adds flags for specific command.
+func (s *LabelsSyncCommand) addFlags() {
+	flagSet := s.cmd.Flags()
+
+	flagSet.BoolVar(&s.dryRun, "dry-run", false, "only print actions instead of applying them")
+}
+
+func (s *LabelsSyncCommand) runSync(args []string) error {
+	cfg, err := config.Load(cmdCfg.ConfigFilePath)
+	if err != nil {
+		return err
+	}
+
+	declaredLabels, err := labels.Load(cfg.LabelsConfig.File)
+	if err != nil {
+		return err
+	}
+
+	syncer := labels.NewSyncer(gh.NewClient(cfg.Owner, cfg.Repo, cfg.AccessToken))
+	syncer.DryRun = s.dryRun
+
+	actions, err := syncer.Sync(declaredLabels)
+	for _, action := range actions {
+		fmt.Println(action)
+	}
+	if err != nil {
+		return err
+	}
+	if len(actions) == 0 {
+		fmt.Printf("all %d declared labels are up to date\n", len(declaredLabels))
+	}
+	return nil
+}
+
+// addFlags adds flags for specific command.
+func (v *LabelsValidateCommand) addFlags() {
+	flagSet := v.cmd.Flags()
+
+	flagSet.StringVar(&v.scope, "scope", matcher.ScopeTitle, "scope to match samples in, one of title, body and files")
+	flagSet.StringArrayVarP(&v.samples, "sample", "s", nil, "sample to test label rules against, could be specified multiple times")
+	flagSet.StringVarP(&v.sampleFile, "file", "f", "", "file containing samples, one sample per line")
+}
+
+func (v *LabelsValidateCommand) runValidate(args []string) error {
+	cfg, err := config.Load(cmdCfg.ConfigFilePath)
+	if err != nil {
+		return err
+	}
+
+	rules := cfg.LabelRules
+	if len(rules) == 0 {
+		fmt.Println("no label rules in config, validate built-in rules")
+		rules = matcher.DefaultRules
+	}
+
+	m, err := matcher.New(rules)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("%d label rules are valid\n", len(rules))
+
+	samples := v.samples
+	if v.sampleFile != "" {
+		fileSamples, err := readSamples(v.sampleFile)
+		if err != nil {
+			return err
+		}
+		samples = append(samples, fileSamples...)
+	}
+
+	failures := 0
+	for _, sample := range samples {
+		text, expected, hasExpected := parseSample(sample)
+		got := m.Match(v.scope, text)
+
+		if !hasExpected {
+			fmt.Printf("%q => %s\n", text, strings.Join(got, ", "))
+			continue
+		}
+		if len(got) != len(expected) || (len(got) != 0 && !reflect.DeepEqual(got, expected)) {
+			failures++
+			fmt.Printf("FAIL %q => %s, expected %s\n", text, strings.Join(got, ", "), strings.Join(expected, ", "))
+			continue
+		}
+		fmt.Printf("ok   %q => %s\n", text, strings.Join(got, ", "))
+	}
+
+	if failures != 0 {
+		return fmt.Errorf("%d of %d samples do not get expected labels", failures, len(samples))
+	}
+	return nil
+}
+
+// readSamples reads samples from file in path.
+func readSamples(path string) ([]string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var samples []string
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		samples = append(samples, line)
+	}
+	return samples, scanner.Err()
+}
+
+// parseSample splits a sample into text and sorted expected labels.
+func parseSample(sample string) (string, []string, bool) {
+	parts := strings.SplitN(sample, "=>", 2)
+	text := strings.TrimSpace(parts[0])
+	if len(parts) == 1 {
+		return text, nil, false
+	}
+
+	expected := []string{}
+	for _, label := range strings.Split(parts[1], ",") {
+		if label = strings.TrimSpace(label); label != "" {
+			expected = append(expected, label)
+		}
+	}
+	expected = utils.UniqueElementSlice(expected)
+	sort.Strings(expected)
+	return text, expected, true
+}
@@ -0,0 +1,142 @@
+// Copyright 2018 The Pouch Robot Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package labels
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/pouchcontainer/pouchrobot/utils"
+
+	"gopkg.in/yaml.v2"
+)
+
+// colorRegex matches a hex color code of label.
+var colorRegex = regexp.MustCompile("^[0-9a-f]{6}$")
+
+// Label is a declared label of the repo.
+type Label struct {
+	// Name is the name of label.
+	Name string `json:"name" yaml:"name"`
+
+	// Color is the hex color code of label, like "e11d21".
+	Color string `json:"color" yaml:"color"`
+
+	// Description is a short description of label.
+	Description string `json:"description" yaml:"description"`
+
+	// Aliases are former or alternative names of label. Existing labels
+	// with these names are renamed or merged into this label when syncing.
+	Aliases []string `json:"aliases" yaml:"aliases"`
+}
+
+// RequiredLabels are labels robot attaches by itself. They are always
+// declared, and could be overridden by labels of the same name in label file.
+var RequiredLabels = []Label{
+	{Name: utils.PRConflictLabel, Color: "e11d21", Description: "Pull request conflicts with master and needs a rebase"},
+	{Name: utils.PRGapLabel, Color: "fbca04", Description: "Pull request is far behind master and needs a rebase"},
+	{Name: utils.CIFailureLable, Color: "e11d21", Description: "CI fails on this pull request"},
+	{Name: utils.PriorityP1Label, Color: "b60205", Description: "Highest priority"},
+	{Name: utils.LGTMLabel, Color: "0e8a16", Description: "Pull request is approved by maintainers"},
+	{Name: utils.ChangesRequestedLabel, Color: "d93f0b", Description: "Reviewers request changes on pull request"},
+	{Name: utils.MoreInfoNeededLabel, Color: "d4c5f9", Description: "More information is needed from the author"},
+	{Name: utils.StaleLabel, Color: "795548", Description: "No activity for a long time"},
+	{Name: utils.RottenLabel, Color: "3e2723", Description: "No activity for a long time after becoming stale"},
+	{Name: utils.FrozenLabel, Color: "d3e2f0", Description: "Never becomes stale"},
+	{Name: utils.PRInProgressLabel, Color: "c2e0c6", Description: "An open pull request fixes the issue"},
+	{Name: utils.FixedLabel, Color: "0e8a16", Description: "A merged pull request fixes the issue"},
+	{Name: utils.SizeLabelPrefix + "XS", Color: "009900", Description: "Pull request is extra small by changed lines"},
+	{Name: utils.SizeLabelPrefix + "S", Color: "77bb00", Description: "Pull request is small by changed lines"},
+	{Name: utils.SizeLabelPrefix + "M", Color: "eebb00", Description: "Pull request is medium by changed lines"},
+	{Name: utils.SizeLabelPrefix + "L", Color: "ee9900", Description: "Pull request is large by changed lines"},
+	{Name: utils.SizeLabelPrefix + "XL", Color: "ee5500", Description: "Pull request is extra large by changed lines"},
+	{Name: utils.SizeLabelPrefix + "XXL", Color: "ee0000", Description: "Pull request is extra extra large by changed lines"},
+}
+
+// Load reads declared labels from file in path, and merges them with RequiredLabels.
+// A file with suffix .yml or .yaml is parsed as YAML, otherwise as JSON.
+// If path is empty, RequiredLabels are returned.
+func Load(path string) ([]Label, error) {
+	var declared []Label
+	if path != "" {
+		data, err := ioutil.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+
+		switch strings.ToLower(filepath.Ext(path)) {
+		case ".yml", ".yaml":
+			err = yaml.Unmarshal(data, &declared)
+		default:
+			err = json.Unmarshal(data, &declared)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse label file %s: %v", path, err)
+		}
+	}
+
+	labels := merge(RequiredLabels, declared)
+	if err := Validate(labels); err != nil {
+		return nil, err
+	}
+	return labels, nil
+}
+
+// merge returns labels in base overridden and extended by labels in overrides.
+func merge(base, overrides []Label) []Label {
+	result := []Label{}
+	index := map[string]int{}
+	for _, label := range append(append([]Label{}, base...), overrides...) {
+		label.Color = normalizeColor(label.Color)
+		key := strings.ToLower(label.Name)
+		if i, ok := index[key]; ok {
+			result[i] = label
+			continue
+		}
+		index[key] = len(result)
+		result = append(result, label)
+	}
+	return result
+}
+
+// Validate checks that labels have valid names and colors, and that no name
+// or alias is declared twice.
+func Validate(labels []Label) error {
+	names := map[string]string{}
+	for _, label := range labels {
+		if label.Name == "" {
+			return fmt.Errorf("label name is empty")
+		}
+		if !colorRegex.MatchString(normalizeColor(label.Color)) {
+			return fmt.Errorf("label %s has invalid color %q", label.Name, label.Color)
+		}
+		for _, name := range append([]string{label.Name}, label.Aliases...) {
+			key := strings.ToLower(name)
+			if owner, ok := names[key]; ok {
+				return fmt.Errorf("name %s of label %s is already declared by label %s", name, label.Name, owner)
+			}
+			names[key] = label.Name
+		}
+	}
+	return nil
+}
+
+func normalizeColor(color string) string {
+	return strings.ToLower(strings.TrimPrefix(color, "#"))
+}
@@ -0,0 +1,159 @@
+// Copyright 2018 The Pouch Robot Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package labels
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestMerge(t *testing.T) {
+	base := []Label{
+		{Name: "LGTM", Color: "0e8a16"},
+		{Name: "kind/bug", Color: "#E11D21"},
+	}
+
+	tests := []struct {
+		name      string
+		overrides []Label
+		want      []Label
+	}{
+		{
+			name: "no overrides",
+			want: []Label{{Name: "LGTM", Color: "0e8a16"}, {Name: "kind/bug", Color: "e11d21"}},
+		},
+		{
+			name:      "override case insensitively",
+			overrides: []Label{{Name: "lgtm", Color: "#00FF00", Description: "approved"}},
+			want:      []Label{{Name: "lgtm", Color: "00ff00", Description: "approved"}, {Name: "kind/bug", Color: "e11d21"}},
+		},
+		{
+			name:      "extend",
+			overrides: []Label{{Name: "areas/network", Color: "1d76db"}},
+			want: []Label{
+				{Name: "LGTM", Color: "0e8a16"},
+				{Name: "kind/bug", Color: "e11d21"},
+				{Name: "areas/network", Color: "1d76db"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := merge(base, tt.overrides); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("merge() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name   string
+		labels []Label
+		err    string
+	}{
+		{name: "valid", labels: []Label{{Name: "kind/bug", Color: "#e11d21", Aliases: []string{"bug"}}}},
+		{name: "empty name", labels: []Label{{Color: "e11d21"}}, err: "label name is empty"},
+		{name: "short color", labels: []Label{{Name: "kind/bug", Color: "e11"}}, err: "invalid color"},
+		{name: "named color", labels: []Label{{Name: "kind/bug", Color: "red"}}, err: "invalid color"},
+		{
+			name:   "duplicate name",
+			labels: []Label{{Name: "kind/bug", Color: "e11d21"}, {Name: "Kind/Bug", Color: "e11d21"}},
+			err:    "already declared",
+		},
+		{
+			name:   "alias of another label",
+			labels: []Label{{Name: "kind/bug", Color: "e11d21"}, {Name: "bug", Color: "e11d21", Aliases: []string{"kind/bug"}}},
+			err:    "already declared by label kind/bug",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := Validate(tt.labels)
+			if tt.err == "" {
+				if err != nil {
+					t.Errorf("Validate() error = %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.err) {
+				t.Errorf("Validate() error = %v, want %q", err, tt.err)
+			}
+		})
+	}
+}
+
+func TestRequiredLabels(t *testing.T) {
+	if err := Validate(RequiredLabels); err != nil {
+		t.Errorf("RequiredLabels are invalid: %v", err)
+	}
+
+	labels, err := Load("")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(labels) != len(RequiredLabels) {
+		t.Errorf("Load(\"\") returns %d labels, want %d required labels", len(labels), len(RequiredLabels))
+	}
+}
+
+func TestLoad(t *testing.T) {
+	dir, err := ioutil.TempDir("", "labels")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	tests := []struct {
+		file    string
+		content string
+		err     string
+	}{
+		{file: "labels.yaml", content: "- name: LGTM\n  color: \"#00FF00\"\n- name: areas/network\n  color: 1d76db\n"},
+		{file: "labels.json", content: `[{"name": "LGTM", "color": "00ff00"}, {"name": "areas/network", "color": "1d76db"}]`},
+		{file: "invalid.json", content: `[{"name": "areas/network", "color": "blue"}]`, err: "invalid color"},
+		{file: "broken.json", content: `{`, err: "failed to parse label file"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.file, func(t *testing.T) {
+			path := filepath.Join(dir, tt.file)
+			if err := ioutil.WriteFile(path, []byte(tt.content), 0644); err != nil {
+				t.Fatal(err)
+			}
+			labels, err := Load(path)
+			if tt.err != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.err) {
+					t.Errorf("Load() error = %v, want %q", err, tt.err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Load() error = %v", err)
+			}
+			if len(labels) != len(RequiredLabels)+1 {
+				t.Errorf("Load() returns %d labels, want %d", len(labels), len(RequiredLabels)+1)
+			}
+			for _, label := range labels {
+				if label.Name == "LGTM" && label.Color != "00ff00" {
+					t.Errorf("color of LGTM = %q, want overridden 00ff00", label.Color)
+				}
+			}
+		})
+	}
+}
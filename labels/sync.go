@@ -0,0 +1,200 @@
+// Copyright 2018 The Pouch Robot Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package labels
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/pouchcontainer/pouchrobot/gh"
+
+	"github.com/google/go-github/github"
+	"github.com/sirupsen/logrus"
+)
+
+// Client is the part of GitHub client Syncer needs. It is satisfied by *gh.Client.
+type Client interface {
+	ListRepoLabels() ([]*gh.RepoLabel, error)
+	CreateRepoLabel(label *gh.RepoLabel) error
+	EditRepoLabel(name string, label *gh.RepoLabel) error
+	DeleteRepoLabel(name string) error
+	ListIssuesWithLabel(label string) ([]*github.Issue, error)
+	AddLabelsToIssue(num int, labels []string) error
+	RemoveLabelForIssue(num int, label string) error
+}
+
+// Syncer makes labels of a repo consistent with declared labels.
+type Syncer struct {
+	client Client
+
+	// DryRun makes Syncer only report actions instead of applying them.
+	DryRun bool
+}
+
+// NewSyncer initializes a brand new label syncer.
+func NewSyncer(client Client) *Syncer {
+	return &Syncer{
+		client: client,
+	}
+}
+
+// Sync creates missing labels, updates colors and descriptions of existing
+// ones, and renames or merges aliased labels into declared ones.
+// It returns the actions taken, or to be taken in dry run mode.
+func (s *Syncer) Sync(labels []Label) ([]string, error) {
+	existing, err := s.existingLabels()
+	if err != nil {
+		return nil, err
+	}
+
+	var actions []string
+	act := func(format string, args ...interface{}) {
+		action := fmt.Sprintf(format, args...)
+		actions = append(actions, action)
+		logrus.Infof("label sync: %s", action)
+	}
+
+	for _, label := range labels {
+		current, ok := existing[strings.ToLower(label.Name)]
+
+		var aliases []*gh.RepoLabel
+		for _, alias := range label.Aliases {
+			if aliasLabel, ok := existing[strings.ToLower(alias)]; ok {
+				aliases = append(aliases, aliasLabel)
+			}
+		}
+
+		switch {
+		case !ok && len(aliases) != 0:
+			// rename the first alias, GitHub keeps it on issues and pull requests.
+			alias := aliases[0]
+			aliases = aliases[1:]
+			act("rename label %s to %s", alias.Name, label.Name)
+			if !s.DryRun {
+				if err := s.client.EditRepoLabel(alias.Name, toRepoLabel(label, true)); err != nil {
+					return actions, err
+				}
+			}
+		case !ok:
+			act("create label %s", label.Name)
+			if !s.DryRun {
+				if err := s.client.CreateRepoLabel(toRepoLabel(label, false)); err != nil {
+					return actions, err
+				}
+			}
+		case current.Name != label.Name || current.Color != label.Color || current.Description != label.Description:
+			act("update label %s", label.Name)
+			if !s.DryRun {
+				if err := s.client.EditRepoLabel(current.Name, toRepoLabel(label, true)); err != nil {
+					return actions, err
+				}
+			}
+		}
+
+		// merge the rest aliases since the declared label exists already.
+		for _, alias := range aliases {
+			if err := s.mergeLabel(alias.Name, label.Name, act); err != nil {
+				return actions, err
+			}
+		}
+	}
+
+	return actions, nil
+}
+
+// mergeLabel moves label from to label to on all issues and pull requests,
+// and then deletes label from.
+func (s *Syncer) mergeLabel(from, to string, act func(string, ...interface{})) error {
+	issues, err := s.client.ListIssuesWithLabel(from)
+	if err != nil {
+		return err
+	}
+
+	for _, issue := range issues {
+		act("replace label %s with %s on #%d", from, to, issue.GetNumber())
+		if s.DryRun {
+			continue
+		}
+		if err := s.client.AddLabelsToIssue(issue.GetNumber(), []string{to}); err != nil {
+			return err
+		}
+		if err := s.client.RemoveLabelForIssue(issue.GetNumber(), from); err != nil {
+			return err
+		}
+	}
+
+	act("delete label %s", from)
+	if s.DryRun {
+		return nil
+	}
+	return s.client.DeleteRepoLabel(from)
+}
+
+// Missing returns names of declared labels which do not exist in repo.
+func (s *Syncer) Missing(labels []Label) ([]string, error) {
+	existing, err := s.existingLabels()
+	if err != nil {
+		return nil, err
+	}
+
+	var missing []string
+	for _, label := range labels {
+		if _, ok := existing[strings.ToLower(label.Name)]; !ok {
+			missing = append(missing, label.Name)
+		}
+	}
+	return missing, nil
+}
+
+// Check warns about declared labels which are missing in repo.
+func (s *Syncer) Check(labels []Label) {
+	missing, err := s.Missing(labels)
+	if err != nil {
+		logrus.Errorf("failed to check labels: %v", err)
+		return
+	}
+	if len(missing) == 0 {
+		logrus.Infof("all %d declared labels exist in repo", len(labels))
+		return
+	}
+	logrus.Warnf("labels %v are missing in repo, run `pouchrobot labels sync` to create them", missing)
+}
+
+// existingLabels returns labels in repo keyed by lower case name.
+func (s *Syncer) existingLabels() (map[string]*gh.RepoLabel, error) {
+	repoLabels, err := s.client.ListRepoLabels()
+	if err != nil {
+		return nil, err
+	}
+
+	existing := map[string]*gh.RepoLabel{}
+	for _, repoLabel := range repoLabels {
+		existing[strings.ToLower(repoLabel.Name)] = repoLabel
+	}
+	return existing, nil
+}
+
+// toRepoLabel converts a declared label to the request of GitHub API.
+func toRepoLabel(label Label, rename bool) *gh.RepoLabel {
+	repoLabel := &gh.RepoLabel{
+		Name:        label.Name,
+		Color:       label.Color,
+		Description: label.Description,
+	}
+	if rename {
+		repoLabel.NewName = label.Name
+	}
+	return repoLabel
+}
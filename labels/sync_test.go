@@ -0,0 +1,172 @@
+// Copyright 2018 The Pouch Robot Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package labels
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/pouchcontainer/pouchrobot/gh"
+
+	"github.com/google/go-github/github"
+)
+
+// fakeClient records calls Syncer makes to GitHub.
+type fakeClient struct {
+	labels []*gh.RepoLabel
+	issues map[string][]int
+	calls  []string
+}
+
+func (c *fakeClient) ListRepoLabels() ([]*gh.RepoLabel, error) { return c.labels, nil }
+
+func (c *fakeClient) CreateRepoLabel(label *gh.RepoLabel) error {
+	c.calls = append(c.calls, fmt.Sprintf("create %s %s", label.Name, label.Color))
+	return nil
+}
+
+func (c *fakeClient) EditRepoLabel(name string, label *gh.RepoLabel) error {
+	c.calls = append(c.calls, fmt.Sprintf("edit %s %s %s", name, label.NewName, label.Color))
+	return nil
+}
+
+func (c *fakeClient) DeleteRepoLabel(name string) error {
+	c.calls = append(c.calls, fmt.Sprintf("delete %s", name))
+	return nil
+}
+
+func (c *fakeClient) ListIssuesWithLabel(label string) ([]*github.Issue, error) {
+	var issues []*github.Issue
+	for _, num := range c.issues[label] {
+		issues = append(issues, &github.Issue{Number: github.Int(num)})
+	}
+	return issues, nil
+}
+
+func (c *fakeClient) AddLabelsToIssue(num int, labels []string) error {
+	c.calls = append(c.calls, fmt.Sprintf("add %d %v", num, labels))
+	return nil
+}
+
+func (c *fakeClient) RemoveLabelForIssue(num int, label string) error {
+	c.calls = append(c.calls, fmt.Sprintf("remove %d %s", num, label))
+	return nil
+}
+
+func TestSync(t *testing.T) {
+	tests := []struct {
+		name        string
+		existing    []*gh.RepoLabel
+		issues      map[string][]int
+		labels      []Label
+		wantActions []string
+		wantCalls   []string
+	}{
+		{
+			name:        "create",
+			labels:      []Label{{Name: "kind/bug", Color: "e11d21"}},
+			wantActions: []string{"create label kind/bug"},
+			wantCalls:   []string{"create kind/bug e11d21"},
+		},
+		{
+			name:     "unchanged",
+			existing: []*gh.RepoLabel{{Name: "kind/bug", Color: "e11d21"}},
+			labels:   []Label{{Name: "kind/bug", Color: "e11d21"}},
+		},
+		{
+			name:        "update",
+			existing:    []*gh.RepoLabel{{Name: "Kind/Bug", Color: "e11d21"}},
+			labels:      []Label{{Name: "kind/bug", Color: "e11d21"}},
+			wantActions: []string{"update label kind/bug"},
+			wantCalls:   []string{"edit Kind/Bug kind/bug e11d21"},
+		},
+		{
+			name:        "rename alias",
+			existing:    []*gh.RepoLabel{{Name: "bug", Color: "ee0701"}},
+			labels:      []Label{{Name: "kind/bug", Color: "e11d21", Aliases: []string{"bug"}}},
+			wantActions: []string{"rename label bug to kind/bug"},
+			wantCalls:   []string{"edit bug kind/bug e11d21"},
+		},
+		{
+			name:     "merge alias",
+			existing: []*gh.RepoLabel{{Name: "kind/bug", Color: "e11d21"}, {Name: "bug", Color: "ee0701"}},
+			issues:   map[string][]int{"bug": {12, 34}},
+			labels:   []Label{{Name: "kind/bug", Color: "e11d21", Aliases: []string{"bug"}}},
+			wantActions: []string{
+				"replace label bug with kind/bug on #12",
+				"replace label bug with kind/bug on #34",
+				"delete label bug",
+			},
+			wantCalls: []string{
+				"add 12 [kind/bug]", "remove 12 bug",
+				"add 34 [kind/bug]", "remove 34 bug",
+				"delete bug",
+			},
+		},
+		{
+			name:     "rename first alias and merge the rest",
+			existing: []*gh.RepoLabel{{Name: "bug", Color: "ee0701"}, {Name: "defect", Color: "ee0701"}},
+			issues:   map[string][]int{"defect": {56}},
+			labels:   []Label{{Name: "kind/bug", Color: "e11d21", Aliases: []string{"bug", "defect"}}},
+			wantActions: []string{
+				"rename label bug to kind/bug",
+				"replace label defect with kind/bug on #56",
+				"delete label defect",
+			},
+			wantCalls: []string{
+				"edit bug kind/bug e11d21",
+				"add 56 [kind/bug]", "remove 56 defect",
+				"delete defect",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for _, dryRun := range []bool{false, true} {
+				client := &fakeClient{labels: tt.existing, issues: tt.issues}
+				syncer := NewSyncer(client)
+				syncer.DryRun = dryRun
+
+				actions, err := syncer.Sync(tt.labels)
+				if err != nil {
+					t.Fatalf("Sync() error = %v", err)
+				}
+				if !reflect.DeepEqual(actions, tt.wantActions) {
+					t.Errorf("Sync() actions = %q, want %q", actions, tt.wantActions)
+				}
+
+				wantCalls := tt.wantCalls
+				if dryRun {
+					wantCalls = nil
+				}
+				if !reflect.DeepEqual(client.calls, wantCalls) {
+					t.Errorf("Sync() with DryRun %v calls = %q, want %q", dryRun, client.calls, wantCalls)
+				}
+			}
+		})
+	}
+}
+
+func TestMissing(t *testing.T) {
+	client := &fakeClient{labels: []*gh.RepoLabel{{Name: "LGTM"}, {Name: "Kind/Bug"}}}
+	missing, err := NewSyncer(client).Missing([]Label{{Name: "lgtm"}, {Name: "kind/bug"}, {Name: "areas/network"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{"areas/network"}; !reflect.DeepEqual(missing, want) {
+		t.Errorf("Missing() = %v, want %v", missing, want)
+	}
+}
@@ -0,0 +1,93 @@
+// Copyright 2018 The Pouch Robot Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package lifecycle
+
+import (
+	"strings"
+
+	"github.com/pouchcontainer/pouchrobot/utils"
+
+	"github.com/sirupsen/logrus"
+)
+
+// HandleEvent reactivates a stale or rotten issue or pull request when a
+// human acts on it, and handles "/remove-lifecycle" commands in comments.
+func (m *Manager) HandleEvent(eventType string, data []byte) error {
+	action, err := utils.ExtractActionType(data)
+	if err != nil {
+		return err
+	}
+	// labeling is not an activity, otherwise labels added by hand would be removed at once.
+	if action == "labeled" || action == "unlabeled" || action == "closed" || action == "deleted" {
+		return nil
+	}
+
+	sender, err := utils.ExactSender(data)
+	if err != nil {
+		return err
+	}
+	robot, err := m.client.Login()
+	if err != nil {
+		return err
+	}
+	if sender.GetType() == "Bot" || strings.EqualFold(sender.GetLogin(), robot) {
+		return nil
+	}
+
+	var num int
+	var labels []string
+	switch eventType {
+	case "issues", "issue_comment":
+		issue, err := utils.ExactIssue(data)
+		if err != nil {
+			return err
+		}
+		num, labels = issue.GetNumber(), labelNames(&issue)
+	case "pull_request", "pull_request_review", "pull_request_review_comment":
+		pr, err := utils.ExactPR(data)
+		if err != nil {
+			return err
+		}
+		// pull request in payload carries no labels.
+		if num = pr.GetNumber(); num != 0 {
+			if labels, err = m.client.GetStrLabelsInIssue(num); err != nil {
+				return err
+			}
+		}
+	default:
+		return nil
+	}
+	if num == 0 {
+		return nil
+	}
+
+	if eventType == "issue_comment" {
+		comment, err := utils.ExactIssueComment(data)
+		if err != nil {
+			return err
+		}
+		for _, label := range RemoveCommandLabels(comment.GetBody()) {
+			if utils.SliceContainsElement(labels, label) {
+				logrus.Infof("remove label %s from issue %d by command of %s", label, num, sender.GetLogin())
+				m.client.RemoveLabelForIssue(num, label)
+			}
+		}
+	}
+
+	if utils.SliceContainsElement(labels, utils.StaleLabel) || utils.SliceContainsElement(labels, utils.RottenLabel) {
+		return m.reactivate(num)
+	}
+	return nil
+}
@@ -0,0 +1,322 @@
+// Copyright 2018 The Pouch Robot Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package lifecycle
+
+import (
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/pouchcontainer/pouchrobot/config"
+	"github.com/pouchcontainer/pouchrobot/gh"
+	"github.com/pouchcontainer/pouchrobot/utils"
+	"github.com/pouchcontainer/pouchrobot/utils/templates"
+
+	"github.com/google/go-github/github"
+	"github.com/sirupsen/logrus"
+)
+
+// DefaultSchedule is the default cron expression to check lifecycle of open
+// issues and pull requests, and to follow up issues needing more information.
+const DefaultSchedule = "0 * * * *"
+
+// Default durations of lifecycle stages in days.
+const (
+	DefaultStaleDays  = 90
+	DefaultRottenDays = 30
+	DefaultCloseDays  = 30
+)
+
+// DefaultExemptLabels are labels which exempt issues and pull requests from lifecycle by default.
+var DefaultExemptLabels = []string{utils.PriorityP1Label, utils.FrozenLabel}
+
+// removeCommandRegex matches command like "/remove-lifecycle stale".
+var removeCommandRegex = regexp.MustCompile(`(?m)^/remove-lifecycle\s+(stale|rotten|frozen)\s*$`)
+
+// day is the duration of a day.
+const day = 24 * time.Hour
+
+// Data is the data to render lifecycle comments.
+type Data struct {
+	// Author is the GitHub login of the issue or pull request author.
+	Author string
+
+	// Kind is "issue" or "pull request".
+	Kind string
+
+	// Days is days of inactivity which leads to the current stage.
+	Days int
+
+	// NextDays is days before the next stage if there is still no activity.
+	NextDays int
+}
+
+// Client is the part of GitHub client lifecycle needs. It is satisfied by *gh.Client.
+type Client interface {
+	Login() (string, error)
+	ListOpenIssues() ([]*github.Issue, error)
+	ListComments(num int) ([]*github.IssueComment, error)
+	ListCommits(num int) ([]*github.RepositoryCommit, error)
+	LabeledAt(num int, label string) (time.Time, bool, error)
+	IssueHasLabel(num int, label string) bool
+	GetStrLabelsInIssue(num int) ([]string, error)
+	AddLabelsToIssue(num int, labels []string) error
+	RemoveLabelForIssue(num int, label string) error
+	UpsertComment(num int, id string, body string) error
+	RemoveMarkedComments(num int, id string) error
+	CloseIssue(num int) error
+}
+
+// Manager marks inactive issues and pull requests stale, then rotten, and
+// closes them at last. Stale and rotten labels are removed on any human activity.
+type Manager struct {
+	client    Client
+	templates *templates.Renderer
+	cfg       config.LifecycleConfig
+}
+
+// New initializes a brand new lifecycle manager.
+func New(client Client, renderer *templates.Renderer, cfg config.LifecycleConfig) *Manager {
+	if len(cfg.ExemptLabels) == 0 {
+		cfg.ExemptLabels = DefaultExemptLabels
+	}
+	return &Manager{
+		client:    client,
+		templates: renderer,
+		cfg:       cfg,
+	}
+}
+
+// CheckAll checks lifecycle of all open issues and pull requests.
+func (m *Manager) CheckAll() error {
+	issues, err := m.client.ListOpenIssues()
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	for _, issue := range issues {
+		if err := m.Check(issue, now); err != nil {
+			logrus.Errorf("failed to check lifecycle of issue %d: %v", issue.GetNumber(), err)
+		}
+	}
+	return nil
+}
+
+// Check moves an issue or pull request to the next stage of lifecycle if
+// it has been inactive long enough, or back to active on human activity.
+func (m *Manager) Check(issue *github.Issue, now time.Time) error {
+	labels := labelNames(issue)
+	if containsAny(labels, m.cfg.ExemptLabels) {
+		return nil
+	}
+
+	stages := m.Stages(labels)
+	isStale := utils.SliceContainsElement(labels, utils.StaleLabel)
+	isRotten := utils.SliceContainsElement(labels, utils.RottenLabel)
+
+	// updated_at covers every activity including robot's own ones, so an
+	// issue updated recently is active unless it is already in lifecycle.
+	if !isStale && !isRotten && now.Sub(issue.GetUpdatedAt()) < days(stages.StaleDays) {
+		return nil
+	}
+
+	num := issue.GetNumber()
+	comments, err := m.client.ListComments(num)
+	if err != nil {
+		return err
+	}
+	robot, err := m.client.Login()
+	if err != nil {
+		return err
+	}
+	var commits []*github.RepositoryCommit
+	if issue.PullRequestLinks != nil {
+		// pushing commits leaves no comment but is an activity on pull request.
+		if commits, err = m.client.ListCommits(num); err != nil {
+			return err
+		}
+	}
+	lastActivity := lastHumanActivity(issue, comments, commits, robot)
+
+	switch {
+	case isRotten:
+		rottenAt, ok := markedAt(comments, robot, utils.LifecycleRottenCommentID)
+		if !ok {
+			// label is added by someone else, start counting from now.
+			return m.comment(issue, utils.LifecycleRottenCommentID, stages.RottenDays, stages.CloseDays)
+		}
+		if lastActivity.After(rottenAt) {
+			return m.reactivate(num)
+		}
+		if now.Sub(rottenAt) >= days(stages.CloseDays) {
+			if err := m.comment(issue, utils.LifecycleClosedCommentID, stages.CloseDays, 0); err != nil {
+				return err
+			}
+			logrus.Infof("close rotten issue %d", num)
+			return m.client.CloseIssue(num)
+		}
+	case isStale:
+		staleAt, ok := markedAt(comments, robot, utils.LifecycleStaleCommentID)
+		if !ok {
+			return m.comment(issue, utils.LifecycleStaleCommentID, stages.StaleDays, stages.RottenDays)
+		}
+		if lastActivity.After(staleAt) {
+			return m.reactivate(num)
+		}
+		if now.Sub(staleAt) >= days(stages.RottenDays) {
+			if err := m.comment(issue, utils.LifecycleRottenCommentID, stages.RottenDays, stages.CloseDays); err != nil {
+				return err
+			}
+			m.client.RemoveLabelForIssue(num, utils.StaleLabel)
+			return m.client.AddLabelsToIssue(num, []string{utils.RottenLabel})
+		}
+	default:
+		if now.Sub(lastActivity) >= days(stages.StaleDays) {
+			if err := m.comment(issue, utils.LifecycleStaleCommentID, stages.StaleDays, stages.RottenDays); err != nil {
+				return err
+			}
+			return m.client.AddLabelsToIssue(num, []string{utils.StaleLabel})
+		}
+	}
+	return nil
+}
+
+// Stages returns durations of lifecycle stages for an issue with labels.
+func (m *Manager) Stages(labels []string) config.LifecycleStages {
+	stages := m.cfg.LifecycleStages
+	for _, labelStages := range m.cfg.LabelStages {
+		if utils.SliceContainsElement(labels, labelStages.Label) {
+			stages = mergeStages(labelStages.LifecycleStages, stages)
+			break
+		}
+	}
+	return mergeStages(stages, config.LifecycleStages{
+		StaleDays:  DefaultStaleDays,
+		RottenDays: DefaultRottenDays,
+		CloseDays:  DefaultCloseDays,
+	})
+}
+
+// reactivate removes stale and rotten labels and comments from an issue.
+func (m *Manager) reactivate(num int) error {
+	logrus.Infof("issue %d becomes active again", num)
+	for _, label := range []string{utils.StaleLabel, utils.RottenLabel} {
+		if m.client.IssueHasLabel(num, label) {
+			m.client.RemoveLabelForIssue(num, label)
+		}
+	}
+	m.client.RemoveMarkedComments(num, utils.LifecycleStaleCommentID)
+	return m.client.RemoveMarkedComments(num, utils.LifecycleRottenCommentID)
+}
+
+func (m *Manager) comment(issue *github.Issue, id string, stageDays, nextDays int) error {
+	kind := "issue"
+	if issue.PullRequestLinks != nil {
+		kind = "pull request"
+	}
+
+	body, err := m.templates.Render(id, Data{
+		Author:   issue.User.GetLogin(),
+		Kind:     kind,
+		Days:     stageDays,
+		NextDays: nextDays,
+	})
+	if err != nil {
+		return err
+	}
+	return m.client.UpsertComment(issue.GetNumber(), id, body)
+}
+
+// RemoveCommandLabels returns lifecycle labels to remove according to
+// commands like "/remove-lifecycle stale" in a comment body.
+func RemoveCommandLabels(body string) []string {
+	var labels []string
+	for _, match := range removeCommandRegex.FindAllStringSubmatch(body, -1) {
+		labels = append(labels, "lifecycle/"+match[1])
+	}
+	return labels
+}
+
+// lastHumanActivity returns time of the latest activity on issue which is
+// not done by robot or other bots, among creation, comments and commits of
+// pull request. updated_at is not used since robot's own comments and
+// labels update it too.
+func lastHumanActivity(issue *github.Issue, comments []*github.IssueComment, commits []*github.RepositoryCommit, robot string) time.Time {
+	last := issue.GetCreatedAt()
+	for _, comment := range comments {
+		user := comment.User
+		if strings.EqualFold(user.GetLogin(), robot) || user.GetType() == "Bot" {
+			continue
+		}
+		if comment.GetUpdatedAt().After(last) {
+			last = comment.GetUpdatedAt()
+		}
+	}
+	for _, commit := range commits {
+		if commit.Commit == nil || commit.Commit.Committer == nil {
+			continue
+		}
+		if date := commit.Commit.Committer.GetDate(); date.After(last) {
+			last = date
+		}
+	}
+	return last
+}
+
+// markedAt returns when robot last posted or updated the comment identified by id.
+func markedAt(comments []*github.IssueComment, robot, id string) (time.Time, bool) {
+	marker := gh.CommentMarker(id)
+	for _, comment := range comments {
+		if strings.EqualFold(comment.User.GetLogin(), robot) && strings.Contains(comment.GetBody(), marker) {
+			return comment.GetUpdatedAt(), true
+		}
+	}
+	return time.Time{}, false
+}
+
+func mergeStages(stages, defaults config.LifecycleStages) config.LifecycleStages {
+	if stages.StaleDays <= 0 {
+		stages.StaleDays = defaults.StaleDays
+	}
+	if stages.RottenDays <= 0 {
+		stages.RottenDays = defaults.RottenDays
+	}
+	if stages.CloseDays <= 0 {
+		stages.CloseDays = defaults.CloseDays
+	}
+	return stages
+}
+
+func labelNames(issue *github.Issue) []string {
+	var names []string
+	for _, label := range issue.Labels {
+		names = append(names, label.GetName())
+	}
+	return names
+}
+
+func containsAny(labels, candidates []string) bool {
+	for _, candidate := range candidates {
+		if utils.SliceContainsElement(labels, candidate) {
+			return true
+		}
+	}
+	return false
+}
+
+func days(n int) time.Duration {
+	return time.Duration(n) * day
+}
@@ -0,0 +1,310 @@
+// Copyright 2018 The Pouch Robot Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package lifecycle
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/pouchcontainer/pouchrobot/config"
+	"github.com/pouchcontainer/pouchrobot/gh"
+	"github.com/pouchcontainer/pouchrobot/utils"
+	"github.com/pouchcontainer/pouchrobot/utils/templates"
+
+	"github.com/google/go-github/github"
+)
+
+// robot is the login of robot in tests.
+const robot = "pouchrobot"
+
+// now is the fixed time checks run at in tests.
+var now = time.Date(2018, 6, 1, 0, 0, 0, 0, time.UTC)
+
+// daysAgo returns the time n days before now.
+func daysAgo(n int) time.Time {
+	return now.Add(-days(n))
+}
+
+// fakeClient serves an issue and records calls robot makes to GitHub.
+type fakeClient struct {
+	labels    []string
+	labeledAt time.Time
+	comments  []*github.IssueComment
+	commits   []*github.RepositoryCommit
+	calls     []string
+}
+
+func (c *fakeClient) Login() (string, error) { return robot, nil }
+
+func (c *fakeClient) ListOpenIssues() ([]*github.Issue, error) { return nil, nil }
+
+func (c *fakeClient) ListComments(num int) ([]*github.IssueComment, error) { return c.comments, nil }
+
+func (c *fakeClient) ListCommits(num int) ([]*github.RepositoryCommit, error) { return c.commits, nil }
+
+func (c *fakeClient) LabeledAt(num int, label string) (time.Time, bool, error) {
+	return c.labeledAt, !c.labeledAt.IsZero(), nil
+}
+
+func (c *fakeClient) IssueHasLabel(num int, label string) bool {
+	return utils.SliceContainsElement(c.labels, label)
+}
+
+func (c *fakeClient) GetStrLabelsInIssue(num int) ([]string, error) { return c.labels, nil }
+
+func (c *fakeClient) AddLabelsToIssue(num int, labels []string) error {
+	c.calls = append(c.calls, fmt.Sprintf("add %v", labels))
+	return nil
+}
+
+func (c *fakeClient) RemoveLabelForIssue(num int, label string) error {
+	c.calls = append(c.calls, fmt.Sprintf("remove %s", label))
+	return nil
+}
+
+func (c *fakeClient) UpsertComment(num int, id string, body string) error {
+	c.calls = append(c.calls, fmt.Sprintf("comment %s", id))
+	return nil
+}
+
+func (c *fakeClient) RemoveMarkedComments(num int, id string) error {
+	c.calls = append(c.calls, fmt.Sprintf("uncomment %s", id))
+	return nil
+}
+
+func (c *fakeClient) CloseIssue(num int) error {
+	c.calls = append(c.calls, "close")
+	return nil
+}
+
+// comment returns a comment of user updated at.
+func comment(user string, at time.Time, body string) *github.IssueComment {
+	return &github.IssueComment{
+		User:      &github.User{Login: github.String(user)},
+		Body:      github.String(body),
+		CreatedAt: &at,
+		UpdatedAt: &at,
+	}
+}
+
+// marked returns a comment of robot marked by id and updated at.
+func marked(id string, at time.Time) *github.IssueComment {
+	return comment(robot, at, gh.CommentMarker(id))
+}
+
+// commit returns a commit committed at.
+func commit(at time.Time) *github.RepositoryCommit {
+	return &github.RepositoryCommit{Commit: &github.Commit{Committer: &github.CommitAuthor{Date: &at}}}
+}
+
+func TestStages(t *testing.T) {
+	m := New(nil, nil, config.LifecycleConfig{
+		LifecycleStages: config.LifecycleStages{StaleDays: 60},
+		LabelStages: []config.LabelLifecycleStages{
+			{Label: "kind/question", LifecycleStages: config.LifecycleStages{StaleDays: 14, CloseDays: 7}},
+		},
+	})
+
+	tests := []struct {
+		name   string
+		labels []string
+		want   config.LifecycleStages
+	}{
+		{name: "defaults", labels: nil, want: config.LifecycleStages{StaleDays: 60, RottenDays: DefaultRottenDays, CloseDays: DefaultCloseDays}},
+		{name: "label override", labels: []string{"kind/question"}, want: config.LifecycleStages{StaleDays: 14, RottenDays: DefaultRottenDays, CloseDays: 7}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := m.Stages(tt.labels); got != tt.want {
+				t.Errorf("Stages() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRemoveCommandLabels(t *testing.T) {
+	body := "still working on it\n/remove-lifecycle stale\n/remove-lifecycle rotten"
+	want := []string{"lifecycle/stale", "lifecycle/rotten"}
+	if got := RemoveCommandLabels(body); !reflect.DeepEqual(got, want) {
+		t.Errorf("RemoveCommandLabels() = %v, want %v", got, want)
+	}
+	if got := RemoveCommandLabels("please /remove-lifecycle stale"); got != nil {
+		t.Errorf("RemoveCommandLabels() = %v, want nil for command not at line start", got)
+	}
+}
+
+func TestCheck(t *testing.T) {
+	tests := []struct {
+		name        string
+		labels      []string
+		updated     time.Time
+		pullRequest bool
+		comments    []*github.IssueComment
+		commits     []*github.RepositoryCommit
+		want        []string
+	}{
+		{
+			name:    "active",
+			updated: daysAgo(10),
+		},
+		{
+			name:    "exempt",
+			labels:  []string{utils.FrozenLabel},
+			updated: daysAgo(100),
+		},
+		{
+			name:    "inactive",
+			updated: daysAgo(100),
+			want:    []string{"comment " + utils.LifecycleStaleCommentID, "add [" + utils.StaleLabel + "]"},
+		},
+		{
+			name:    "stale by hand",
+			labels:  []string{utils.StaleLabel},
+			updated: daysAgo(1),
+			want:    []string{"comment " + utils.LifecycleStaleCommentID},
+		},
+		{
+			name:     "stale for a while",
+			labels:   []string{utils.StaleLabel},
+			updated:  daysAgo(10),
+			comments: []*github.IssueComment{marked(utils.LifecycleStaleCommentID, daysAgo(10))},
+		},
+		{
+			name:     "stale becomes rotten",
+			labels:   []string{utils.StaleLabel},
+			updated:  daysAgo(31),
+			comments: []*github.IssueComment{marked(utils.LifecycleStaleCommentID, daysAgo(31))},
+			want: []string{
+				"comment " + utils.LifecycleRottenCommentID,
+				"remove " + utils.StaleLabel,
+				"add [" + utils.RottenLabel + "]",
+			},
+		},
+		{
+			name:    "stale reactivated by comment",
+			labels:  []string{utils.StaleLabel},
+			updated: daysAgo(5),
+			comments: []*github.IssueComment{
+				marked(utils.LifecycleStaleCommentID, daysAgo(31)),
+				comment("contributor", daysAgo(5), "still working on it"),
+			},
+			want: []string{
+				"remove " + utils.StaleLabel,
+				"uncomment " + utils.LifecycleStaleCommentID,
+				"uncomment " + utils.LifecycleRottenCommentID,
+			},
+		},
+		{
+			name:        "stale pull request reactivated by commit",
+			labels:      []string{utils.StaleLabel},
+			updated:     daysAgo(5),
+			pullRequest: true,
+			comments:    []*github.IssueComment{marked(utils.LifecycleStaleCommentID, daysAgo(31))},
+			commits:     []*github.RepositoryCommit{commit(daysAgo(100)), commit(daysAgo(5))},
+			want: []string{
+				"remove " + utils.StaleLabel,
+				"uncomment " + utils.LifecycleStaleCommentID,
+				"uncomment " + utils.LifecycleRottenCommentID,
+			},
+		},
+		{
+			name:     "rotten is closed",
+			labels:   []string{utils.RottenLabel},
+			updated:  daysAgo(31),
+			comments: []*github.IssueComment{marked(utils.LifecycleRottenCommentID, daysAgo(31))},
+			want:     []string{"comment " + utils.LifecycleClosedCommentID, "close"},
+		},
+		{
+			name:    "rotten reactivated by comment",
+			labels:  []string{utils.RottenLabel},
+			updated: daysAgo(1),
+			comments: []*github.IssueComment{
+				marked(utils.LifecycleRottenCommentID, daysAgo(31)),
+				comment("contributor", daysAgo(1), "rebased"),
+			},
+			want: []string{
+				"remove " + utils.RottenLabel,
+				"uncomment " + utils.LifecycleStaleCommentID,
+				"uncomment " + utils.LifecycleRottenCommentID,
+			},
+		},
+		{
+			name:    "rotten by hand",
+			labels:  []string{utils.RottenLabel},
+			updated: daysAgo(1),
+			want:    []string{"comment " + utils.LifecycleRottenCommentID},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := &fakeClient{labels: tt.labels, comments: tt.comments, commits: tt.commits}
+			m := New(client, templates.New("", "en"), config.LifecycleConfig{})
+
+			created := daysAgo(200)
+			issue := &github.Issue{
+				Number:    github.Int(12),
+				User:      &github.User{Login: github.String("contributor")},
+				CreatedAt: &created,
+				UpdatedAt: &tt.updated,
+			}
+			for _, label := range tt.labels {
+				issue.Labels = append(issue.Labels, github.Label{Name: github.String(label)})
+			}
+			if tt.pullRequest {
+				issue.PullRequestLinks = &github.PullRequestLinks{}
+			}
+
+			if err := m.Check(issue, now); err != nil {
+				t.Fatalf("Check() error = %v", err)
+			}
+			if !reflect.DeepEqual(client.calls, tt.want) {
+				t.Errorf("Check() calls = %q, want %q", client.calls, tt.want)
+			}
+		})
+	}
+}
+
+func TestLastHumanActivity(t *testing.T) {
+	created := daysAgo(200)
+	issue := &github.Issue{CreatedAt: &created}
+	bot := comment("ci-bot", daysAgo(1), "build passed")
+	bot.User.Type = github.String("Bot")
+
+	tests := []struct {
+		name     string
+		comments []*github.IssueComment
+		commits  []*github.RepositoryCommit
+		want     time.Time
+	}{
+		{name: "created", want: created},
+		{name: "comment", comments: []*github.IssueComment{comment("contributor", daysAgo(20), "ping")}, want: daysAgo(20)},
+		{name: "robot and bots", comments: []*github.IssueComment{comment(robot, daysAgo(1), "stale"), bot}, want: created},
+		{
+			name:     "commit",
+			comments: []*github.IssueComment{comment("contributor", daysAgo(20), "ping")},
+			commits:  []*github.RepositoryCommit{commit(daysAgo(3)), {Commit: &github.Commit{}}},
+			want:     daysAgo(3),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := lastHumanActivity(issue, tt.comments, tt.commits, robot); !got.Equal(tt.want) {
+				t.Errorf("lastHumanActivity() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
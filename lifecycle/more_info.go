@@ -0,0 +1,144 @@
+// Copyright 2018 The Pouch Robot Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package lifecycle
+
+import (
+	"strings"
+	"time"
+
+	"github.com/pouchcontainer/pouchrobot/config"
+	"github.com/pouchcontainer/pouchrobot/utils"
+	"github.com/pouchcontainer/pouchrobot/utils/templates"
+
+	"github.com/google/go-github/github"
+	"github.com/sirupsen/logrus"
+)
+
+// Default durations of following up issues which need more information in days.
+const (
+	DefaultRemindDays        = 7
+	DefaultMoreInfoCloseDays = 7
+)
+
+// MoreInfoChecker follows up issues labeled status/more-info-needed. It
+// reminds the author after a while without response, and closes the issue
+// if there is still no response after the reminder.
+type MoreInfoChecker struct {
+	client     Client
+	templates  *templates.Renderer
+	remindDays int
+	closeDays  int
+}
+
+// NewMoreInfoChecker initializes a brand new checker.
+func NewMoreInfoChecker(client Client, renderer *templates.Renderer, cfg config.MoreInfoConfig) *MoreInfoChecker {
+	checker := &MoreInfoChecker{
+		client:     client,
+		templates:  renderer,
+		remindDays: cfg.RemindDays,
+		closeDays:  cfg.CloseDays,
+	}
+	if checker.remindDays <= 0 {
+		checker.remindDays = DefaultRemindDays
+	}
+	if checker.closeDays <= 0 {
+		checker.closeDays = DefaultMoreInfoCloseDays
+	}
+	return checker
+}
+
+// CheckAll checks all open issues labeled status/more-info-needed.
+func (c *MoreInfoChecker) CheckAll() error {
+	issues, err := c.client.ListOpenIssues()
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	for _, issue := range issues {
+		if issue.PullRequestLinks != nil || !utils.SliceContainsElement(labelNames(issue), utils.MoreInfoNeededLabel) {
+			continue
+		}
+		if err := c.Check(issue, now); err != nil {
+			logrus.Errorf("failed to follow up issue %d needing more info: %v", issue.GetNumber(), err)
+		}
+	}
+	return nil
+}
+
+// Check follows up an issue labeled status/more-info-needed.
+func (c *MoreInfoChecker) Check(issue *github.Issue, now time.Time) error {
+	num := issue.GetNumber()
+	labeledAt, ok, err := c.client.LabeledAt(num, utils.MoreInfoNeededLabel)
+	if err != nil || !ok {
+		return err
+	}
+
+	comments, err := c.client.ListComments(num)
+	if err != nil {
+		return err
+	}
+	robot, err := c.client.Login()
+	if err != nil {
+		return err
+	}
+
+	// a reply missed by webhook also counts.
+	author := issue.User.GetLogin()
+	for _, comment := range comments {
+		if strings.EqualFold(comment.User.GetLogin(), author) && comment.GetCreatedAt().After(labeledAt) {
+			return ResolveMoreInfo(c.client, num)
+		}
+	}
+
+	remindedAt, reminded := markedAt(comments, robot, utils.MoreInfoReminderCommentID)
+	if reminded && remindedAt.Before(labeledAt) {
+		// reminder of a previous round.
+		reminded = false
+	}
+
+	data := Data{Author: author, Kind: "issue", Days: c.remindDays, NextDays: c.closeDays}
+	switch {
+	case !reminded && now.Sub(labeledAt) >= days(c.remindDays):
+		body, err := c.templates.Render(utils.MoreInfoReminderCommentID, data)
+		if err != nil {
+			return err
+		}
+		return c.client.UpsertComment(num, utils.MoreInfoReminderCommentID, body)
+	case reminded && now.Sub(remindedAt) >= days(c.closeDays):
+		data.Days, data.NextDays = c.remindDays+c.closeDays, 0
+		body, err := c.templates.Render(utils.MoreInfoClosedCommentID, data)
+		if err != nil {
+			return err
+		}
+		if err := c.client.UpsertComment(num, utils.MoreInfoClosedCommentID, body); err != nil {
+			return err
+		}
+		logrus.Infof("close issue %d since no more info is provided", num)
+		return c.client.CloseIssue(num)
+	}
+	return nil
+}
+
+// ResolveMoreInfo removes label status/more-info-needed and the reminder
+// from an issue, since the author responds.
+func ResolveMoreInfo(client Client, num int) error {
+	if client.IssueHasLabel(num, utils.MoreInfoNeededLabel) {
+		if err := client.RemoveLabelForIssue(num, utils.MoreInfoNeededLabel); err != nil {
+			return err
+		}
+	}
+	return client.RemoveMarkedComments(num, utils.MoreInfoReminderCommentID)
+}
@@ -0,0 +1,138 @@
+// Copyright 2018 The Pouch Robot Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package lifecycle
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/pouchcontainer/pouchrobot/config"
+	"github.com/pouchcontainer/pouchrobot/utils"
+	"github.com/pouchcontainer/pouchrobot/utils/templates"
+
+	"github.com/google/go-github/github"
+)
+
+func TestMoreInfoCheck(t *testing.T) {
+	resolved := []string{
+		"remove " + utils.MoreInfoNeededLabel,
+		"uncomment " + utils.MoreInfoReminderCommentID,
+	}
+
+	tests := []struct {
+		name      string
+		labeledAt time.Time
+		comments  []*github.IssueComment
+		want      []string
+	}{
+		{
+			name: "not labeled",
+		},
+		{
+			name:      "waiting",
+			labeledAt: daysAgo(3),
+		},
+		{
+			name:      "remind",
+			labeledAt: daysAgo(7),
+			want:      []string{"comment " + utils.MoreInfoReminderCommentID},
+		},
+		{
+			name:      "reminded",
+			labeledAt: daysAgo(10),
+			comments:  []*github.IssueComment{marked(utils.MoreInfoReminderCommentID, daysAgo(3))},
+		},
+		{
+			name:      "close",
+			labeledAt: daysAgo(14),
+			comments:  []*github.IssueComment{marked(utils.MoreInfoReminderCommentID, daysAgo(7))},
+			want:      []string{"comment " + utils.MoreInfoClosedCommentID, "close"},
+		},
+		{
+			name:      "reminder of previous round",
+			labeledAt: daysAgo(8),
+			comments:  []*github.IssueComment{marked(utils.MoreInfoReminderCommentID, daysAgo(30))},
+			want:      []string{"comment " + utils.MoreInfoReminderCommentID},
+		},
+		{
+			name:      "author replied",
+			labeledAt: daysAgo(14),
+			comments: []*github.IssueComment{
+				marked(utils.MoreInfoReminderCommentID, daysAgo(7)),
+				comment("Reporter", daysAgo(1), "here is the log"),
+			},
+			want: resolved,
+		},
+		{
+			name:      "author replied before labeled",
+			labeledAt: daysAgo(7),
+			comments:  []*github.IssueComment{comment("reporter", daysAgo(8), "it fails")},
+			want:      []string{"comment " + utils.MoreInfoReminderCommentID},
+		},
+		{
+			name:      "others replied",
+			labeledAt: daysAgo(3),
+			comments:  []*github.IssueComment{comment("someone", daysAgo(1), "me too")},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := &fakeClient{labels: []string{utils.MoreInfoNeededLabel}, labeledAt: tt.labeledAt, comments: tt.comments}
+			c := NewMoreInfoChecker(client, templates.New("", "en"), config.MoreInfoConfig{})
+			issue := &github.Issue{
+				Number: github.Int(34),
+				User:   &github.User{Login: github.String("reporter")},
+			}
+
+			if err := c.Check(issue, now); err != nil {
+				t.Fatalf("Check() error = %v", err)
+			}
+			if !reflect.DeepEqual(client.calls, tt.want) {
+				t.Errorf("Check() calls = %q, want %q", client.calls, tt.want)
+			}
+		})
+	}
+}
+
+func TestResolveMoreInfo(t *testing.T) {
+	tests := []struct {
+		name   string
+		labels []string
+		want   []string
+	}{
+		{
+			name:   "labeled",
+			labels: []string{utils.MoreInfoNeededLabel, "kind/bug"},
+			want:   []string{"remove " + utils.MoreInfoNeededLabel, "uncomment " + utils.MoreInfoReminderCommentID},
+		},
+		{
+			name:   "label removed by hand",
+			labels: []string{"kind/bug"},
+			want:   []string{"uncomment " + utils.MoreInfoReminderCommentID},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := &fakeClient{labels: tt.labels}
+			if err := ResolveMoreInfo(client, 34); err != nil {
+				t.Fatalf("ResolveMoreInfo() error = %v", err)
+			}
+			if !reflect.DeepEqual(client.calls, tt.want) {
+				t.Errorf("ResolveMoreInfo() calls = %q, want %q", client.calls, tt.want)
+			}
+		})
+	}
+}
@@ -15,8 +15,6 @@
 package main
 
 import (
-	"encoding/json"
-	"io/ioutil"
 	"os"
 
 	"github.com/pouchcontainer/pouchrobot/config"
@@ -25,23 +23,28 @@ import (
 	"github.com/spf13/cobra"
 )
 
-var rootCmd = &cobra.Command{}
+var rootCmd = &cobra.Command{
+	Use:               "pouchrobot",
+	Short:             "An AI-based collaboration robot applied to open source project on GitHub",
+	Args:              cobra.NoArgs,
+	SilenceUsage:      true,
+	SilenceErrors:     true,
+	DisableAutoGenTag: true, // disable displaying auto generation tag in cli docs
+	PersistentPreRun: func(cmd *cobra.Command, args []string) {
+		if cmdCfg.Debug {
+			logrus.Infof("start at debug level")
+			logrus.SetLevel(logrus.DebugLevel)
+		}
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runDaemon(cmd)
+	},
+}
 var cmdCfg config.CmdConfig
 
 func main() {
-	rootCmd = &cobra.Command{
-		Use:               "pouchrobot",
-		Short:             "An AI-based collaboration robot applied to open source project on GitHub",
-		Args:              cobra.NoArgs,
-		SilenceUsage:      true,
-		SilenceErrors:     true,
-		DisableAutoGenTag: true, // disable displaying auto generation tag in cli docs
-		RunE: func(cmd *cobra.Command, args []string) error {
-			return runDaemon(cmd)
-		},
-	}
-
-	flagSet := rootCmd.Flags()
+	// sub commands are registered to rootCmd in init functions of their own files.
+	flagSet := rootCmd.PersistentFlags()
 	flagSet.StringVarP(&cmdCfg.ConfigFilePath, "config", "c", "config.json", "Config file path for robot")
 	flagSet.BoolVarP(&cmdCfg.Debug, "debug", "D", false, "Switch daemon log level to DEBUG mode")
 
@@ -52,27 +55,20 @@ func main() {
 }
 
 func runDaemon(cmd *cobra.Command) error {
-	if cmdCfg.Debug {
-		logrus.Infof("start daemon at debug level")
-		logrus.SetLevel(logrus.DebugLevel)
-	}
-
-	configContent, err := ioutil.ReadFile(cmdCfg.ConfigFilePath)
+	cfg, err := config.Load(cmdCfg.ConfigFilePath)
 	if err != nil {
 		return err
 	}
 
-	var cfg config.Config
-	if err := json.Unmarshal(configContent, &cfg); err != nil {
-		return err
-	}
-
-	logrus.Debugf("config value is %v", cfg)
+	logrus.Infof("effective config is %s", cfg)
 
 	s, err := NewServer(cfg)
 	if err != nil {
 		return err
 	}
 
+	// reload config on SIGHUP or when config file is changed.
+	go s.WatchConfig(cmdCfg.ConfigFilePath)
+
 	return s.Run()
 }
@@ -0,0 +1,193 @@
+// Copyright 2018 The Pouch Robot Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package maintainers
+
+import (
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pouchcontainer/pouchrobot/gh"
+	"github.com/pouchcontainer/pouchrobot/scheduler"
+
+	"github.com/sirupsen/logrus"
+)
+
+// DefaultFile is the default path of maintainers file in repository.
+const DefaultFile = "MAINTAINERS"
+
+// DefaultRefreshInterval is the default interval to reload maintainers file.
+const DefaultRefreshInterval = 60 * time.Minute
+
+// Maintainers caches maintainers of repository loaded from a file in the
+// default branch of repository, and refreshes them periodically.
+type Maintainers struct {
+	sync.RWMutex
+
+	client   *gh.Client
+	file     string
+	format   string
+	interval time.Duration
+
+	// users are lower cased GitHub IDs of maintainers, including members
+	// of maintainer teams.
+	users map[string]bool
+
+	// teams are maintainer teams in form of "org/team".
+	teams []string
+
+	// teamMembers are lower cased members of each maintainer team expanded
+	// last time, kept for teams which fail to expand later.
+	teamMembers map[string][]string
+
+	// fallback are maintainers from config, used until maintainers file is
+	// loaded successfully.
+	fallback []string
+
+	// loaded is true once maintainers file is loaded successfully.
+	loaded bool
+}
+
+// New initializes a brand new maintainers cache. Maintainers are seeded
+// from fallback until Refresh succeeds.
+func New(client *gh.Client, file, format string, interval time.Duration, fallback []string) *Maintainers {
+	if file == "" {
+		file = DefaultFile
+	}
+	if interval <= 0 {
+		interval = DefaultRefreshInterval
+	}
+	return &Maintainers{
+		client:      client,
+		file:        file,
+		format:      format,
+		interval:    interval,
+		users:       toUsers(fallback),
+		teamMembers: map[string][]string{},
+		fallback:    fallback,
+	}
+}
+
+// Reload switches maintainers file, format, refresh interval and fallback
+// maintainers. Maintainers from the new file are loaded by the next Refresh.
+func (m *Maintainers) Reload(file, format string, interval time.Duration, fallback []string) {
+	if file == "" {
+		file = DefaultFile
+	}
+	if interval <= 0 {
+		interval = DefaultRefreshInterval
+	}
+
+	m.Lock()
+	m.file = file
+	m.format = format
+	m.interval = interval
+	m.fallback = fallback
+	if !m.loaded {
+		m.users = toUsers(fallback)
+	}
+	m.Unlock()
+}
+
+// settings returns maintainers file, format and refresh interval in effect.
+func (m *Maintainers) settings() (string, string, time.Duration) {
+	m.RLock()
+	defer m.RUnlock()
+	return m.file, m.format, m.interval
+}
+
+// Schedule returns the cron expression of refreshing maintainers, which runs
+// about every refresh interval in effect.
+func (m *Maintainers) Schedule() string {
+	_, _, interval := m.settings()
+	return scheduler.Every(interval)
+}
+
+// Refresh reloads maintainers file from repository and expands maintainer
+// teams to their members. Cached maintainers are kept if it fails, and
+// members of a team which fails to expand are kept from last expansion.
+func (m *Maintainers) Refresh() error {
+	file, format, _ := m.settings()
+	data, err := m.client.GetFileContent(file)
+	if err != nil {
+		return err
+	}
+
+	if format == "" {
+		format = DetectFormat(file, data)
+	}
+
+	logins, teams, err := Parse(format, data)
+	if err != nil {
+		return err
+	}
+
+	users := toUsers(logins)
+
+	m.RLock()
+	lastMembers := m.teamMembers
+	m.RUnlock()
+
+	teamMembers := map[string][]string{}
+	for _, team := range teams {
+		parts := strings.SplitN(team, "/", 2)
+		members, err := m.client.ListTeamMembers(parts[0], parts[1])
+		if err != nil {
+			// a team which fails to expand should not drop its known members.
+			logrus.Warnf("failed to expand maintainer team %s, keep %d members expanded before: %v",
+				team, len(lastMembers[team]), err)
+			members = lastMembers[team]
+		}
+		teamMembers[team] = members
+		for _, member := range members {
+			users[strings.ToLower(member)] = true
+		}
+	}
+
+	m.Lock()
+	m.users = users
+	m.teams = teams
+	m.teamMembers = teamMembers
+	m.loaded = true
+	m.Unlock()
+
+	logrus.Infof("succeed in loading %d maintainers from %s", len(users), file)
+	return nil
+}
+
+// IsMaintainer returns true if user is a maintainer or a member of maintainer team.
+func (m *Maintainers) IsMaintainer(user string) bool {
+	m.RLock()
+	defer m.RUnlock()
+
+	return m.users[strings.ToLower(user)]
+}
+
+// Teams returns maintainer teams in form of "org/team".
+func (m *Maintainers) Teams() []string {
+	m.RLock()
+	defer m.RUnlock()
+
+	return append([]string{}, m.teams...)
+}
+
+// toUsers converts logins to a set of lower cased logins.
+func toUsers(logins []string) map[string]bool {
+	users := map[string]bool{}
+	for _, login := range logins {
+		users[strings.ToLower(login)] = true
+	}
+	return users
+}
@@ -0,0 +1,52 @@
+// Copyright 2018 The Pouch Robot Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package maintainers
+
+import (
+	"testing"
+)
+
+func TestFallback(t *testing.T) {
+	m := New(nil, "", "", 0, []string{"AllenSun"})
+
+	tests := []struct {
+		name string
+		user string
+		want bool
+	}{
+		{name: "fallback", user: "allensun", want: true},
+		{name: "other", user: "someone", want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := m.IsMaintainer(tt.user); got != tt.want {
+				t.Errorf("IsMaintainer(%q) = %v, want %v", tt.user, got, tt.want)
+			}
+		})
+	}
+
+	m.Reload("", "", 0, []string{"someone"})
+	if m.IsMaintainer("allensun") || !m.IsMaintainer("someone") {
+		t.Errorf("fallback is not switched before maintainers file is loaded")
+	}
+
+	m.Lock()
+	m.loaded = true
+	m.Unlock()
+	m.Reload("", "", 0, []string{"another"})
+	if m.IsMaintainer("another") || !m.IsMaintainer("someone") {
+		t.Errorf("fallback should not replace maintainers loaded from file")
+	}
+}
@@ -0,0 +1,161 @@
+// Copyright 2018 The Pouch Robot Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package maintainers
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"gopkg.in/yaml.v2"
+)
+
+const (
+	// FormatTOML is the format of MAINTAINERS file used by projects like
+	// Docker and PouchContainer, in which maintainers are listed in arrays
+	// named "people" or in "GitHub" fields.
+	FormatTOML = "toml"
+
+	// FormatYAML is the format of OWNERS file, in which maintainers are
+	// listed in "maintainers" or "approvers".
+	FormatYAML = "yaml"
+
+	// FormatPlain is a plain text format in which each line contains a
+	// GitHub ID like "allencloud" or "Allen Sun <allen@example.com> (@allencloud)".
+	FormatPlain = "plain"
+)
+
+// mentionRegex matches a mention of a user or a team like @allencloud or @org/team,
+// but not domain of an email address.
+var mentionRegex = regexp.MustCompile(`(?:^|[^A-Za-z0-9._%+-])@([A-Za-z0-9][A-Za-z0-9-]*(/[A-Za-z0-9._-]+)?)`)
+
+// DetectFormat detects format of maintainers file from its path and content.
+func DetectFormat(path string, data []byte) string {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".toml":
+		return FormatTOML
+	case ".yml", ".yaml":
+		return FormatYAML
+	}
+
+	if strings.EqualFold(filepath.Base(path), "OWNERS") {
+		return FormatYAML
+	}
+	if entries, err := parseTOML(data); err == nil && len(entries) != 0 {
+		return FormatTOML
+	}
+	return FormatPlain
+}
+
+// Parse parses maintainers file in format, and returns GitHub IDs of
+// maintainers and teams in form of "org/team".
+func Parse(format string, data []byte) ([]string, []string, error) {
+	var entries []string
+	switch format {
+	case FormatTOML:
+		var err error
+		if entries, err = parseTOML(data); err != nil {
+			return nil, nil, err
+		}
+	case FormatYAML:
+		var err error
+		if entries, err = parseYAML(data); err != nil {
+			return nil, nil, err
+		}
+	case FormatPlain:
+		entries = parsePlain(data)
+	default:
+		return nil, nil, fmt.Errorf("unknown maintainers file format %q", format)
+	}
+
+	var users, teams []string
+	seen := map[string]bool{}
+	for _, entry := range entries {
+		entry = strings.TrimPrefix(strings.TrimSpace(entry), "@")
+		if entry == "" || seen[strings.ToLower(entry)] {
+			continue
+		}
+		seen[strings.ToLower(entry)] = true
+
+		if strings.Contains(entry, "/") {
+			teams = append(teams, entry)
+			continue
+		}
+		users = append(users, entry)
+	}
+	return users, teams, nil
+}
+
+// parseTOML returns strings in "people" arrays like `people = ["a", "b"]`,
+// or if there is none, GitHub fields of [people.*] tables.
+func parseTOML(data []byte) ([]string, error) {
+	tomlEntries, err := scanTOML(data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse maintainers file as TOML: %v", err)
+	}
+
+	var entries []string
+	for _, entry := range tomlEntries {
+		if strings.EqualFold(entry.key[len(entry.key)-1], "people") {
+			entries = append(entries, entry.strings...)
+		}
+	}
+	if len(entries) != 0 {
+		return entries, nil
+	}
+
+	for _, entry := range tomlEntries {
+		table := append(append([]string{}, entry.table...), entry.key[:len(entry.key)-1]...)
+		if len(table) == 2 && strings.EqualFold(table[0], "people") &&
+			strings.EqualFold(entry.key[len(entry.key)-1], "github") {
+			entries = append(entries, entry.strings...)
+		}
+	}
+	return entries, nil
+}
+
+func parseYAML(data []byte) ([]string, error) {
+	var owners struct {
+		Maintainers []string `yaml:"maintainers"`
+		Approvers   []string `yaml:"approvers"`
+	}
+	if err := yaml.Unmarshal(data, &owners); err != nil {
+		return nil, fmt.Errorf("failed to parse maintainers file as YAML: %v", err)
+	}
+	return append(owners.Maintainers, owners.Approvers...), nil
+}
+
+func parsePlain(data []byte) []string {
+	var entries []string
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		if mentions := mentionRegex.FindAllStringSubmatch(line, -1); len(mentions) != 0 {
+			for _, mention := range mentions {
+				entries = append(entries, mention[1])
+			}
+			continue
+		}
+		entries = append(entries, strings.Fields(line)[0])
+	}
+	return entries
+}
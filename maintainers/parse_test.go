@@ -0,0 +1,160 @@
+// Copyright 2018 The Pouch Robot Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package maintainers
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name      string
+		file      string
+		data      string
+		wantUsers []string
+		wantTeams []string
+	}{
+		{
+			name: "toml people",
+			file: "MAINTAINERS",
+			data: `[Org]
+  [Org."Core maintainers"]
+    people = [
+      "allencloud",
+      "Ace-Tang",
+    ]
+  [Org.Teams]
+    people = ["@pouchcontainer/maintainers"]
+
+[people]
+  [people.allencloud]
+    Name = "Allen Sun"
+    GitHub = "allencloud"
+`,
+			wantUsers: []string{"allencloud", "Ace-Tang"},
+			wantTeams: []string{"pouchcontainer/maintainers"},
+		},
+		{
+			name: "toml github fields",
+			file: "MAINTAINERS.toml",
+			data: `[people.allencloud]
+  GitHub = "allencloud"
+[people.skoo87]
+  GitHub = "skoo87"
+`,
+			wantUsers: []string{"allencloud", "skoo87"},
+		},
+		{
+			name: "toml commented out entries",
+			file: "MAINTAINERS",
+			data: `# people = ["nobody"]
+[Org."Core maintainers"]
+  people = [
+    "allencloud", # joined in 2017
+    # "retired",
+    "Ace-Tang",
+  ]
+`,
+			wantUsers: []string{"allencloud", "Ace-Tang"},
+		},
+		{
+			name: "toml brackets inside values",
+			file: "MAINTAINERS",
+			data: `[Org."Core maintainers [2018]"]
+  description = "listed in [people = [] # not a comment"
+  people = [
+    "allencloud", # since [2017]
+    "skoo87",
+  ]
+`,
+			wantUsers: []string{"allencloud", "skoo87"},
+		},
+		{
+			name: "toml github fields of people tables only",
+			file: "MAINTAINERS",
+			data: `[people.allencloud]
+  Name = "Allen Sun [\"allen\"]"
+  GitHub = "allencloud"
+# [people.retired]
+#   GitHub = "retired"
+[people.skoo87]
+  # GitHub = "skoo"
+  GitHub = "skoo87" # current ID
+[Org.Bot]
+  GitHub = "pouchrobot"
+`,
+			wantUsers: []string{"allencloud", "skoo87"},
+		},
+		{
+			name: "yaml owners",
+			file: "OWNERS",
+			data: `maintainers:
+- allencloud
+approvers:
+- skoo87
+- allencloud
+- "@pouchcontainer/reviewers"
+`,
+			wantUsers: []string{"allencloud", "skoo87"},
+			wantTeams: []string{"pouchcontainer/reviewers"},
+		},
+		{
+			name: "plain",
+			file: "MAINTAINERS.txt",
+			data: `# maintainers of PouchContainer
+allencloud
+Allen Sun <allen@example.com> (@Ace-Tang)
+
+@pouchcontainer/maintainers
+`,
+			wantUsers: []string{"allencloud", "Ace-Tang"},
+			wantTeams: []string{"pouchcontainer/maintainers"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			users, teams, err := Parse(DetectFormat(tt.file, []byte(tt.data)), []byte(tt.data))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(users, tt.wantUsers) {
+				t.Errorf("Parse() users = %v, want %v", users, tt.wantUsers)
+			}
+			if !reflect.DeepEqual(teams, tt.wantTeams) {
+				t.Errorf("Parse() teams = %v, want %v", teams, tt.wantTeams)
+			}
+		})
+	}
+}
+
+func TestParseUnknownFormat(t *testing.T) {
+	if _, _, err := Parse("xml", nil); err == nil {
+		t.Errorf("Parse() expected error")
+	}
+}
+
+func TestParseInvalidTOML(t *testing.T) {
+	for _, data := range []string{
+		`people = ["allencloud"`,
+		`people = ["allencloud]`,
+		`[people.allencloud`,
+		`allencloud`,
+	} {
+		if _, _, err := Parse(FormatTOML, []byte(data)); err == nil {
+			t.Errorf("Parse(%q) expected error", data)
+		}
+	}
+}
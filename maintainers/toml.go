@@ -0,0 +1,346 @@
+// Copyright 2018 The Pouch Robot Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package maintainers
+
+import (
+	"fmt"
+	"strings"
+)
+
+// tomlEntry is a key/value pair of TOML document. Only strings in value are
+// kept, since maintainers are always listed in strings.
+type tomlEntry struct {
+	// table is the path of table which key belongs to, like ["people", "allencloud"].
+	table []string
+
+	// key is the dotted path of key, like ["people"].
+	key []string
+
+	// strings are all strings in value, including ones nested in arrays.
+	strings []string
+}
+
+// scanTOML scans key/value pairs of TOML document in data. It understands
+// comments, tables, strings, arrays and inline tables, which covers what
+// maintainers files use, rather than the whole TOML specification.
+func scanTOML(data []byte) ([]tomlEntry, error) {
+	s := &tomlScanner{data: data, line: 1}
+
+	var (
+		table   []string
+		entries []tomlEntry
+	)
+	for {
+		s.skipSpace(true)
+		if s.eof() {
+			return entries, nil
+		}
+
+		switch s.peek() {
+		case '#':
+			s.skipComment()
+			continue
+		case '[':
+			path, err := s.header()
+			if err != nil {
+				return nil, err
+			}
+			table = path
+		default:
+			key, err := s.keyPath()
+			if err != nil {
+				return nil, err
+			}
+			s.skipSpace(false)
+			if !s.consume('=') {
+				return nil, s.errorf("expected = after key %s", strings.Join(key, "."))
+			}
+			s.skipSpace(false)
+
+			entry := tomlEntry{table: table, key: key}
+			if err := s.value(&entry.strings); err != nil {
+				return nil, err
+			}
+			entries = append(entries, entry)
+		}
+
+		if err := s.endOfLine(); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// tomlScanner walks through TOML document byte by byte.
+type tomlScanner struct {
+	data []byte
+	pos  int
+	line int
+}
+
+func (s *tomlScanner) eof() bool {
+	return s.pos >= len(s.data)
+}
+
+func (s *tomlScanner) peek() byte {
+	return s.data[s.pos]
+}
+
+func (s *tomlScanner) next() byte {
+	c := s.data[s.pos]
+	s.pos++
+	if c == '\n' {
+		s.line++
+	}
+	return c
+}
+
+// consume skips c if it is the next byte.
+func (s *tomlScanner) consume(c byte) bool {
+	if s.eof() || s.peek() != c {
+		return false
+	}
+	s.next()
+	return true
+}
+
+func (s *tomlScanner) hasPrefix(prefix string) bool {
+	return strings.HasPrefix(string(s.data[s.pos:]), prefix)
+}
+
+func (s *tomlScanner) errorf(format string, args ...interface{}) error {
+	return fmt.Errorf("line %d: %s", s.line, fmt.Sprintf(format, args...))
+}
+
+// skipSpace skips blanks, and also newlines if newline is true.
+func (s *tomlScanner) skipSpace(newline bool) {
+	for !s.eof() {
+		switch s.peek() {
+		case ' ', '\t', '\r':
+		case '\n':
+			if !newline {
+				return
+			}
+		default:
+			return
+		}
+		s.next()
+	}
+}
+
+// skipComment skips a comment till the end of line.
+func (s *tomlScanner) skipComment() {
+	for !s.eof() && s.peek() != '\n' {
+		s.next()
+	}
+}
+
+// skipArraySpace skips blanks, newlines and comments between array elements.
+func (s *tomlScanner) skipArraySpace() {
+	for {
+		s.skipSpace(true)
+		if s.eof() || s.peek() != '#' {
+			return
+		}
+		s.skipComment()
+	}
+}
+
+// endOfLine makes sure nothing but a comment is left in current line.
+func (s *tomlScanner) endOfLine() error {
+	s.skipSpace(false)
+	if !s.eof() && s.peek() == '#' {
+		s.skipComment()
+	}
+	if !s.eof() && s.peek() != '\n' {
+		return s.errorf("unexpected %q at end of line", s.peek())
+	}
+	return nil
+}
+
+// header scans a table header like [people.allencloud] or [[people]].
+func (s *tomlScanner) header() ([]string, error) {
+	s.next()
+	array := s.consume('[')
+
+	path, err := s.keyPath()
+	if err != nil {
+		return nil, err
+	}
+	s.skipSpace(false)
+	if !s.consume(']') || (array && !s.consume(']')) {
+		return nil, s.errorf("unterminated table header %s", strings.Join(path, "."))
+	}
+	return path, nil
+}
+
+// keyPath scans a dotted key like Org."Core maintainers".
+func (s *tomlScanner) keyPath() ([]string, error) {
+	var path []string
+	for {
+		s.skipSpace(false)
+		key, err := s.key()
+		if err != nil {
+			return nil, err
+		}
+		path = append(path, key)
+
+		s.skipSpace(false)
+		if !s.consume('.') {
+			return path, nil
+		}
+	}
+}
+
+// key scans a bare or quoted key.
+func (s *tomlScanner) key() (string, error) {
+	if !s.eof() && (s.peek() == '"' || s.peek() == '\'') {
+		return s.str()
+	}
+
+	start := s.pos
+	for !s.eof() && isBareKeyChar(s.peek()) {
+		s.next()
+	}
+	if s.pos == start {
+		return "", s.errorf("invalid key")
+	}
+	return string(s.data[start:s.pos]), nil
+}
+
+func isBareKeyChar(c byte) bool {
+	return c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9' || c == '_' || c == '-'
+}
+
+// value scans a value, and appends strings in it to strs.
+func (s *tomlScanner) value(strs *[]string) error {
+	if s.eof() {
+		return s.errorf("missing value")
+	}
+
+	switch s.peek() {
+	case '"', '\'':
+		str, err := s.str()
+		if err != nil {
+			return err
+		}
+		*strs = append(*strs, str)
+		return nil
+	case '[':
+		s.next()
+		for {
+			s.skipArraySpace()
+			if s.consume(']') {
+				return nil
+			}
+			if err := s.value(strs); err != nil {
+				return err
+			}
+			s.skipArraySpace()
+			if s.consume(',') {
+				continue
+			}
+			if !s.consume(']') {
+				return s.errorf("expected , or ] in array")
+			}
+			return nil
+		}
+	case '{':
+		s.next()
+		for {
+			s.skipSpace(false)
+			if s.consume('}') {
+				return nil
+			}
+			if _, err := s.keyPath(); err != nil {
+				return err
+			}
+			s.skipSpace(false)
+			if !s.consume('=') {
+				return s.errorf("expected = in inline table")
+			}
+			s.skipSpace(false)
+			if err := s.value(strs); err != nil {
+				return err
+			}
+			s.skipSpace(false)
+			if s.consume(',') {
+				continue
+			}
+			if !s.consume('}') {
+				return s.errorf("expected , or } in inline table")
+			}
+			return nil
+		}
+	}
+
+	// numbers, booleans and dates
+	start := s.pos
+	for !s.eof() && !strings.ContainsRune(" \t\r\n,]}#", rune(s.peek())) {
+		s.next()
+	}
+	if s.pos == start {
+		return s.errorf("missing value")
+	}
+	return nil
+}
+
+// str scans a basic, literal or multi-line string.
+func (s *tomlScanner) str() (string, error) {
+	quote := s.next()
+	multiline := s.hasPrefix(strings.Repeat(string(quote), 2))
+	if multiline {
+		s.next()
+		s.next()
+		// a newline immediately following the opening delimiter is trimmed.
+		if s.hasPrefix("\r\n") {
+			s.next()
+		}
+		s.consume('\n')
+	}
+
+	var sb strings.Builder
+	for !s.eof() {
+		if multiline && s.hasPrefix(strings.Repeat(string(quote), 3)) {
+			s.pos += 3
+			return sb.String(), nil
+		}
+
+		c := s.next()
+		switch {
+		case !multiline && c == quote:
+			return sb.String(), nil
+		case !multiline && c == '\n':
+			return "", s.errorf("unterminated string")
+		case quote == '"' && c == '\\' && !s.eof():
+			sb.WriteByte(unescape(s.next()))
+		default:
+			sb.WriteByte(c)
+		}
+	}
+	return "", s.errorf("unterminated string")
+}
+
+// unescape returns the byte which escape sequence \c stands for.
+func unescape(c byte) byte {
+	switch c {
+	case 'n':
+		return '\n'
+	case 't':
+		return '\t'
+	case 'r':
+		return '\r'
+	}
+	return c
+}
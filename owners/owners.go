@@ -0,0 +1,250 @@
+// Copyright 2018 The Pouch Robot Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package owners
+
+import (
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pouchcontainer/pouchrobot/gh"
+
+	"github.com/sirupsen/logrus"
+	"gopkg.in/yaml.v2"
+)
+
+// FileName is the name of OWNERS files in directories of repository.
+const FileName = "OWNERS"
+
+// CacheDuration is how long a loaded OWNERS file is cached.
+const CacheDuration = 30 * time.Minute
+
+// File is the content of an OWNERS file.
+type File struct {
+	// Reviewers are GitHub IDs who are suggested to review changes in the directory.
+	Reviewers []string `yaml:"reviewers"`
+
+	// Approvers are GitHub IDs whose approval is required for changes in the directory.
+	Approvers []string `yaml:"approvers"`
+
+	// Options are options of the OWNERS file.
+	Options struct {
+		// NoParentOwners stops inheriting owners from parent directories.
+		NoParentOwners bool `yaml:"no_parent_owners"`
+	} `yaml:"options"`
+}
+
+// Parse parses content of an OWNERS file.
+func Parse(data []byte) (*File, error) {
+	file := &File{}
+	if err := yaml.Unmarshal(data, file); err != nil {
+		return nil, fmt.Errorf("failed to parse OWNERS file: %v", err)
+	}
+	return file, nil
+}
+
+// Area is a directory owning a set of changed files, which is the nearest
+// directory containing an OWNERS file. Root directory is "".
+type Area struct {
+	// Dir is the directory of the OWNERS file.
+	Dir string
+
+	// Files are changed files in the area.
+	Files []string
+
+	// Reviewers are reviewers of the area, including inherited ones.
+	Reviewers []string
+
+	// Approvers are approvers of the area, including inherited ones.
+	Approvers []string
+}
+
+// Name returns the display name of area.
+func (a *Area) Name() string {
+	if a.Dir == "" {
+		return "/"
+	}
+	return a.Dir
+}
+
+// Lookup gets OWNERS file in dir. The second return value is false if dir
+// has no OWNERS file.
+type Lookup func(dir string) (*File, bool)
+
+// Areas groups files by the areas owning them.
+func Areas(files []string, lookup Lookup) []*Area {
+	areas := map[string]*Area{}
+	for _, file := range files {
+		dir := nearestOwnersDir(path.Dir(file), lookup)
+		if dir == nil {
+			continue
+		}
+
+		area, ok := areas[*dir]
+		if !ok {
+			area = &Area{Dir: *dir}
+			area.Reviewers, area.Approvers = inheritedOwners(*dir, lookup)
+			areas[*dir] = area
+		}
+		area.Files = append(area.Files, file)
+	}
+
+	var result []*Area
+	for _, area := range areas {
+		result = append(result, area)
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].Dir < result[j].Dir })
+	return result
+}
+
+// MissingApprovals returns areas which are not approved by any of their
+// approvers. Areas without approvers need no approval.
+func MissingApprovals(areas []*Area, approved []string) []*Area {
+	approvedSet := map[string]bool{}
+	for _, user := range approved {
+		approvedSet[strings.ToLower(user)] = true
+	}
+
+	var missing []*Area
+	for _, area := range areas {
+		if len(area.Approvers) == 0 {
+			continue
+		}
+		ok := false
+		for _, approver := range area.Approvers {
+			if approvedSet[strings.ToLower(approver)] {
+				ok = true
+				break
+			}
+		}
+		if !ok {
+			missing = append(missing, area)
+		}
+	}
+	return missing
+}
+
+// nearestOwnersDir returns the nearest directory of dir containing an OWNERS file.
+func nearestOwnersDir(dir string, lookup Lookup) *string {
+	for {
+		dir = cleanDir(dir)
+		if _, ok := lookup(dir); ok {
+			return &dir
+		}
+		if dir == "" {
+			return nil
+		}
+		dir = path.Dir(dir)
+	}
+}
+
+// inheritedOwners collects owners of dir and its parents until root or an
+// OWNERS file with no_parent_owners.
+func inheritedOwners(dir string, lookup Lookup) ([]string, []string) {
+	var reviewers, approvers []string
+	seenReviewers, seenApprovers := map[string]bool{}, map[string]bool{}
+	for {
+		dir = cleanDir(dir)
+		if file, ok := lookup(dir); ok {
+			reviewers = appendUnique(reviewers, seenReviewers, file.Reviewers)
+			approvers = appendUnique(approvers, seenApprovers, file.Approvers)
+			if file.Options.NoParentOwners {
+				break
+			}
+		}
+		if dir == "" {
+			break
+		}
+		dir = path.Dir(dir)
+	}
+	return reviewers, approvers
+}
+
+func cleanDir(dir string) string {
+	if dir == "." || dir == "/" {
+		return ""
+	}
+	return strings.Trim(dir, "/")
+}
+
+func appendUnique(list []string, seen map[string]bool, users []string) []string {
+	for _, user := range users {
+		user = strings.TrimPrefix(strings.TrimSpace(user), "@")
+		if user == "" || seen[strings.ToLower(user)] {
+			continue
+		}
+		seen[strings.ToLower(user)] = true
+		list = append(list, user)
+	}
+	return list
+}
+
+// Loader loads OWNERS files from the default branch of repository and caches them.
+type Loader struct {
+	sync.Mutex
+
+	client *gh.Client
+	cache  map[string]*cacheEntry
+}
+
+type cacheEntry struct {
+	file     *File
+	loadedAt time.Time
+}
+
+// NewLoader initializes a brand new OWNERS files loader.
+func NewLoader(client *gh.Client) *Loader {
+	return &Loader{
+		client: client,
+		cache:  map[string]*cacheEntry{},
+	}
+}
+
+// Get gets OWNERS file in dir. It is a Lookup.
+// Directories without an OWNERS file or with an invalid one are cached as
+// having none, so that every directory is requested at most once per CacheDuration.
+func (l *Loader) Get(dir string) (*File, bool) {
+	l.Lock()
+	entry, ok := l.cache[dir]
+	l.Unlock()
+	if ok && time.Since(entry.loadedAt) < CacheDuration {
+		return entry.file, entry.file != nil
+	}
+
+	entry = &cacheEntry{loadedAt: time.Now()}
+	data, err := l.client.GetFileContent(path.Join(dir, FileName))
+	switch {
+	case err == nil:
+		if entry.file, err = Parse(data); err != nil {
+			logrus.Warnf("failed to load %s in directory %q: %v", FileName, dir, err)
+		}
+	case !gh.IsNotFound(err):
+		// do not cache a failed request, it may succeed next time.
+		return nil, false
+	}
+
+	l.Lock()
+	l.cache[dir] = entry
+	l.Unlock()
+	return entry.file, entry.file != nil
+}
+
+// Areas groups files by the areas owning them with OWNERS files in repository.
+func (l *Loader) Areas(files []string) []*Area {
+	return Areas(files, l.Get)
+}
@@ -0,0 +1,92 @@
+// Copyright 2018 The Pouch Robot Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package owners
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestAreas(t *testing.T) {
+	files := map[string]*File{
+		"":        {Reviewers: []string{"root-reviewer"}, Approvers: []string{"root-approver"}},
+		"network": {Reviewers: []string{"net-reviewer"}, Approvers: []string{"net-approver", "@root-approver"}},
+		"storage/volume": {
+			Approvers: []string{"volume-approver"},
+			Options: struct {
+				NoParentOwners bool `yaml:"no_parent_owners"`
+			}{NoParentOwners: true},
+		},
+	}
+	lookup := func(dir string) (*File, bool) {
+		file, ok := files[dir]
+		return file, ok
+	}
+
+	areas := Areas([]string{
+		"network/bridge/bridge.go",
+		"network/network.go",
+		"storage/volume/core.go",
+		"README.md",
+		"cli/run.go",
+	}, lookup)
+
+	want := []*Area{
+		{
+			Dir:       "",
+			Files:     []string{"README.md", "cli/run.go"},
+			Reviewers: []string{"root-reviewer"},
+			Approvers: []string{"root-approver"},
+		},
+		{
+			Dir:       "network",
+			Files:     []string{"network/bridge/bridge.go", "network/network.go"},
+			Reviewers: []string{"net-reviewer", "root-reviewer"},
+			Approvers: []string{"net-approver", "root-approver"},
+		},
+		{
+			Dir:       "storage/volume",
+			Files:     []string{"storage/volume/core.go"},
+			Approvers: []string{"volume-approver"},
+		},
+	}
+	if !reflect.DeepEqual(areas, want) {
+		for _, area := range areas {
+			t.Logf("%+v", area)
+		}
+		t.Fatalf("Areas() returns unexpected areas")
+	}
+
+	missing := MissingApprovals(areas, []string{"Root-Approver"})
+	if len(missing) != 1 || missing[0].Dir != "storage/volume" {
+		t.Errorf("MissingApprovals() = %v, want only storage/volume", missing)
+	}
+}
+
+func TestParse(t *testing.T) {
+	file, err := Parse([]byte(`reviewers:
+- foo
+approvers:
+- bar
+options:
+  no_parent_owners: true
+`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(file.Reviewers, []string{"foo"}) || !reflect.DeepEqual(file.Approvers, []string{"bar"}) || !file.Options.NoParentOwners {
+		t.Errorf("Parse() = %+v", file)
+	}
+}
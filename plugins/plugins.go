@@ -0,0 +1,314 @@
+// Copyright 2018 The Pouch Robot Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package plugins
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/pouchcontainer/pouchrobot/config"
+	"github.com/pouchcontainer/pouchrobot/rules"
+	"github.com/pouchcontainer/pouchrobot/utils"
+
+	"github.com/sirupsen/logrus"
+)
+
+// DefaultTimeout is the timeout of each request to a plugin if it is not configured.
+const DefaultTimeout = 10 * time.Second
+
+// commentIDPrefix is the prefix of comment id of comments posted for plugins.
+// Name of plugin and ID of comment are appended to it.
+const commentIDPrefix = "plugin-"
+
+// Client is the part of GitHub client plugins need. It is satisfied by *gh.Client.
+type Client interface {
+	Login() (string, error)
+	AddLabelsToIssue(num int, labels []string) error
+	RemoveLabelForIssue(num int, label string) error
+	UpsertComment(num int, id string, body string) error
+	AssignIssueToUsers(num int, users []string) error
+	CloseIssue(num int) error
+}
+
+// Maintainers decides whether a user is a maintainer. It is satisfied by *maintainers.Maintainers.
+type Maintainers interface {
+	IsMaintainer(user string) bool
+}
+
+// Manager posts events to external plugins, and takes actions they reply
+// with credentials of robot.
+type Manager struct {
+	client      Client
+	maintainers Maintainers
+	plugins     []config.PluginConfig
+
+	// retryInterval is the interval before the first retry, which doubles on each retry.
+	retryInterval time.Duration
+}
+
+// New creates a manager of plugins. It fails if any plugin is invalid.
+func New(client Client, maintainers Maintainers, plugins []config.PluginConfig) (*Manager, error) {
+	if err := Validate(plugins); err != nil {
+		return nil, err
+	}
+	return &Manager{
+		client:        client,
+		maintainers:   maintainers,
+		plugins:       plugins,
+		retryInterval: time.Second,
+	}, nil
+}
+
+// Validate checks that every plugin has a unique name, an absolute HTTP
+// endpoint, events and known permissions.
+func Validate(plugins []config.PluginConfig) error {
+	names := map[string]bool{}
+	for i, plugin := range plugins {
+		if plugin.Name == "" {
+			return fmt.Errorf("plugin %d has no name", i)
+		}
+		if names[plugin.Name] {
+			return fmt.Errorf("plugin %s is declared twice", plugin.Name)
+		}
+		names[plugin.Name] = true
+
+		u, err := url.Parse(plugin.Endpoint)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return fmt.Errorf("plugin %s has invalid endpoint %q", plugin.Name, plugin.Endpoint)
+		}
+		if len(plugin.Events) == 0 {
+			return fmt.Errorf("plugin %s subscribes to no events", plugin.Name)
+		}
+		for _, permission := range plugin.Permissions {
+			switch permission {
+			case ActionAddLabels, ActionRemoveLabels, ActionComment, ActionAssign, ActionClose:
+			default:
+				return fmt.Errorf("plugin %s has unknown permission %q", plugin.Name, permission)
+			}
+		}
+		if plugin.TimeoutSeconds < 0 || plugin.Retries < 0 {
+			return fmt.Errorf("plugin %s has negative timeout or retries", plugin.Name)
+		}
+	}
+	return nil
+}
+
+// HandleEvent posts an event to every plugin subscribing to it in background,
+// so that slow plugins never delay webhook responses to GitHub. Events
+// triggered by robot itself are not posted.
+func (m *Manager) HandleEvent(eventType string, data []byte) error {
+	request, err := m.newRequest(eventType, data)
+	if err != nil || request == nil {
+		return err
+	}
+
+	for _, plugin := range m.plugins {
+		if !subscribes(plugin, eventType) {
+			continue
+		}
+		go func(plugin config.PluginConfig) {
+			if err := m.Dispatch(plugin, request); err != nil {
+				logrus.Errorf("failed to dispatch %s event to plugin %s: %v", eventType, plugin.Name, err)
+			}
+		}(plugin)
+	}
+	return nil
+}
+
+// newRequest builds the request to plugins for an event. It returns nil if
+// event is not about an issue or pull request, or is triggered by robot.
+func (m *Manager) newRequest(eventType string, data []byte) (*Request, error) {
+	event, err := rules.ParseEvent(eventType, data)
+	if err != nil || event == nil || event.Number == 0 {
+		return nil, err
+	}
+	if robot, err := m.client.Login(); err == nil && strings.EqualFold(event.Sender, robot) {
+		return nil, nil
+	}
+
+	metadata := Metadata{
+		Action:            event.Action,
+		Number:            event.Number,
+		IsPullRequest:     event.IsPullRequest,
+		Labels:            event.Labels,
+		Author:            event.Author,
+		AuthorAssociation: event.AuthorAssociation,
+		Sender:            event.Sender,
+	}
+	if m.maintainers != nil {
+		metadata.AuthorIsMaintainer = m.maintainers.IsMaintainer(event.Author)
+		metadata.SenderIsMaintainer = m.maintainers.IsMaintainer(event.Sender)
+	}
+	return &Request{Event: eventType, Payload: json.RawMessage(data), Metadata: metadata}, nil
+}
+
+// Dispatch posts request to plugin and takes actions it replies. Actions out
+// of permissions of plugin are rejected and skipped.
+func (m *Manager) Dispatch(plugin config.PluginConfig, request *Request) error {
+	response, err := m.call(plugin, request)
+	if err != nil {
+		return err
+	}
+
+	num := request.Metadata.Number
+	for _, action := range response.Actions {
+		if !permits(plugin, action.Type) {
+			logrus.Warnf("plugin %s is not permitted to take action %s on %d, reject it", plugin.Name, action.Type, num)
+			continue
+		}
+		if err := m.apply(plugin, request.Metadata, action); err != nil {
+			return fmt.Errorf("failed to take action %s on %d: %v", action.Type, num, err)
+		}
+	}
+	return nil
+}
+
+// call posts request to plugin, and retries on network errors and 5xx statuses.
+func (m *Manager) call(plugin config.PluginConfig, request *Request) (*Response, error) {
+	body, err := json.Marshal(request)
+	if err != nil {
+		return nil, err
+	}
+
+	timeout := DefaultTimeout
+	if plugin.TimeoutSeconds > 0 {
+		timeout = time.Duration(plugin.TimeoutSeconds) * time.Second
+	}
+	client := &http.Client{Timeout: timeout}
+
+	interval := m.retryInterval
+	for attempt := 0; ; attempt++ {
+		response, retry, err := post(client, plugin, request.Event, body)
+		if err == nil {
+			return response, nil
+		}
+		if !retry || attempt >= plugin.Retries {
+			return nil, err
+		}
+		logrus.Warnf("failed to call plugin %s, retry in %v: %v", plugin.Name, interval, err)
+		time.Sleep(interval)
+		interval *= 2
+	}
+}
+
+// post posts body to plugin once. It returns true if the request could be retried on failure.
+func post(client *http.Client, plugin config.PluginConfig, event string, body []byte) (*Response, bool, error) {
+	req, err := http.NewRequest(http.MethodPost, plugin.Endpoint, bytes.NewReader(body))
+	if err != nil {
+		return nil, false, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Pouchrobot-Event", event)
+	if plugin.Secret != "" {
+		req.Header.Set("X-Pouchrobot-Signature", Sign(plugin.Secret, body))
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, true, err
+	}
+	defer resp.Body.Close()
+
+	data, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, true, err
+	}
+	if resp.StatusCode >= 500 {
+		return nil, true, fmt.Errorf("plugin replies status %d: %s", resp.StatusCode, data)
+	}
+	if resp.StatusCode >= 300 {
+		return nil, false, fmt.Errorf("plugin replies status %d: %s", resp.StatusCode, data)
+	}
+
+	response := &Response{}
+	if len(bytes.TrimSpace(data)) == 0 {
+		return response, false, nil
+	}
+	if err := json.Unmarshal(data, response); err != nil {
+		return nil, false, fmt.Errorf("failed to parse reply of plugin: %v", err)
+	}
+	return response, false, nil
+}
+
+// Sign returns signature of body with secret, in format "sha256=<hex digest>".
+func Sign(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// apply takes action on issue or pull request of event for plugin.
+func (m *Manager) apply(plugin config.PluginConfig, metadata Metadata, action Action) error {
+	num := metadata.Number
+	switch action.Type {
+	case ActionAddLabels:
+		if len(action.Labels) == 0 {
+			return nil
+		}
+		return m.client.AddLabelsToIssue(num, action.Labels)
+	case ActionRemoveLabels:
+		for _, label := range action.Labels {
+			// removing a label which is not attached fails.
+			if !utils.SliceContainsElementFold(metadata.Labels, label) {
+				continue
+			}
+			if err := m.client.RemoveLabelForIssue(num, label); err != nil {
+				return err
+			}
+		}
+		return nil
+	case ActionComment:
+		id := commentIDPrefix + plugin.Name
+		if action.ID != "" {
+			id += "-" + action.ID
+		}
+		return m.client.UpsertComment(num, id, action.Body)
+	case ActionAssign:
+		if len(action.Users) == 0 {
+			return nil
+		}
+		return m.client.AssignIssueToUsers(num, action.Users)
+	case ActionClose:
+		return m.client.CloseIssue(num)
+	}
+	return fmt.Errorf("unknown action type %q", action.Type)
+}
+
+func subscribes(plugin config.PluginConfig, eventType string) bool {
+	for _, event := range plugin.Events {
+		if event == "*" || event == eventType {
+			return true
+		}
+	}
+	return false
+}
+
+func permits(plugin config.PluginConfig, actionType string) bool {
+	for _, permission := range plugin.Permissions {
+		if permission == actionType {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,202 @@
+// Copyright 2018 The Pouch Robot Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package plugins
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/pouchcontainer/pouchrobot/config"
+)
+
+// fakeClient records calls robot makes to GitHub.
+type fakeClient struct {
+	calls []string
+}
+
+func (c *fakeClient) Login() (string, error) { return "pouchrobot", nil }
+
+func (c *fakeClient) AddLabelsToIssue(num int, labels []string) error {
+	c.calls = append(c.calls, fmt.Sprintf("add %d %v", num, labels))
+	return nil
+}
+
+func (c *fakeClient) RemoveLabelForIssue(num int, label string) error {
+	c.calls = append(c.calls, fmt.Sprintf("remove %d %s", num, label))
+	return nil
+}
+
+func (c *fakeClient) UpsertComment(num int, id string, body string) error {
+	c.calls = append(c.calls, fmt.Sprintf("comment %d %s %s", num, id, body))
+	return nil
+}
+
+func (c *fakeClient) AssignIssueToUsers(num int, users []string) error {
+	c.calls = append(c.calls, fmt.Sprintf("assign %d %v", num, users))
+	return nil
+}
+
+func (c *fakeClient) CloseIssue(num int) error {
+	c.calls = append(c.calls, fmt.Sprintf("close %d", num))
+	return nil
+}
+
+type fakeMaintainers map[string]bool
+
+func (m fakeMaintainers) IsMaintainer(user string) bool { return m[user] }
+
+const payload = `{
+  "action": "opened",
+  "issue": {"number": 7, "title": "t", "body": "b", "user": {"login": "alice"}, "author_association": "MEMBER", "labels": [{"name": "kind/bug"}]},
+  "sender": {"login": "alice"}
+}`
+
+func TestDispatch(t *testing.T) {
+	var received Request
+	var signature string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+		signature = r.Header.Get("X-Pouchrobot-Signature")
+		if signature != Sign("s3cret", body) {
+			t.Errorf("signature = %s, want %s", signature, Sign("s3cret", body))
+		}
+		json.Unmarshal(body, &received)
+		fmt.Fprint(w, `{"actions": [
+			{"type": "addLabels", "labels": ["triage/accepted"]},
+			{"type": "removeLabels", "labels": ["kind/bug", "kind/feature"]},
+			{"type": "comment", "id": "hello", "body": "hi"},
+			{"type": "close"}
+		]}`)
+	}))
+	defer server.Close()
+
+	plugin := config.PluginConfig{
+		Name:        "triage",
+		Endpoint:    server.URL,
+		Events:      []string{"issues"},
+		Permissions: []string{ActionAddLabels, ActionRemoveLabels, ActionComment},
+		Secret:      "s3cret",
+	}
+	client := &fakeClient{}
+	m, err := New(client, fakeMaintainers{"alice": true}, []config.PluginConfig{plugin})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	request, err := m.newRequest("issues", []byte(payload))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := m.Dispatch(plugin, request); err != nil {
+		t.Fatalf("Dispatch() error = %v", err)
+	}
+
+	wantMetadata := Metadata{
+		Action:             "opened",
+		Number:             7,
+		Labels:             []string{"kind/bug"},
+		Author:             "alice",
+		AuthorAssociation:  "MEMBER",
+		AuthorIsMaintainer: true,
+		Sender:             "alice",
+		SenderIsMaintainer: true,
+	}
+	if !reflect.DeepEqual(received.Metadata, wantMetadata) || received.Event != "issues" {
+		t.Errorf("plugin received %+v, want metadata %+v", received, wantMetadata)
+	}
+
+	// close is not permitted, and kind/feature is not attached.
+	want := []string{
+		"add 7 [triage/accepted]",
+		"remove 7 kind/bug",
+		"comment 7 plugin-triage-hello hi",
+	}
+	if !reflect.DeepEqual(client.calls, want) {
+		t.Errorf("calls = %q, want %q", client.calls, want)
+	}
+}
+
+func TestDispatchRetries(t *testing.T) {
+	tests := []struct {
+		name     string
+		statuses []int
+		retries  int
+		calls    int
+		err      string
+	}{
+		{name: "retry until success", statuses: []int{500, 502, 200}, retries: 2, calls: 3},
+		{name: "retries exhausted", statuses: []int{500, 500, 500}, retries: 1, calls: 2, err: "status 500"},
+		{name: "no retry on client error", statuses: []int{400, 200}, retries: 3, calls: 1, err: "status 400"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			calls := 0
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(tt.statuses[calls])
+				calls++
+			}))
+			defer server.Close()
+
+			plugin := config.PluginConfig{Name: "p", Endpoint: server.URL, Events: []string{"*"}, Retries: tt.retries}
+			m, err := New(&fakeClient{}, nil, []config.PluginConfig{plugin})
+			if err != nil {
+				t.Fatal(err)
+			}
+			m.retryInterval = 0
+
+			request, _ := m.newRequest("issues", []byte(payload))
+			err = m.Dispatch(plugin, request)
+			if calls != tt.calls {
+				t.Errorf("plugin called %d times, want %d", calls, tt.calls)
+			}
+			if tt.err == "" && err != nil {
+				t.Errorf("Dispatch() error = %v, want nil", err)
+			}
+			if tt.err != "" && (err == nil || !strings.Contains(err.Error(), tt.err)) {
+				t.Errorf("Dispatch() error = %v, want %q", err, tt.err)
+			}
+		})
+	}
+}
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name   string
+		plugin config.PluginConfig
+		err    string
+	}{
+		{name: "valid", plugin: config.PluginConfig{Name: "a", Endpoint: "https://example.com/hook", Events: []string{"*"}}},
+		{name: "endpoint", plugin: config.PluginConfig{Name: "a", Endpoint: "example.com", Events: []string{"*"}}, err: "invalid endpoint"},
+		{name: "events", plugin: config.PluginConfig{Name: "a", Endpoint: "https://example.com"}, err: "no events"},
+		{name: "permission", plugin: config.PluginConfig{Name: "a", Endpoint: "https://example.com", Events: []string{"*"}, Permissions: []string{"merge"}}, err: "unknown permission"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := Validate([]config.PluginConfig{tt.plugin})
+			if tt.err == "" && err != nil {
+				t.Errorf("Validate() error = %v, want nil", err)
+			}
+			if tt.err != "" && (err == nil || !strings.Contains(err.Error(), tt.err)) {
+				t.Errorf("Validate() error = %v, want %q", err, tt.err)
+			}
+		})
+	}
+}
@@ -0,0 +1,104 @@
+// Copyright 2018 The Pouch Robot Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package plugins
+
+import (
+	"encoding/json"
+)
+
+// The following are types of actions plugins could ask robot to take.
+const (
+	// ActionAddLabels adds Labels to the issue or pull request.
+	ActionAddLabels = "addLabels"
+
+	// ActionRemoveLabels removes Labels from the issue or pull request.
+	ActionRemoveLabels = "removeLabels"
+
+	// ActionComment posts Body as a comment, or updates the comment of the same ID.
+	ActionComment = "comment"
+
+	// ActionAssign assigns Users to the issue or pull request.
+	ActionAssign = "assign"
+
+	// ActionClose closes the issue or pull request.
+	ActionClose = "close"
+)
+
+// Request is what robot posts to a plugin for an event.
+type Request struct {
+	// Event is the webhook event type.
+	Event string `json:"event"`
+
+	// Payload is the original webhook payload from GitHub.
+	Payload json.RawMessage `json:"payload"`
+
+	// Metadata is what robot has already known about the event.
+	Metadata Metadata `json:"metadata"`
+}
+
+// Metadata is what robot has already known about an event.
+type Metadata struct {
+	// Action is the action type of event.
+	Action string `json:"action"`
+
+	// Number is the number of issue or pull request.
+	Number int `json:"number"`
+
+	// IsPullRequest is true if event is about a pull request.
+	IsPullRequest bool `json:"isPullRequest"`
+
+	// Labels are labels attached to issue or pull request.
+	Labels []string `json:"labels"`
+
+	// Author is the author of issue or pull request, or author of comment for comment events.
+	Author string `json:"author"`
+
+	// AuthorAssociation is the association of Author with repo.
+	AuthorAssociation string `json:"authorAssociation"`
+
+	// AuthorIsMaintainer is true if Author is a maintainer of repo.
+	AuthorIsMaintainer bool `json:"authorIsMaintainer"`
+
+	// Sender is the user who triggers event.
+	Sender string `json:"sender"`
+
+	// SenderIsMaintainer is true if Sender is a maintainer of repo.
+	SenderIsMaintainer bool `json:"senderIsMaintainer"`
+}
+
+// Response is what a plugin replies to robot.
+type Response struct {
+	// Actions are actions for robot to take on the issue or pull request of event.
+	Actions []Action `json:"actions"`
+}
+
+// Action is an action a plugin asks robot to take.
+type Action struct {
+	// Type is the type of action, like ActionAddLabels.
+	Type string `json:"type"`
+
+	// Labels are labels to add or remove.
+	Labels []string `json:"labels,omitempty"`
+
+	// Body is the body of comment.
+	Body string `json:"body,omitempty"`
+
+	// ID distinguishes comments of the same plugin. A comment with the same
+	// ID is updated instead of posting a new one.
+	ID string `json:"id,omitempty"`
+
+	// Users are users to assign to.
+	Users []string `json:"users,omitempty"`
+}
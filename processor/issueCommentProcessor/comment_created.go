@@ -17,6 +17,9 @@ package issueCommentProcessor
 import (
 	"strings"
 
+	"github.com/pouchcontainer/pouchrobot/lifecycle"
+	"github.com/pouchcontainer/pouchrobot/utils"
+
 	"github.com/google/go-github/github"
 )
 
@@ -33,9 +36,23 @@ func (icp *IssueCommentProcessor) ActToIssueCommentCreated(issue *github.Issue,
 
 	users := []string{commentUser}
 
+	// a reply from author is regarded as providing more information.
+	if issue.User != nil && strings.EqualFold(issue.User.GetLogin(), commentUser) && hasLabel(issue, utils.MoreInfoNeededLabel) {
+		lifecycle.ResolveMoreInfo(icp.Client, *(issue.Number))
+	}
+
 	if strings.HasPrefix(strings.ToLower(commentBody), "#dibs") || strings.HasPrefix(strings.ToLower(commentBody), "/assign") {
 		return icp.Client.AssignIssueToUsers(*(issue.Number), users)
 	}
 
 	return nil
 }
+
+func hasLabel(issue *github.Issue, label string) bool {
+	for _, l := range issue.Labels {
+		if l.GetName() == label {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,43 @@
+// Copyright 2018 The Pouch Robot Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package issueProcessor
+
+import (
+	"github.com/pouchcontainer/pouchrobot/utils"
+
+	"github.com/google/go-github/github"
+)
+
+// closedIssueLabels are status labels robot attaches to an open issue, which
+// are meaningless once the issue is closed.
+var closedIssueLabels = []string{
+	utils.MoreInfoNeededLabel,
+	utils.PRInProgressLabel,
+	utils.StaleLabel,
+	utils.RottenLabel,
+}
+
+// ActToIssueClosed acts to the event that an issue is closed.
+func (ip *IssueProcessor) ActToIssueClosed(issue *github.Issue) error {
+	return ip.Client.RemoveExistingLabels(*(issue.Number), closedIssueLabels)
+}
+
+// ActToIssueReopened acts to the event that an issue is reopened. Checks on
+// opened issue are run again, while issue is not translated again.
+func (ip *IssueProcessor) ActToIssueReopened(issue *github.Issue) error {
+	ip.attachLabels(issue)
+	ip.attachComments(issue)
+	return nil
+}
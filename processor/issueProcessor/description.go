@@ -0,0 +1,155 @@
+// Copyright 2018 The Pouch Robot Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package issueProcessor
+
+import (
+	"github.com/pouchcontainer/pouchrobot/repotemplates"
+	"github.com/pouchcontainer/pouchrobot/utils"
+	"github.com/pouchcontainer/pouchrobot/utils/templates"
+
+	"github.com/google/go-github/github"
+)
+
+const (
+	// minTitleLength is the default minimum length of a sufficient issue title.
+	minTitleLength = 20
+
+	// minDescriptionLength is the default minimum length of a sufficient issue
+	// description. It is only checked when repo has no issue templates.
+	minDescriptionLength = 100
+)
+
+// titleMinLength returns minimum length of issue title in effect.
+func (ip *IssueProcessor) titleMinLength() int {
+	if ip.RepoConfig != nil && ip.RepoConfig.Current().TitleMinLength > 0 {
+		return ip.RepoConfig.Current().TitleMinLength
+	}
+	return minTitleLength
+}
+
+// descriptionMinLength returns minimum length of issue description in effect.
+func (ip *IssueProcessor) descriptionMinLength() int {
+	if ip.RepoConfig != nil && ip.RepoConfig.Current().DescriptionMinLength > 0 {
+		return ip.RepoConfig.Current().DescriptionMinLength
+	}
+	return minDescriptionLength
+}
+
+// checkTitle attaches a comment and label status/more-info-needed if title
+// of issue is too short, and returns whether the title is sufficient.
+func (ip *IssueProcessor) checkTitle(issue *github.Issue) (bool, error) {
+	num := *(issue.Number)
+	minLength := ip.titleMinLength()
+	if len(issue.GetTitle()) >= minLength {
+		return true, ip.Client.RemoveMarkedComments(num, utils.IssueTitleTooShortID)
+	}
+
+	body, err := ip.Templates.Render(utils.IssueTitleTooShortID, templates.Data{
+		Author:    issue.User.GetLogin(),
+		Owner:     ip.Owner,
+		Repo:      ip.Repo,
+		MinLength: minLength,
+	})
+	if err != nil {
+		return false, err
+	}
+	if err := ip.Client.UpsertComment(num, utils.IssueTitleTooShortID, body); err != nil {
+		return false, err
+	}
+	return false, ip.addMoreInfoNeededLabel(num)
+}
+
+// checkDescription checks description of issue against issue templates of
+// repo, or its length if repo has no templates. It attaches a comment and
+// label status/more-info-needed if description is insufficient, and returns
+// whether the description is sufficient.
+func (ip *IssueProcessor) checkDescription(issue *github.Issue) (bool, error) {
+	var issueTemplates []*repotemplates.Template
+	if ip.RepoTemplates != nil {
+		issueTemplates = ip.RepoTemplates.IssueTemplates()
+	}
+	if len(issueTemplates) == 0 {
+		return ip.checkDescriptionLength(issue)
+	}
+
+	num := *(issue.Number)
+	tmpl := repotemplates.Best(issueTemplates, issue.GetBody())
+	missing := tmpl.Missing(issue.GetBody())
+	if len(missing) == 0 {
+		return true, ip.Client.RemoveMarkedComments(num, utils.IssueTemplateSectionsID)
+	}
+
+	body, err := ip.Templates.Render(utils.IssueTemplateSectionsID, repotemplates.MissingSections{
+		Author:   issue.User.GetLogin(),
+		Template: tmpl.Name,
+		Missing:  missing,
+	})
+	if err != nil {
+		return false, err
+	}
+	if err := ip.Client.UpsertComment(num, utils.IssueTemplateSectionsID, body); err != nil {
+		return false, err
+	}
+	return false, ip.addMoreInfoNeededLabel(num)
+}
+
+func (ip *IssueProcessor) checkDescriptionLength(issue *github.Issue) (bool, error) {
+	num := *(issue.Number)
+	minLength := ip.descriptionMinLength()
+	if len(issue.GetBody()) >= minLength {
+		return true, ip.Client.RemoveMarkedComments(num, utils.IssueDescriptionTooShortID)
+	}
+
+	body, err := ip.Templates.Render(utils.IssueDescriptionTooShortID, templates.Data{
+		Author:    issue.User.GetLogin(),
+		Owner:     ip.Owner,
+		Repo:      ip.Repo,
+		MinLength: minLength,
+	})
+	if err != nil {
+		return false, err
+	}
+	if err := ip.Client.UpsertComment(num, utils.IssueDescriptionTooShortID, body); err != nil {
+		return false, err
+	}
+	return false, ip.addMoreInfoNeededLabel(num)
+}
+
+// moreInfoRequested returns whether robot has asked for more information
+// with a comment on title or description of issue, which means label
+// status/more-info-needed is attached by robot rather than a maintainer.
+func (ip *IssueProcessor) moreInfoRequested(num int) (bool, error) {
+	for _, id := range []string{
+		utils.IssueTitleTooShortID,
+		utils.IssueDescriptionTooShortID,
+		utils.IssueTemplateSectionsID,
+	} {
+		comments, err := ip.Client.ListMarkedComments(num, id)
+		if err != nil {
+			return false, err
+		}
+		if len(comments) != 0 {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (ip *IssueProcessor) addMoreInfoNeededLabel(num int) error {
+	if ip.Client.IssueHasLabel(num, utils.MoreInfoNeededLabel) {
+		return nil
+	}
+	return ip.Client.AddLabelsToIssue(num, []string{utils.MoreInfoNeededLabel})
+}
@@ -15,8 +15,7 @@
 package issueProcessor
 
 import (
-	"fmt"
-
+	"github.com/pouchcontainer/pouchrobot/lifecycle"
 	"github.com/pouchcontainer/pouchrobot/processor/issueProcessor/open"
 	"github.com/pouchcontainer/pouchrobot/utils"
 
@@ -48,37 +47,29 @@ func (fIP *IssueProcessor) ActToIssueEdited(issue *github.Issue) error {
 		}
 	}
 
-	// attach comment
-	newComment := &github.IssueComment{}
-
-	// check if the title is too short or the body empty.
-	if issue.Title == nil || len(*(issue.Title)) < 20 {
-		body := fmt.Sprintf(utils.IssueTitleTooShort, *(issue.User.Login), fIP.Owner, fIP.Repo)
-		newComment.Body = &body
-		if err := fIP.Client.AddCommentToIssue(*(issue.Number), newComment); err != nil {
-			return err
-		}
-		logrus.Infof("succeed in attaching TITLE TOO SHORT comment for issue %d", *(issue.Number))
-
-		labels := []string{"status/more-info-needed"}
-		fIP.Client.AddLabelsToIssue(*(issue.Number), labels)
+	// label status/more-info-needed attached by a maintainer is left to
+	// the maintainer and author, so only the one robot attached is resolved.
+	requested, err := fIP.moreInfoRequested(*(issue.Number))
+	if err != nil {
+		return err
+	}
 
+	// check whether title and description are sufficient.
+	titleOK, err := fIP.checkTitle(issue)
+	if err != nil {
+		return err
+	}
+	descriptionOK, err := fIP.checkDescription(issue)
+	if err != nil {
+		return err
+	}
+	if !titleOK || !descriptionOK {
+		logrus.Infof("issue %d still needs more information", *(issue.Number))
 		return nil
 	}
-
-	if issue.Body == nil || len(*(issue.Body)) < 50 {
-		body := fmt.Sprintf(utils.IssueDescriptionTooShort, *(issue.User.Login), fIP.Owner, fIP.Repo, fIP.Owner, fIP.Repo)
-		newComment.Body = &body
-		if err := fIP.Client.AddCommentToIssue(*(issue.Number), newComment); err != nil {
-			return err
-		}
-		logrus.Infof("secceed in attaching TITLE TOO SHORT comment for issue %d", *(issue.Number))
-
-		labels := []string{"status/more-info-needed"}
-		fIP.Client.AddLabelsToIssue(*(issue.Number), labels)
-
+	if !requested {
 		return nil
 	}
 
-	return nil
+	return lifecycle.ResolveMoreInfo(fIP.Client, *(issue.Number))
 }
@@ -0,0 +1,91 @@
+// Copyright 2018 The Pouch Robot Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package issueProcessor
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/pouchcontainer/pouchrobot/gh"
+	"github.com/pouchcontainer/pouchrobot/gh/ghtest"
+	"github.com/pouchcontainer/pouchrobot/utils"
+	"github.com/pouchcontainer/pouchrobot/utils/templates"
+
+	"github.com/google/go-github/github"
+)
+
+func TestActToIssueEdited(t *testing.T) {
+	robotComment := &github.IssueComment{
+		ID:   github.Int(100),
+		User: &github.User{Login: github.String(ghtest.Login)},
+		Body: github.String(gh.CommentMarker(utils.IssueDescriptionTooShortID) + "\nplease describe more"),
+	}
+
+	tests := []struct {
+		name     string
+		body     string
+		comments []*github.IssueComment
+		want     []string
+	}{
+		{
+			name:     "robot asked and description is sufficient",
+			body:     strings.Repeat("a", minDescriptionLength),
+			comments: []*github.IssueComment{robotComment},
+			want: []string{
+				"DELETE /repos/pouchcontainer/pouch/issues/comments/100",
+				"DELETE /repos/pouchcontainer/pouch/issues/12/labels/status/more-info-needed",
+			},
+		},
+		{
+			name: "maintainer asked and description is sufficient",
+			body: strings.Repeat("a", minDescriptionLength),
+		},
+		{
+			name:     "robot asked and description is still short",
+			body:     "short",
+			comments: []*github.IssueComment{robotComment},
+			want:     []string{"PATCH /repos/pouchcontainer/pouch/issues/comments/100"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := ghtest.NewServer()
+			defer server.Close()
+			issue := &github.Issue{
+				Number: github.Int(12),
+				Title:  github.String("Support restarting containers on boot"),
+				Body:   github.String(tt.body),
+				User:   &github.User{Login: github.String("contributor")},
+				Labels: []github.Label{{Name: github.String(utils.MoreInfoNeededLabel)}},
+			}
+			server.Issues[12] = issue
+			server.Comments[12] = tt.comments
+			ip := &IssueProcessor{
+				Client:    server.Client("pouchcontainer", "pouch"),
+				Templates: templates.New("", "en"),
+				Owner:     "pouchcontainer",
+				Repo:      "pouch",
+			}
+
+			if err := ip.ActToIssueEdited(issue); err != nil {
+				t.Fatalf("ActToIssueEdited() error = %v", err)
+			}
+			if got := server.Recorded(); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ActToIssueEdited() requests = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
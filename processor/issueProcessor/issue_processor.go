@@ -15,11 +15,12 @@
 package issueProcessor
 
 import (
-	"fmt"
-
 	"github.com/pouchcontainer/pouchrobot/gh"
 	"github.com/pouchcontainer/pouchrobot/processor/issueProcessor/open"
+	"github.com/pouchcontainer/pouchrobot/repoconfig"
+	"github.com/pouchcontainer/pouchrobot/repotemplates"
 	"github.com/pouchcontainer/pouchrobot/utils"
+	"github.com/pouchcontainer/pouchrobot/utils/templates"
 	"github.com/pouchcontainer/pouchrobot/utils/translators"
 
 	"github.com/google/go-github/github"
@@ -51,8 +52,17 @@ func init() {
 type IssueProcessor struct {
 	Client     *gh.Client
 	Translator translators.Translator
+	Templates  *templates.Renderer
 	Owner      string
 	Repo       string
+
+	// RepoTemplates loads issue templates of repo to check issue description
+	// against. Only length of description is checked if it is nil.
+	RepoTemplates *repotemplates.Loader
+
+	// RepoConfig provides settings from per repository config file. Built-in
+	// defaults are used if it is nil.
+	RepoConfig *repoconfig.Loader
 }
 
 // Process processes
@@ -83,9 +93,19 @@ func (ip *IssueProcessor) Process(data []byte) error {
 		if err := ip.ActToIssueLabeled(&issue); err != nil {
 			return nil
 		}
+	case "closed":
+		if err := ip.ActToIssueClosed(&issue); err != nil {
+			return err
+		}
 	case "reopened":
+		if err := ip.ActToIssueReopened(&issue); err != nil {
+			return err
+		}
+	case "assigned", "unassigned", "unlabeled", "deleted", "transferred",
+		"pinned", "unpinned", "locked", "unlocked", "milestoned", "demilestoned":
+		// nothing to do with these actions.
 	default:
-		return fmt.Errorf("unknown action type %s in issue: ", actionType)
+		logrus.Warnf("unknown action type %s in issue, ignore it", actionType)
 	}
 
 	return nil
@@ -15,9 +15,8 @@
 package issueProcessor
 
 import (
-	"fmt"
-
 	"github.com/pouchcontainer/pouchrobot/utils"
+	"github.com/pouchcontainer/pouchrobot/utils/templates"
 
 	"github.com/google/go-github/github"
 )
@@ -30,21 +29,20 @@ func (ip *IssueProcessor) ActToIssueLabeled(issue *github.Issue) error {
 
 func (ip *IssueProcessor) actToPriority(issue *github.Issue) error {
 	if !ip.Client.IssueHasLabel(*(issue.Number), utils.PriorityP1Label) {
-		id, exist := ip.Client.IssueHasComment(*(issue.Number), utils.IssueNeedP1CommentSubStr)
-		if !exist {
-			return nil
-		}
-		return ip.Client.RemoveComment(id)
+		return ip.Client.RemoveMarkedComments(*(issue.Number), utils.IssueNeedP1CommentID)
 	}
 
-	if _, exist := ip.Client.IssueHasComment(*(issue.Number), utils.IssueNeedP1CommentSubStr); exist {
+	if _, exist := ip.Client.HasMarkedComment(*(issue.Number), utils.IssueNeedP1CommentID); exist {
 		return nil
 	}
 
-	body := fmt.Sprintf(utils.IssueNeedP1Comment, *(issue.User.Login), ip.Owner, ip.Repo)
-	newComment := &github.IssueComment{
-		Body: &body,
+	body, err := ip.Templates.Render(utils.IssueNeedP1CommentID, templates.Data{
+		Author: *(issue.User.Login),
+		Owner:  ip.Owner,
+		Repo:   ip.Repo,
+	})
+	if err != nil {
+		return err
 	}
-
-	return ip.Client.AddCommentToIssue(*(issue.Number), newComment)
+	return ip.Client.UpsertComment(*(issue.Number), utils.IssueNeedP1CommentID, body)
 }
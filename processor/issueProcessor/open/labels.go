@@ -15,9 +15,8 @@
 package open
 
 import (
-	"strings"
-
 	"github.com/pouchcontainer/pouchrobot/utils"
+	"github.com/pouchcontainer/pouchrobot/utils/matcher"
 
 	"github.com/google/go-github/github"
 	"github.com/sirupsen/logrus"
@@ -39,18 +38,7 @@ func ParseTitleToGenerateLabels(issue *github.Issue) []string {
 		logrus.Errorf("issue %d has no title when ParseTitleToGenerateLabels", *(issue.Number))
 		return nil
 	}
-	var labels []string
-	title := issue.Title
-	for label, matchedSlice := range utils.TitleMatches {
-		for _, pattern := range matchedSlice {
-			lowerCaseTitle := strings.ToLower(*title)
-			if strings.Contains(lowerCaseTitle, pattern) {
-				labels = append(labels, label)
-				break
-			}
-		}
-	}
-	return labels
+	return matcher.Current().Match(matcher.ScopeTitle, *(issue.Title))
 }
 
 // ParseBodyToGenerateLabels parses issue title to generate a slice.
@@ -59,16 +47,5 @@ func ParseBodyToGenerateLabels(issue *github.Issue) []string {
 		logrus.Errorf("issue %d has no body when ParseBodyToGenerateLabels", *(issue.Number))
 		return nil
 	}
-	var labels []string
-	content := issue.Body
-	for label, matchedSlice := range utils.BodyMatches {
-		for _, pattern := range matchedSlice {
-			lowerCaseBody := strings.ToLower(*content)
-			if strings.Contains(lowerCaseBody, pattern) {
-				labels = append(labels, label)
-				break
-			}
-		}
-	}
-	return labels
+	return matcher.Current().Match(matcher.ScopeBody, *(issue.Body))
 }
@@ -15,10 +15,7 @@
 package issueProcessor
 
 import (
-	"fmt"
-
 	"github.com/pouchcontainer/pouchrobot/processor/issueProcessor/open"
-	"github.com/pouchcontainer/pouchrobot/utils"
 
 	"github.com/google/go-github/github"
 )
@@ -63,50 +60,8 @@ func (ip *IssueProcessor) attachLabels(issue *github.Issue) error {
 }
 
 func (ip *IssueProcessor) attachComments(issue *github.Issue) error {
-	ip.attachTitleComments(issue)
-	ip.attachBodyComments(issue)
+	ip.checkTitle(issue)
+	ip.checkDescription(issue)
 
 	return nil
 }
-
-func (ip *IssueProcessor) attachTitleComments(issue *github.Issue) error {
-	// check if the title is too short or the body empty.
-	if issue.Title != nil && len(*(issue.Title)) > 20 {
-		return nil
-	}
-
-	// attach comment
-	body := fmt.Sprintf(utils.IssueTitleTooShort, *(issue.User.Login), ip.Owner, ip.Repo)
-	newComment := &github.IssueComment{
-		Body: &body,
-	}
-
-	if err := ip.Client.AddCommentToIssue(*(issue.Number), newComment); err != nil {
-		return err
-	}
-
-	labels := []string{"status/more-info-needed"}
-	return ip.Client.AddLabelsToIssue(*(issue.Number), labels)
-}
-
-func (ip *IssueProcessor) attachBodyComments(issue *github.Issue) error {
-	if issue.Body != nil && len(*(issue.Body)) > 100 {
-		return nil
-	}
-
-	// attach comment
-	body := fmt.Sprintf(utils.IssueDescriptionTooShort, *(issue.User.Login), ip.Owner, ip.Repo, ip.Owner, ip.Repo)
-	newComment := &github.IssueComment{
-		Body: &body,
-	}
-	if err := ip.Client.AddCommentToIssue(*(issue.Number), newComment); err != nil {
-		return err
-	}
-
-	if ip.Client.IssueHasLabel(*(issue.Number), "status/more-info-needed") {
-		return nil
-	}
-
-	labels := []string{"status/more-info-needed"}
-	return ip.Client.AddLabelsToIssue(*(issue.Number), labels)
-}
@@ -44,15 +44,15 @@ func (prcp *PRCommentProcessor) updateLabels(issue *github.Issue, comment *githu
 		return nil
 	}
 
-	if !isMaintainer(user) {
+	if !prcp.Maintainers.IsMaintainer(user) {
 		return nil
 	}
 
-	if prcp.Client.IssueHasLabel(*(issue.Number), "LGTM") {
+	if prcp.Client.IssueHasLabel(*(issue.Number), utils.LGTMLabel) {
 		return nil
 	}
 
-	return prcp.Client.AddLabelsToIssue(*(issue.Number), []string{"LGTM"})
+	return prcp.Client.AddLabelsToIssue(*(issue.Number), []string{utils.LGTMLabel})
 }
 
 func (prcp *PRCommentProcessor) retriggerCI(issue *github.Issue, comment *github.IssueComment) error {
@@ -69,18 +69,9 @@ func (prcp *PRCommentProcessor) retriggerCI(issue *github.Issue, comment *github
 	return nil
 }
 
-func isMaintainer(user string) bool {
-	for _, maintainerID := range utils.Maintainers {
-		if strings.ToLower(user) == strings.ToLower(maintainerID) {
-			return true
-		}
-	}
-	return false
-}
-
 /*func hasLGTMInLabels(issue *github.Issue) bool {
 	for _, label := range issue.Labels {
-		if label.GetName() == "LGTM" {
+		if label.GetName() == utils.LGTMLabel {
 			return true
 		}
 	}
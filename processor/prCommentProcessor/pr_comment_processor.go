@@ -16,12 +16,14 @@ package prCommentProcessor
 
 import (
 	"github.com/pouchcontainer/pouchrobot/gh"
+	"github.com/pouchcontainer/pouchrobot/maintainers"
 	"github.com/pouchcontainer/pouchrobot/utils"
 )
 
 // PRCommentProcessor is
 type PRCommentProcessor struct {
-	Client *gh.Client
+	Client      *gh.Client
+	Maintainers *maintainers.Maintainers
 }
 
 // Process processes pull request events
@@ -15,14 +15,19 @@
 package processor
 
 import (
-	"fmt"
-
 	"github.com/pouchcontainer/pouchrobot/gh"
+	"github.com/pouchcontainer/pouchrobot/lifecycle"
+	"github.com/pouchcontainer/pouchrobot/maintainers"
+	"github.com/pouchcontainer/pouchrobot/plugins"
 	"github.com/pouchcontainer/pouchrobot/processor/issueCommentProcessor"
 	"github.com/pouchcontainer/pouchrobot/processor/issueProcessor"
 	"github.com/pouchcontainer/pouchrobot/processor/prCommentProcessor"
 	"github.com/pouchcontainer/pouchrobot/processor/pullRequestProcessor"
+	"github.com/pouchcontainer/pouchrobot/repoconfig"
+	"github.com/pouchcontainer/pouchrobot/reporter"
+	"github.com/pouchcontainer/pouchrobot/rules"
 	"github.com/pouchcontainer/pouchrobot/utils"
+	"github.com/pouchcontainer/pouchrobot/utils/templates"
 	"github.com/pouchcontainer/pouchrobot/utils/translators"
 
 	"github.com/sirupsen/logrus"
@@ -34,38 +39,93 @@ type Processor struct {
 	PullRequestProcessor  *pullRequestProcessor.PullRequestProcessor
 	IssueCommentProcessor *issueCommentProcessor.IssueCommentProcessor
 	PRCommentProcessor    *prCommentProcessor.PRCommentProcessor
+
+	// Reporter counts review comments in weekly report. Review comments are
+	// not recorded if it is nil.
+	Reporter *reporter.Reporter
+
+	// Rules takes actions of declarative rules on matching events. It is nil
+	// if no rules file is configured.
+	Rules *rules.Engine
+
+	// Plugins posts events to external plugins. It is nil if no plugins are configured.
+	Plugins *plugins.Manager
+
+	// RepoConfig refreshes per repository config file on pushes. It is nil
+	// if per repository config file is not loaded.
+	RepoConfig *repoconfig.Loader
+
+	// Lifecycle reactivates stale issues and pull requests on human activity.
+	// It is nil if lifecycle management is disabled.
+	Lifecycle *lifecycle.Manager
+
+	// client lists files changed in pull requests for every event.
+	client *gh.Client
 }
 
 // New initializes a brand new processor.
-func New(client *gh.Client, translator translators.Translator, owner string, repo string) *Processor {
+func New(client *gh.Client, translator translators.Translator, renderer *templates.Renderer, maintainers *maintainers.Maintainers, owner string, repo string) *Processor {
 	return &Processor{
+		client: client,
 		IssueProcessor: &issueProcessor.IssueProcessor{
 			Client:     client,
 			Translator: translator,
+			Templates:  renderer,
 			Owner:      owner,
 			Repo:       repo,
 		},
 		PullRequestProcessor: &pullRequestProcessor.PullRequestProcessor{
-			Client: client,
-			Owner:  owner,
-			Repo:   repo,
+			Client:    client,
+			Templates: renderer,
+			Owner:     owner,
+			Repo:      repo,
+
+			Maintainers: maintainers,
 		},
 		IssueCommentProcessor: &issueCommentProcessor.IssueCommentProcessor{
 			Client: client,
 		},
 		PRCommentProcessor: &prCommentProcessor.PRCommentProcessor{
-			Client: client,
+			Client:      client,
+			Maintainers: maintainers,
 		},
 	}
 }
 
 // HandleEvent processes an event received from github
 func (p *Processor) HandleEvent(eventType string, data []byte) error {
+	// files changed in pull request are listed at most once per event.
+	files := gh.NewFilesCache(p.client)
+
+	if p.Lifecycle != nil {
+		if err := p.Lifecycle.HandleEvent(eventType, data); err != nil {
+			logrus.Errorf("failed to handle lifecycle of %s event: %v", eventType, err)
+		}
+	}
+
+	if p.RepoConfig != nil {
+		if err := p.RepoConfig.HandleEvent(eventType, data, files); err != nil {
+			logrus.Errorf("failed to handle per repository config file on %s event: %v", eventType, err)
+		}
+	}
+
+	if p.Rules != nil {
+		if err := p.Rules.HandleEvent(eventType, data, files); err != nil {
+			logrus.Errorf("failed to apply rules to %s event: %v", eventType, err)
+		}
+	}
+
+	if p.Plugins != nil {
+		if err := p.Plugins.HandleEvent(eventType, data); err != nil {
+			logrus.Errorf("failed to post %s event to plugins: %v", eventType, err)
+		}
+	}
+
 	switch eventType {
 	case "issues":
 		p.IssueProcessor.Process(data)
 	case "pull_request":
-		p.PullRequestProcessor.Process(data)
+		p.PullRequestProcessor.Process(data, files)
 	case "issue_comment":
 		// since pr is also a kind of issue, we need to first make it clear
 		issueType := judgeIssueOrPR(data)
@@ -78,11 +138,41 @@ func (p *Processor) HandleEvent(eventType string, data []byte) error {
 			p.PRCommentProcessor.Process(data)
 			return nil
 		}
+	case "pull_request_review":
+		p.PullRequestProcessor.ProcessReview(data, files)
+	case "pull_request_review_comment":
+		p.processReviewComment(data)
+	case "push":
+		// pushes are only handled by RepoConfig.
 	case "ping":
 		logrus.Debug("Got ping from GitHub")
 	default:
-		return fmt.Errorf("unknown event type %s", eventType)
+		// GitHub sends events a webhook subscribes to, answering them with
+		// errors only makes GitHub show failed deliveries.
+		logrus.Infof("ignore unsupported event type %s", eventType)
+	}
+	return nil
+}
+
+// processReviewComment records a newly created review comment for weekly report.
+func (p *Processor) processReviewComment(data []byte) error {
+	if p.Reporter == nil {
+		return nil
+	}
+	actionType, err := utils.ExtractActionType(data)
+	if err != nil || actionType != "created" {
+		return err
+	}
+
+	pr, err := utils.ExactPR(data)
+	if err != nil {
+		return err
+	}
+	comment, err := utils.ExactReviewComment(data)
+	if err != nil {
+		return err
 	}
+	p.Reporter.RecordReviewComment(&comment, pr.User.GetLogin())
 	return nil
 }
 
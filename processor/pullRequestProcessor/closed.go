@@ -0,0 +1,100 @@
+// Copyright 2018 The Pouch Robot Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pullRequestProcessor
+
+import (
+	"strings"
+
+	"github.com/pouchcontainer/pouchrobot/gh"
+	"github.com/pouchcontainer/pouchrobot/utils"
+	"github.com/pouchcontainer/pouchrobot/utils/templates"
+
+	"github.com/google/go-github/github"
+	"github.com/sirupsen/logrus"
+)
+
+// closedPRLabels are status labels robot attaches to an open pull request,
+// which are meaningless once the pull request is closed.
+var closedPRLabels = []string{
+	utils.PRConflictLabel,
+	utils.PRGapLabel,
+	utils.CIFailureLable,
+	utils.ChangesRequestedLabel,
+	utils.StaleLabel,
+	utils.RottenLabel,
+}
+
+// PostMergeHook is called after a pull request is merged.
+type PostMergeHook func(pr *github.PullRequest) error
+
+// postMergeHook is a registered PostMergeHook with its name for logging.
+type postMergeHook struct {
+	name string
+	hook PostMergeHook
+}
+
+// RegisterPostMergeHook registers hook to be called after a pull request is
+// merged. Hooks are called in order of registration, and a failed hook does
+// not stop the following ones.
+func (prp *PullRequestProcessor) RegisterPostMergeHook(name string, hook PostMergeHook) {
+	prp.postMergeHooks = append(prp.postMergeHooks, postMergeHook{name: name, hook: hook})
+}
+
+// ActToPRClosed acts to the event that a pull request is closed or merged.
+func (prp *PullRequestProcessor) ActToPRClosed(pr *github.PullRequest) error {
+	prp.Client.RemoveExistingLabels(*(pr.Number), closedPRLabels)
+	prp.resolveIssues(pr)
+
+	if !pr.GetMerged() {
+		return nil
+	}
+	for _, h := range prp.postMergeHooks {
+		if err := h.hook(pr); err != nil {
+			logrus.Errorf("failed to run post merge hook %s on pull request %d: %v", h.name, *(pr.Number), err)
+		}
+	}
+	return nil
+}
+
+// ActToPRReopened acts to the event that a pull request is reopened. Checks
+// on opened pull request are run again, while reviewers are not assigned again.
+func (prp *PullRequestProcessor) ActToPRReopened(pr *github.PullRequest, files gh.FileLister) error {
+	prp.attachLabels(pr, files)
+	prp.attachComments(pr)
+	prp.linkIssues(pr, nil)
+	prp.CheckApprovals(pr, files, false)
+	return nil
+}
+
+// ThankContributor is a PostMergeHook which thanks author of merged pull request.
+func (prp *PullRequestProcessor) ThankContributor(pr *github.PullRequest) error {
+	author := pr.User.GetLogin()
+	if author == "" || pr.User.GetType() == "Bot" {
+		return nil
+	}
+	if robot, err := prp.Client.Login(); err == nil && strings.EqualFold(author, robot) {
+		return nil
+	}
+
+	body, err := prp.Templates.Render(utils.PRMergedThanksID, templates.Data{
+		Author: author,
+		Owner:  prp.Owner,
+		Repo:   prp.Repo,
+	})
+	if err != nil {
+		return err
+	}
+	return prp.Client.UpsertComment(*(pr.Number), utils.PRMergedThanksID, body)
+}
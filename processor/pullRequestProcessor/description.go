@@ -0,0 +1,113 @@
+// Copyright 2018 The Pouch Robot Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pullRequestProcessor
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/pouchcontainer/pouchrobot/repotemplates"
+	"github.com/pouchcontainer/pouchrobot/utils"
+	"github.com/pouchcontainer/pouchrobot/utils/templates"
+
+	"github.com/google/go-github/github"
+)
+
+// TemplateStatusContext is the context of commit status which reports
+// whether pull request description follows pull request template.
+const TemplateStatusContext = "pouchrobot/pr-template"
+
+const (
+	// minTitleLength is the default minimum length of a sufficient pull request title.
+	minTitleLength = 20
+
+	// minDescriptionLength is the default minimum length of a sufficient pull
+	// request description. It is only checked when repo has no pull request template.
+	minDescriptionLength = 100
+)
+
+// titleMinLength returns minimum length of pull request title in effect.
+func (prp *PullRequestProcessor) titleMinLength() int {
+	if prp.RepoConfig != nil && prp.RepoConfig.Current().TitleMinLength > 0 {
+		return prp.RepoConfig.Current().TitleMinLength
+	}
+	return minTitleLength
+}
+
+// descriptionMinLength returns minimum length of pull request description in effect.
+func (prp *PullRequestProcessor) descriptionMinLength() int {
+	if prp.RepoConfig != nil && prp.RepoConfig.Current().DescriptionMinLength > 0 {
+		return prp.RepoConfig.Current().DescriptionMinLength
+	}
+	return minDescriptionLength
+}
+
+// checkDescription checks description of pull request against pull request
+// template of repo, or its length if repo has no template.
+func (prp *PullRequestProcessor) checkDescription(pr *github.PullRequest) error {
+	if prp.RepoTemplates != nil {
+		if tmpl := prp.RepoTemplates.PullRequestTemplate(); tmpl != nil {
+			return prp.checkTemplateSections(pr, tmpl)
+		}
+	}
+	return prp.checkDescriptionLength(pr)
+}
+
+func (prp *PullRequestProcessor) checkTemplateSections(pr *github.PullRequest, tmpl *repotemplates.Template) error {
+	num := *(pr.Number)
+	sha := pr.Head.GetSHA()
+
+	missing := tmpl.Missing(pr.GetBody())
+	if len(missing) == 0 {
+		if err := prp.Client.RemoveMarkedComments(num, utils.PRTemplateSectionsID); err != nil {
+			return err
+		}
+		return prp.Client.CreateStatus(sha, "success", TemplateStatusContext, "description follows pull request template")
+	}
+
+	body, err := prp.Templates.Render(utils.PRTemplateSectionsID, repotemplates.MissingSections{
+		Author:   pr.User.GetLogin(),
+		Template: tmpl.Name,
+		Missing:  missing,
+	})
+	if err != nil {
+		return err
+	}
+	if err := prp.Client.UpsertComment(num, utils.PRTemplateSectionsID, body); err != nil {
+		return err
+	}
+
+	description := fmt.Sprintf("missing sections: %s", strings.Join(missing, "; "))
+	return prp.Client.CreateStatus(sha, "failure", TemplateStatusContext, description)
+}
+
+func (prp *PullRequestProcessor) checkDescriptionLength(pr *github.PullRequest) error {
+	num := *(pr.Number)
+	minLength := prp.descriptionMinLength()
+	if len(pr.GetBody()) >= minLength {
+		return prp.Client.RemoveMarkedComments(num, utils.PRDescriptionTooShortID)
+	}
+
+	body, err := prp.Templates.Render(utils.PRDescriptionTooShortID, templates.Data{
+		Author:    pr.User.GetLogin(),
+		Owner:     prp.Owner,
+		Repo:      prp.Repo,
+		MinLength: minLength,
+	})
+	if err != nil {
+		return err
+	}
+	return prp.Client.UpsertComment(num, utils.PRDescriptionTooShortID, body)
+}
@@ -0,0 +1,125 @@
+// Copyright 2018 The Pouch Robot Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pullRequestProcessor
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+	"unicode/utf8"
+
+	"github.com/pouchcontainer/pouchrobot/gh/ghtest"
+	"github.com/pouchcontainer/pouchrobot/repotemplates"
+	"github.com/pouchcontainer/pouchrobot/utils/templates"
+
+	"github.com/google/go-github/github"
+)
+
+const (
+	commentPath = "/repos/pouchcontainer/pouch/issues/12/comments"
+	statusPath  = "/repos/pouchcontainer/pouch/statuses/0123abc"
+)
+
+func TestCheckDescription(t *testing.T) {
+	longTitle := strings.Repeat("Describe what this pull request changes in detail ", 2)
+
+	tests := []struct {
+		name     string
+		template string
+		body     string
+		want     []string
+		state    string
+		status   string
+	}{
+		{
+			name:     "missing sections",
+			template: "### What\n\n### Why\n\n### Special notes for reviews\n",
+			body:     "### What\nsupport label sync",
+			want:     []string{"POST " + commentPath, "POST " + statusPath},
+			state:    "failure",
+			status:   "missing sections: Why",
+		},
+		{
+			name:     "follows template",
+			template: "### What\n\n### Why\n",
+			body:     "### What\nsupport label sync\n### Why\nlabels drift",
+			want:     []string{"POST " + statusPath},
+			state:    "success",
+			status:   "description follows pull request template",
+		},
+		{
+			name:     "truncated status",
+			template: "### 1. " + longTitle + "\n\n### 2. " + longTitle + "\n",
+			want:     []string{"POST " + commentPath, "POST " + statusPath},
+			state:    "failure",
+			status:   string([]rune("missing sections: 1. " + strings.TrimSpace(longTitle) + "; 2. " + strings.TrimSpace(longTitle))[:137]) + "...",
+		},
+		{
+			name: "no template and short",
+			body: "fix typo",
+			want: []string{"POST " + commentPath},
+		},
+		{
+			name: "no template and long enough",
+			body: strings.Repeat("a", minDescriptionLength),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := ghtest.NewServer()
+			defer server.Close()
+			if tt.template != "" {
+				server.Files[".github/PULL_REQUEST_TEMPLATE.md"] = tt.template
+			}
+			client := server.Client("pouchcontainer", "pouch")
+			prp := &PullRequestProcessor{
+				Client:        client,
+				Templates:     templates.New("", "en"),
+				Owner:         "pouchcontainer",
+				Repo:          "pouch",
+				RepoTemplates: repotemplates.NewLoader(client, nil),
+			}
+			pr := &github.PullRequest{
+				Number: github.Int(12),
+				Body:   github.String(tt.body),
+				User:   &github.User{Login: github.String("contributor")},
+				Head:   &github.PullRequestBranch{SHA: github.String("0123abc")},
+			}
+
+			if err := prp.checkDescription(pr); err != nil {
+				t.Fatalf("checkDescription() error = %v", err)
+			}
+			if got := server.Recorded(); !reflect.DeepEqual(got, tt.want) {
+				t.Fatalf("checkDescription() requests = %q, want %q", got, tt.want)
+			}
+			if tt.state == "" {
+				return
+			}
+
+			var status github.RepoStatus
+			if err := json.Unmarshal(server.Requests[len(server.Requests)-1].Body, &status); err != nil {
+				t.Fatal(err)
+			}
+			if status.GetState() != tt.state || status.GetContext() != TemplateStatusContext || status.GetDescription() != tt.status {
+				t.Errorf("status = %s %s %q, want %s %s %q", status.GetState(), status.GetContext(), status.GetDescription(),
+					tt.state, TemplateStatusContext, tt.status)
+			}
+			if n := utf8.RuneCountInString(status.GetDescription()); n > 140 {
+				t.Errorf("status description has %d characters, want at most 140", n)
+			}
+		})
+	}
+}
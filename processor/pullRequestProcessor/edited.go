@@ -15,28 +15,30 @@
 package pullRequestProcessor
 
 import (
-	"fmt"
-
-	"github.com/pouchcontainer/pouchrobot/processor/pullRequestProcessor/open"
+	"github.com/pouchcontainer/pouchrobot/gh"
 	"github.com/pouchcontainer/pouchrobot/utils"
+	"github.com/pouchcontainer/pouchrobot/utils/templates"
 
 	"github.com/google/go-github/github"
 )
 
 // ActToPREdited acts to the event which represents pull request edition.
-func (prp *PullRequestProcessor) ActToPREdited(pr *github.PullRequest) error {
+// oldBody is the description before edition, and nil if it is not changed.
+func (prp *PullRequestProcessor) ActToPREdited(pr *github.PullRequest, files gh.FileLister, oldBody *string) error {
 	// update labels
-	prp.updateLabels(pr)
+	prp.updateLabels(pr, files)
 	// update comment
 	prp.updateComments(pr)
+	// description may reference other issues now.
+	prp.linkIssues(pr, oldBody)
 
 	return nil
 }
 
-func (prp *PullRequestProcessor) updateLabels(pr *github.PullRequest) error {
-	newLabels := open.ParseToGeneratePRLabels(pr)
+func (prp *PullRequestProcessor) updateLabels(pr *github.PullRequest, files gh.FileLister) error {
+	newLabels, genErr := prp.generateLabels(pr, files)
 	if len(newLabels) == 0 {
-		return nil
+		return genErr
 	}
 
 	// get a string slice of labels attached to the current pull request.
@@ -48,66 +50,39 @@ func (prp *PullRequestProcessor) updateLabels(pr *github.PullRequest) error {
 	deltaLabels := utils.DeltaSlice(strLabels, newLabels)
 
 	if len(deltaLabels) == 0 {
-		return nil
+		return genErr
 	}
 
 	// add delta labels to pull request
-	return prp.Client.AddLabelsToIssue(*(pr.Number), deltaLabels)
+	if err := prp.Client.AddLabelsToIssue(*(pr.Number), deltaLabels); err != nil {
+		return err
+	}
+	return genErr
 }
 
 func (prp *PullRequestProcessor) updateComments(pr *github.PullRequest) error {
 	prp.updateTitleComment(pr)
-	prp.updateBodyComment(pr)
+	prp.checkDescription(pr)
 
 	return nil
 }
 
 func (prp *PullRequestProcessor) updateTitleComment(pr *github.PullRequest) error {
 	// check if the title is too short or the body empty.
-	if pr.Title == nil || len(*(pr.Title)) < 20 {
-		if _, exist := prp.Client.IssueHasComment(*(pr.Number), utils.IssueTitleTooShortSubStr); exist {
-			// do nothing
-			return nil
-		}
-
-		body := fmt.Sprintf(utils.PRTitleTooShort, *(pr.User.Login))
-		newComment := &github.IssueComment{
-			Body: &body,
+	minLength := prp.titleMinLength()
+	if len(pr.GetTitle()) < minLength {
+		body, err := prp.Templates.Render(utils.PRTitleTooShortID, templates.Data{
+			Author:    *(pr.User.Login),
+			Owner:     prp.Owner,
+			Repo:      prp.Repo,
+			MinLength: minLength,
+		})
+		if err != nil {
+			return err
 		}
-		return prp.Client.AddCommentToPR(*(pr.Number), newComment)
+		return prp.Client.UpsertComment(*(pr.Number), utils.PRTitleTooShortID, body)
 	}
 
 	// PR title meets the length
-	id, exist := prp.Client.IssueHasComment(*(pr.Number), utils.PRTitleTooShortSubStr)
-	if !exist {
-		// do nothing
-		return nil
-	}
-
-	return prp.Client.RemoveComment(id)
-}
-
-func (prp *PullRequestProcessor) updateBodyComment(pr *github.PullRequest) error {
-	// check if the pull request decription is too short or the body empty.
-	if pr.Body == nil || len(*(pr.Body)) < 100 {
-		if _, exist := prp.Client.IssueHasComment(*(pr.Number), utils.PRDescriptionTooShortSubStr); exist {
-			// do nothing
-			return nil
-		}
-
-		body := fmt.Sprintf(utils.PRDescriptionTooShort, *(pr.User.Login), prp.Owner, prp.Repo)
-		newComment := &github.IssueComment{
-			Body: &body,
-		}
-		return prp.Client.AddCommentToPR(*(pr.Number), newComment)
-	}
-
-	// PR title meets the length
-	id, exist := prp.Client.IssueHasComment(*(pr.Number), utils.PRDescriptionTooShortSubStr)
-	if !exist {
-		// do nothing
-		return nil
-	}
-
-	return prp.Client.RemoveComment(id)
+	return prp.Client.RemoveMarkedComments(*(pr.Number), utils.PRTitleTooShortID)
 }
@@ -20,5 +20,15 @@ import (
 
 // ActToPRLabeled acts the event of pull request labeled.
 func (prp *PullRequestProcessor) ActToPRLabeled(pr *github.PullRequest) error {
-	return nil
+	if !prp.IssueLinks.Enabled {
+		return nil
+	}
+	// a bug fix should reference the issue it fixes.
+	return prp.checkBugReference(pr, prp.referencedIssues(pr))
+}
+
+// ActToPRUnlabeled acts the event of pull request unlabeled.
+func (prp *PullRequestProcessor) ActToPRUnlabeled(pr *github.PullRequest) error {
+	// warning of a bug fix without issue is outdated once kind/bug is removed.
+	return prp.ActToPRLabeled(pr)
 }
@@ -0,0 +1,236 @@
+// Copyright 2018 The Pouch Robot Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pullRequestProcessor
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/pouchcontainer/pouchrobot/gh"
+	"github.com/pouchcontainer/pouchrobot/utils"
+	"github.com/pouchcontainer/pouchrobot/utils/refs"
+	"github.com/pouchcontainer/pouchrobot/utils/templates"
+
+	"github.com/google/go-github/github"
+	"github.com/sirupsen/logrus"
+)
+
+// linkedMarkerRegex matches markers of comments about pull requests fixing an issue,
+// and captures number of pull request.
+var linkedMarkerRegex = regexp.MustCompile(strings.Replace(
+	regexp.QuoteMeta(gh.CommentMarker(utils.LinkedPRCommentID+"-NUMBER")), "NUMBER", `(\d+)`, 1))
+
+// LinkedPR is the data to render comment in an issue about a pull request fixing it.
+type LinkedPR struct {
+	// Author is the GitHub login of pull request author.
+	Author string
+
+	// Number is the number of pull request.
+	Number int
+
+	// Merged is true if pull request has been merged.
+	Merged bool
+}
+
+// linkedCommentID returns the comment id in an issue about pull request num.
+func linkedCommentID(num int) string {
+	return fmt.Sprintf("%s-%d", utils.LinkedPRCommentID, num)
+}
+
+// referencedIssues returns issues which pull request claims to fix in its
+// description or commit messages.
+func (prp *PullRequestProcessor) referencedIssues(pr *github.PullRequest) []*github.Issue {
+	text := pr.GetBody()
+	commits, err := prp.Client.ListCommits(*(pr.Number))
+	if err == nil {
+		for _, commit := range commits {
+			if commit.Commit != nil {
+				text += "\n" + commit.Commit.GetMessage()
+			}
+		}
+	}
+
+	var result []*github.Issue
+	for _, num := range refs.Parse(prp.Owner, prp.Repo, text) {
+		if num == *(pr.Number) {
+			continue
+		}
+		issue, err := prp.Client.GetIssue(num)
+		if err != nil {
+			continue
+		}
+		// pull requests share numbers with issues, and could not be fixed.
+		if issue.PullRequestLinks != nil {
+			continue
+		}
+		result = append(result, issue)
+	}
+	return result
+}
+
+// linkIssues comments in and labels issues referenced by an open pull request,
+// and warns if a bug fix references no issue. If oldBody is not nil, issues
+// referenced only by it are unlinked from pull request.
+func (prp *PullRequestProcessor) linkIssues(pr *github.PullRequest, oldBody *string) error {
+	if !prp.IssueLinks.Enabled {
+		return nil
+	}
+
+	issues := prp.referencedIssues(pr)
+	prp.checkBugReference(pr, issues)
+	if oldBody != nil {
+		prp.unlinkIssues(pr, *oldBody, issues)
+	}
+
+	body, err := prp.Templates.Render(utils.LinkedPRCommentID, LinkedPR{
+		Author: pr.User.GetLogin(),
+		Number: *(pr.Number),
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, issue := range issues {
+		if issue.GetState() != "open" {
+			continue
+		}
+		num := issue.GetNumber()
+		if err := prp.Client.UpsertComment(num, linkedCommentID(*(pr.Number)), body); err != nil {
+			return err
+		}
+		if !prp.Client.IssueHasLabel(num, utils.PRInProgressLabel) {
+			prp.Client.AddLabelsToIssue(num, []string{utils.PRInProgressLabel})
+		}
+	}
+	return nil
+}
+
+// unlinkIssues removes the comment about pull request and status/pr-in-progress
+// from issues which were referenced by oldBody, but no longer referenced by
+// pull request. Issues without the comment have never been linked, and are left
+// as they are.
+func (prp *PullRequestProcessor) unlinkIssues(pr *github.PullRequest, oldBody string, issues []*github.Issue) {
+	referenced := map[int]bool{}
+	for _, issue := range issues {
+		referenced[issue.GetNumber()] = true
+	}
+
+	id := linkedCommentID(*(pr.Number))
+	for _, num := range refs.Parse(prp.Owner, prp.Repo, oldBody) {
+		if num == *(pr.Number) || referenced[num] {
+			continue
+		}
+		comments, err := prp.Client.ListMarkedComments(num, id)
+		if err != nil || len(comments) == 0 {
+			continue
+		}
+		if err := prp.Client.RemoveMarkedComments(num, id); err != nil {
+			continue
+		}
+		if !prp.hasOpenLinkedPR(num, *(pr.Number)) && prp.Client.IssueHasLabel(num, utils.PRInProgressLabel) {
+			prp.Client.RemoveLabelForIssue(num, utils.PRInProgressLabel)
+		}
+		logrus.Infof("unlinked issue %d no longer referenced by pull request %d", num, *(pr.Number))
+	}
+}
+
+// checkBugReference comments in a pull request labeled kind/bug if it
+// references no issue, and removes the comment otherwise.
+func (prp *PullRequestProcessor) checkBugReference(pr *github.PullRequest, issues []*github.Issue) error {
+	num := *(pr.Number)
+	if len(issues) != 0 || !prp.Client.IssueHasLabel(num, utils.BugLabel) {
+		return prp.Client.RemoveMarkedComments(num, utils.BugWithoutIssueID)
+	}
+
+	body, err := prp.Templates.Render(utils.BugWithoutIssueID, templates.Data{
+		Author: pr.User.GetLogin(),
+	})
+	if err != nil {
+		return err
+	}
+	return prp.Client.UpsertComment(num, utils.BugWithoutIssueID, body)
+}
+
+// resolveIssues updates issues referenced by a closed pull request. If pull
+// request is merged, issues are closed or labeled status/fixed. Otherwise
+// the comment about pull request is removed.
+func (prp *PullRequestProcessor) resolveIssues(pr *github.PullRequest) error {
+	if !prp.IssueLinks.Enabled {
+		return nil
+	}
+
+	for _, issue := range prp.referencedIssues(pr) {
+		num := issue.GetNumber()
+		if !pr.GetMerged() {
+			prp.Client.RemoveMarkedComments(num, linkedCommentID(*(pr.Number)))
+			if !prp.hasOpenLinkedPR(num, *(pr.Number)) {
+				prp.Client.RemoveLabelForIssue(num, utils.PRInProgressLabel)
+			}
+			continue
+		}
+
+		body, err := prp.Templates.Render(utils.LinkedPRCommentID, LinkedPR{
+			Author: pr.User.GetLogin(),
+			Number: *(pr.Number),
+			Merged: true,
+		})
+		if err != nil {
+			return err
+		}
+		if err := prp.Client.UpsertComment(num, linkedCommentID(*(pr.Number)), body); err != nil {
+			return err
+		}
+		if prp.Client.IssueHasLabel(num, utils.PRInProgressLabel) {
+			prp.Client.RemoveLabelForIssue(num, utils.PRInProgressLabel)
+		}
+
+		if !prp.IssueLinks.CloseOnMerge {
+			prp.Client.AddLabelsToIssue(num, []string{utils.FixedLabel})
+			continue
+		}
+		// GitHub closes issue by itself if pull request is merged into default branch.
+		if issue.GetState() != "open" {
+			continue
+		}
+		if err := prp.Client.CloseIssue(num); err != nil {
+			return err
+		}
+		logrus.Infof("closed issue %d fixed by merged pull request %d", num, *(pr.Number))
+	}
+	return nil
+}
+
+// hasOpenLinkedPR returns true if an open pull request other than exclude
+// still claims to fix issue num.
+func (prp *PullRequestProcessor) hasOpenLinkedPR(num, exclude int) bool {
+	comments, err := prp.Client.ListComments(num)
+	if err != nil {
+		return false
+	}
+	for _, comment := range comments {
+		for _, match := range linkedMarkerRegex.FindAllStringSubmatch(comment.GetBody(), -1) {
+			pr, err := strconv.Atoi(match[1])
+			if err != nil || pr == exclude {
+				continue
+			}
+			if other, err := prp.Client.GetSinglePR(pr); err == nil && other.GetState() == "open" {
+				return true
+			}
+		}
+	}
+	return false
+}
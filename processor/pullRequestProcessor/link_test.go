@@ -0,0 +1,135 @@
+// Copyright 2018 The Pouch Robot Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pullRequestProcessor
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/pouchcontainer/pouchrobot/config"
+	"github.com/pouchcontainer/pouchrobot/gh"
+	"github.com/pouchcontainer/pouchrobot/gh/ghtest"
+	"github.com/pouchcontainer/pouchrobot/utils"
+	"github.com/pouchcontainer/pouchrobot/utils/templates"
+
+	"github.com/google/go-github/github"
+)
+
+func TestLinkIssues(t *testing.T) {
+	const (
+		issuePath = "/repos/pouchcontainer/pouch/issues/"
+		labelPath = "/labels/" + "status/pr-in-progress"
+	)
+	linked := func(id, pr int) *github.IssueComment {
+		return &github.IssueComment{
+			ID:   github.Int(id),
+			User: &github.User{Login: github.String(ghtest.Login)},
+			Body: github.String(gh.CommentMarker(linkedCommentID(pr)) + "\nworking on it"),
+		}
+	}
+
+	tests := []struct {
+		name    string
+		body    string
+		oldBody *string
+		commit  string
+		// comments are comments in issue 5.
+		comments []*github.IssueComment
+		want     []string
+	}{
+		{
+			name: "link referenced issue",
+			body: "fixes #3",
+			want: []string{"POST " + issuePath + "3/comments", "POST " + issuePath + "3/labels"},
+		},
+		{
+			name:     "body not changed",
+			body:     "fixes #3",
+			comments: []*github.IssueComment{linked(50, 12)},
+			want:     []string{"POST " + issuePath + "3/comments", "POST " + issuePath + "3/labels"},
+		},
+		{
+			name:     "unlink dropped issue",
+			body:     "fixes #3",
+			oldBody:  github.String("fixes #3, fixes #5, fixes #7"),
+			comments: []*github.IssueComment{linked(50, 12)},
+			want: []string{
+				"DELETE /repos/pouchcontainer/pouch/issues/comments/50",
+				"DELETE " + issuePath + "5" + labelPath,
+				"POST " + issuePath + "3/comments", "POST " + issuePath + "3/labels",
+			},
+		},
+		{
+			name:     "keep label of issue fixed by another pull request",
+			body:     "fixes #3",
+			oldBody:  github.String("fixes #3, fixes #5"),
+			comments: []*github.IssueComment{linked(50, 12), linked(51, 20)},
+			want: []string{
+				"DELETE /repos/pouchcontainer/pouch/issues/comments/50",
+				"POST " + issuePath + "3/comments", "POST " + issuePath + "3/labels",
+			},
+		},
+		{
+			name:     "keep issue referenced by commit",
+			body:     "fixes #3",
+			oldBody:  github.String("fixes #3, fixes #5"),
+			commit:   "fix: close leaked fd\n\nfixes #5",
+			comments: []*github.IssueComment{linked(50, 12)},
+			want: []string{
+				"POST " + issuePath + "3/comments", "POST " + issuePath + "3/labels",
+				"PATCH /repos/pouchcontainer/pouch/issues/comments/50",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := ghtest.NewServer()
+			defer server.Close()
+			for _, num := range []int{3, 7} {
+				server.Issues[num] = &github.Issue{Number: github.Int(num), State: github.String("open")}
+			}
+			server.Issues[5] = &github.Issue{
+				Number: github.Int(5),
+				State:  github.String("open"),
+				Labels: []github.Label{{Name: github.String(utils.PRInProgressLabel)}},
+			}
+			server.Comments[5] = tt.comments
+			server.PullRequests[20] = &github.PullRequest{Number: github.Int(20), State: github.String("open")}
+			if tt.commit != "" {
+				server.Commits[12] = []*github.RepositoryCommit{{Commit: &github.Commit{Message: github.String(tt.commit)}}}
+			}
+
+			prp := &PullRequestProcessor{
+				Client:     server.Client("pouchcontainer", "pouch"),
+				Templates:  templates.New("", "en"),
+				Owner:      "pouchcontainer",
+				Repo:       "pouch",
+				IssueLinks: config.IssueLinksConfig{Enabled: true},
+			}
+			pr := &github.PullRequest{
+				Number: github.Int(12),
+				Body:   github.String(tt.body),
+				User:   &github.User{Login: github.String("contributor")},
+			}
+
+			if err := prp.linkIssues(pr, tt.oldBody); err != nil {
+				t.Fatalf("linkIssues() error = %v", err)
+			}
+			if got := server.Recorded(); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("linkIssues() requests = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -15,14 +15,14 @@
 package open
 
 import (
-	"strings"
-
 	"github.com/pouchcontainer/pouchrobot/utils"
+	"github.com/pouchcontainer/pouchrobot/utils/matcher"
 
 	"github.com/google/go-github/github"
 	"github.com/sirupsen/logrus"
 )
 
+// Default maximum changed lines of each size.
 var (
 	// XS is
 	XS = 10
@@ -36,36 +36,10 @@ var (
 	XL = 640
 )
 
-// ParseToGeneratePRLabels parses
+// ParseToGeneratePRLabels parses pull request to generate labels except
+// size labels, which are generated from changed files via MeasureSize.
 func ParseToGeneratePRLabels(pr *github.PullRequest) []string {
-	var labels []string
-	labels = append(labels, ParseToGetPRSize(pr))
-	labels = append(labels, ParseTitleToGenerateLabels(pr)...)
-	return utils.UniqueElementSlice(labels)
-}
-
-// ParseToGetPRSize parses the pr additions and deletions
-func ParseToGetPRSize(pr *github.PullRequest) string {
-	if pr.Additions == nil || pr.Deletions == nil {
-		logrus.Errorf("pull request %d has no field value of Additions or Deletions when ParseToGetPRSize", *(pr.Number))
-		return "size/XS"
-	}
-	if *(pr.Additions)+*(pr.Deletions) <= XS {
-		return "size/XS"
-	}
-	if *(pr.Additions)+*(pr.Deletions) <= S {
-		return "size/S"
-	}
-	if *(pr.Additions)+*(pr.Deletions) <= M {
-		return "size/M"
-	}
-	if *(pr.Additions)+*(pr.Deletions) <= L {
-		return "size/L"
-	}
-	if *(pr.Additions)+*(pr.Deletions) <= XL {
-		return "size/XL"
-	}
-	return "size/XXL"
+	return utils.UniqueElementSlice(ParseTitleToGenerateLabels(pr))
 }
 
 // ParseTitleToGenerateLabels parses
@@ -74,16 +48,14 @@ func ParseTitleToGenerateLabels(pr *github.PullRequest) []string {
 		logrus.Errorf("pull request %d has no title when ParseTitleToGenerateLabels", *(pr.Number))
 		return nil
 	}
-	var labels []string
-	title := pr.Title
-	for label, matchedSlice := range utils.TitleMatches {
-		for _, pattern := range matchedSlice {
-			lowerCaseTitle := strings.ToLower(*title)
-			if strings.Contains(lowerCaseTitle, pattern) {
-				labels = append(labels, label)
-				break
-			}
-		}
+	return matcher.Current().Match(matcher.ScopeTitle, *(pr.Title))
+}
+
+// ParseFilesToGenerateLabels parses changed files of a pull request to generate labels.
+func ParseFilesToGenerateLabels(files []*github.CommitFile) []string {
+	var paths []string
+	for _, file := range files {
+		paths = append(paths, file.GetFilename())
 	}
-	return labels
+	return matcher.Current().MatchFiles(paths)
 }
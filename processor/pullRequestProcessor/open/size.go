@@ -0,0 +1,126 @@
+// Copyright 2018 The Pouch Robot Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package open
+
+import (
+	"path"
+	"strings"
+
+	"github.com/pouchcontainer/pouchrobot/config"
+	"github.com/pouchcontainer/pouchrobot/utils"
+	"github.com/pouchcontainer/pouchrobot/utils/glob"
+
+	"github.com/google/go-github/github"
+)
+
+// DefaultSizeIgnore are patterns of files not counted in size by default.
+var DefaultSizeIgnore = []string{
+	"vendor/**",
+	"*.pb.go",
+	"*_generated.go",
+	"zz_generated*.go",
+	"docs/api/**",
+}
+
+// Size is the number of changed lines of a pull request by kind of files.
+type Size struct {
+	// Code is changed lines of source code.
+	Code int
+
+	// Tests is changed lines of test files.
+	Tests int
+
+	// Docs is changed lines of documents.
+	Docs int
+
+	// Ignored is changed lines of ignored files, which are not counted in Total.
+	Ignored int
+}
+
+// Total returns changed lines counted in size.
+func (s Size) Total() int {
+	return s.Code + s.Tests + s.Docs
+}
+
+// MeasureSize measures size of changed files. Files matching ignore are not
+// counted, DefaultSizeIgnore is used if ignore is empty.
+func MeasureSize(files []*github.CommitFile, ignore []string) Size {
+	if len(ignore) == 0 {
+		ignore = DefaultSizeIgnore
+	}
+
+	var size Size
+	for _, file := range files {
+		name := file.GetFilename()
+		lines := file.GetAdditions() + file.GetDeletions()
+		switch {
+		case glob.Match(ignore, name):
+			size.Ignored += lines
+		case isTestFile(name):
+			size.Tests += lines
+		case isDocFile(name):
+			size.Docs += lines
+		default:
+			size.Code += lines
+		}
+	}
+	return size
+}
+
+// SizeLabel returns the size label of total changed lines. Zero thresholds
+// fall back to XS, S, M, L and XL.
+func SizeLabel(total int, thresholds config.SizeThresholds) string {
+	sizes := []struct {
+		name      string
+		threshold int
+		fallback  int
+	}{
+		{"XS", thresholds.XS, XS},
+		{"S", thresholds.S, S},
+		{"M", thresholds.M, M},
+		{"L", thresholds.L, L},
+		{"XL", thresholds.XL, XL},
+	}
+	for _, size := range sizes {
+		threshold := size.threshold
+		if threshold == 0 {
+			threshold = size.fallback
+		}
+		if total <= threshold {
+			return utils.SizeLabelPrefix + size.name
+		}
+	}
+	return utils.SizeLabelPrefix + "XXL"
+}
+
+func isTestFile(name string) bool {
+	if strings.HasSuffix(name, "_test.go") {
+		return true
+	}
+	for _, dir := range strings.Split(path.Dir(name), "/") {
+		if dir == "test" || dir == "tests" || dir == "testdata" {
+			return true
+		}
+	}
+	return false
+}
+
+func isDocFile(name string) bool {
+	switch strings.ToLower(path.Ext(name)) {
+	case ".md", ".markdown", ".rst", ".txt":
+		return true
+	}
+	return strings.HasPrefix(name, "docs/") || strings.HasPrefix(name, "doc/")
+}
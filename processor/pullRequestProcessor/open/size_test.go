@@ -0,0 +1,49 @@
+// Copyright 2018 The Pouch Robot Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package open
+
+import (
+	"testing"
+
+	"github.com/pouchcontainer/pouchrobot/config"
+
+	"github.com/google/go-github/github"
+)
+
+func TestMeasureSize(t *testing.T) {
+	file := func(name string, additions, deletions int) *github.CommitFile {
+		return &github.CommitFile{Filename: &name, Additions: &additions, Deletions: &deletions}
+	}
+	files := []*github.CommitFile{
+		file("network/bridge.go", 30, 10),
+		file("network/bridge_test.go", 20, 0),
+		file("test/cli_run_test.go", 5, 5),
+		file("docs/architecture.md", 8, 2),
+		file("vendor/github.com/foo/bar.go", 5000, 1000),
+		file("cri/v1alpha2/api.pb.go", 800, 800),
+	}
+
+	size := MeasureSize(files, nil)
+	want := Size{Code: 40, Tests: 30, Docs: 10, Ignored: 7600}
+	if size != want {
+		t.Errorf("MeasureSize() = %+v, want %+v", size, want)
+	}
+	if got := SizeLabel(size.Total(), config.SizeThresholds{}); got != "size/M" {
+		t.Errorf("SizeLabel() with default thresholds = %s, want size/M", got)
+	}
+	if got := SizeLabel(size.Total(), config.SizeThresholds{M: 60, L: 70}); got != "size/XL" {
+		t.Errorf("SizeLabel() with custom thresholds = %s, want size/XL", got)
+	}
+}
@@ -15,29 +15,81 @@
 package pullRequestProcessor
 
 import (
-	"fmt"
-
+	"github.com/pouchcontainer/pouchrobot/gh"
 	"github.com/pouchcontainer/pouchrobot/processor/pullRequestProcessor/open"
 	"github.com/pouchcontainer/pouchrobot/utils"
+	"github.com/pouchcontainer/pouchrobot/utils/templates"
 
 	"github.com/google/go-github/github"
 	"github.com/sirupsen/logrus"
 )
 
 // ActToPROpened acts a pull request opened event.
-func (prp *PullRequestProcessor) ActToPROpened(pr *github.PullRequest) error {
-	prp.attachLabels(pr)
+func (prp *PullRequestProcessor) ActToPROpened(pr *github.PullRequest, files gh.FileLister) error {
+	prp.attachLabels(pr, files)
 	prp.attachComments(pr)
+	prp.linkIssues(pr, nil)
+	prp.assignReviewers(pr, files)
+	prp.CheckApprovals(pr, files, prp.RequestOwnerReviewers)
+	return nil
+}
+
+func (prp *PullRequestProcessor) assignReviewers(pr *github.PullRequest, files gh.FileLister) error {
+	if prp.Assigner == nil || pr.Number == nil || pr.User == nil {
+		return nil
+	}
+
+	changed, err := files.ListFiles(*(pr.Number))
+	if err != nil {
+		return err
+	}
+	var names []string
+	for _, file := range changed {
+		names = append(names, file.GetFilename())
+	}
+
+	reviewers, err := prp.Assigner.Assign(*(pr.Number), pr.User.GetLogin(), names)
+	if err != nil {
+		return err
+	}
+	if len(reviewers) != 0 {
+		logrus.Infof("requested reviewers %v for pull request %d", reviewers, *(pr.Number))
+	}
 	return nil
 }
 
-func (prp *PullRequestProcessor) attachLabels(pr *github.PullRequest) error {
+func (prp *PullRequestProcessor) attachLabels(pr *github.PullRequest, files gh.FileLister) error {
 	// attach labels
+	labels, err := prp.generateLabels(pr, files)
+	prp.attachSizeComment(pr, files)
+	if len(labels) != 0 {
+		if addErr := prp.Client.AddLabelsToIssue(*(pr.Number), labels); addErr != nil {
+			return addErr
+		}
+	}
+	return err
+}
+
+// generateLabels generates labels of pull request from its title, changed
+// files and code owners of changed files. If changed files can not be listed,
+// labels from title are returned together with the error.
+func (prp *PullRequestProcessor) generateLabels(pr *github.PullRequest, files gh.FileLister) ([]string, error) {
 	labels := open.ParseToGeneratePRLabels(pr)
-	if len(labels) == 0 {
-		return nil
+	changed, err := files.ListFiles(*(pr.Number))
+	if err != nil {
+		return labels, err
+	}
+	labels = append(labels, open.ParseFilesToGenerateLabels(changed)...)
+	labels = append(labels, open.SizeLabel(open.MeasureSize(changed, prp.Size.Ignore).Total(), prp.Size.Thresholds))
+
+	if prp.CodeOwners != nil {
+		var names []string
+		for _, file := range changed {
+			names = append(names, file.GetFilename())
+		}
+		labels = append(labels, prp.CodeOwners.Labels(names)...)
 	}
-	return prp.Client.AddLabelsToIssue(*(pr.Number), labels)
+	return utils.UniqueElementSlice(labels), nil
 }
 
 func (prp *PullRequestProcessor) attachComments(pr *github.PullRequest) error {
@@ -45,7 +97,7 @@ func (prp *PullRequestProcessor) attachComments(pr *github.PullRequest) error {
 	prp.attachTitleComments(pr)
 
 	// check pull request whether description is sufficient
-	prp.attachBodyComments(pr)
+	prp.checkDescription(pr)
 
 	// check whether this pull request is signed off
 	prp.addSignoffComments(pr)
@@ -57,30 +109,22 @@ func (prp *PullRequestProcessor) attachComments(pr *github.PullRequest) error {
 }
 
 func (prp *PullRequestProcessor) attachTitleComments(pr *github.PullRequest) error {
-	if pr.Title != nil && len(*(pr.Title)) > 20 {
+	minLength := prp.titleMinLength()
+	if len(pr.GetTitle()) >= minLength {
 		return nil
 	}
 
 	// attach comment
-	body := fmt.Sprintf(utils.PRTitleTooShort, *(pr.User.Login), prp.Owner, prp.Repo)
-	newComment := &github.IssueComment{
-		Body: &body,
-	}
-
-	return prp.Client.AddCommentToPR(*(pr.Number), newComment)
-}
-
-func (prp *PullRequestProcessor) attachBodyComments(pr *github.PullRequest) error {
-	if pr.Body != nil && len(*(pr.Body)) > 50 {
-		return nil
-	}
-
-	body := fmt.Sprintf(utils.PRDescriptionTooShort, *(pr.User.Login), prp.Owner, prp.Repo)
-	newComment := &github.IssueComment{
-		Body: &body,
+	body, err := prp.Templates.Render(utils.PRTitleTooShortID, templates.Data{
+		Author:    *(pr.User.Login),
+		Owner:     prp.Owner,
+		Repo:      prp.Repo,
+		MinLength: minLength,
+	})
+	if err != nil {
+		return err
 	}
-
-	return prp.Client.AddCommentToPR(*(pr.Number), newComment)
+	return prp.Client.UpsertComment(*(pr.Number), utils.PRTitleTooShortID, body)
 }
 
 func (prp *PullRequestProcessor) addSignoffComments(pr *github.PullRequest) error {
@@ -102,12 +146,13 @@ func (prp *PullRequestProcessor) addSignoffComments(pr *github.PullRequest) erro
 		return nil
 	}
 
-	body := fmt.Sprintf(utils.PRNeedsSignOff, *(pr.User.Login))
-	newComment := &github.IssueComment{
-		Body: &body,
+	body, err := prp.Templates.Render(utils.PRNeedsSignOffID, templates.Data{
+		Author: *(pr.User.Login),
+	})
+	if err != nil {
+		return err
 	}
-
-	return prp.Client.AddCommentToPR(*(pr.Number), newComment)
+	return prp.Client.UpsertComment(*(pr.Number), utils.PRNeedsSignOffID, body)
 }
 
 // attachFirstContributionComments attaches a first contributor comments when
@@ -130,11 +175,15 @@ func (prp *PullRequestProcessor) attachFirstContributionComments(pullRequest *gi
 	}
 
 	// generate PR comment body
-	body := fmt.Sprintf(utils.FirstCommitComment, prp.Repo, *(pr.User.Login), prp.Owner, prp.Repo)
-	newComment := &github.IssueComment{
-		Body: &body,
+	body, err := prp.Templates.Render(utils.FirstCommitCommentID, templates.Data{
+		Author: *(pr.User.Login),
+		Owner:  prp.Owner,
+		Repo:   prp.Repo,
+	})
+	if err != nil {
+		return err
 	}
-	return prp.Client.AddCommentToPR(*(pr.Number), newComment)
+	return prp.Client.UpsertComment(*(pr.Number), utils.FirstCommitCommentID, body)
 }
 
 // isFirstContribution returns true if the author_assiciate field is FIRST_TIME_CONTRIBUTOR.
@@ -0,0 +1,185 @@
+// Copyright 2018 The Pouch Robot Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pullRequestProcessor
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/pouchcontainer/pouchrobot/gh"
+	"github.com/pouchcontainer/pouchrobot/owners"
+	"github.com/pouchcontainer/pouchrobot/utils"
+
+	"github.com/google/go-github/github"
+)
+
+// OwnersStatusContext is the context of commit status which reports
+// whether every area touched by a pull request is approved.
+const OwnersStatusContext = "pouchrobot/approvals"
+
+// OwnersSummary is the data to render comment of missing approvals.
+type OwnersSummary struct {
+	// Author is the GitHub login of the pull request author.
+	Author string
+
+	// Missing are touched areas which are not approved yet.
+	Missing []*owners.Area
+
+	// Reviewers are suggested reviewers of the pull request.
+	Reviewers []string
+}
+
+// CheckApprovals checks whether every area touched by pull request is
+// approved by an approver in OWNERS files, reports it as a commit status and
+// summarizes missing approvals in a comment. Reviewers from OWNERS files are
+// requested if requestReviewers is true.
+func (prp *PullRequestProcessor) CheckApprovals(pr *github.PullRequest, files gh.FileLister, requestReviewers bool) error {
+	if prp.Owners == nil || pr.Number == nil || pr.User == nil || pr.Head == nil {
+		return nil
+	}
+	num := *(pr.Number)
+	author := pr.User.GetLogin()
+
+	changed, err := files.ListFiles(num)
+	if err != nil {
+		return err
+	}
+	var names []string
+	for _, file := range changed {
+		names = append(names, file.GetFilename())
+	}
+
+	areas := prp.Owners.Areas(names)
+	if len(areas) == 0 {
+		return nil
+	}
+
+	reviews, err := prp.Client.ListPRReviews(num)
+	if err != nil {
+		return err
+	}
+	approved := approvedReviewers(reviews, author)
+	missing := owners.MissingApprovals(areas, approved)
+
+	requested, err := prp.Client.ListRequestedReviewers(num)
+	if err != nil {
+		return err
+	}
+	reviewers := suggestReviewers(missing, author, requested, reviews)
+	if requestReviewers && len(reviewers) != 0 {
+		prp.Client.RequestReviewers(num, reviewers)
+	}
+
+	if len(missing) == 0 {
+		if err := prp.Client.RemoveMarkedComments(num, utils.OwnersApprovalCommentID); err != nil {
+			return err
+		}
+		return prp.Client.CreateStatus(pr.Head.GetSHA(), "success", OwnersStatusContext, "all touched directories are approved")
+	}
+
+	body, err := prp.Templates.Render(utils.OwnersApprovalCommentID, OwnersSummary{
+		Author:    author,
+		Missing:   missing,
+		Reviewers: reviewers,
+	})
+	if err != nil {
+		return err
+	}
+	if err := prp.Client.UpsertComment(num, utils.OwnersApprovalCommentID, body); err != nil {
+		return err
+	}
+
+	var dirs []string
+	for _, area := range missing {
+		dirs = append(dirs, area.Name())
+	}
+	description := fmt.Sprintf("approval needed in %s", strings.Join(dirs, ", "))
+	return prp.Client.CreateStatus(pr.Head.GetSHA(), "pending", OwnersStatusContext, description)
+}
+
+// approvedReviewers returns users whose latest decisive review approves the
+// pull request.
+func approvedReviewers(reviews []*github.PullRequestReview, author string) []string {
+	var approved []string
+	for _, review := range latestDecisions(reviews, author) {
+		if review.GetState() == "APPROVED" {
+			approved = append(approved, review.User.GetLogin())
+		}
+	}
+	return approved
+}
+
+// latestDecisions returns the latest decisive review of each reviewer other
+// than author, in order of their first reviews. Reviews are in chronological
+// order, comment-only reviews do not change a previous decision.
+func latestDecisions(reviews []*github.PullRequestReview, author string) []*github.PullRequestReview {
+	decisions := map[string]*github.PullRequestReview{}
+	var users []string
+	for _, review := range reviews {
+		user := review.User.GetLogin()
+		state := review.GetState()
+		if user == "" || strings.EqualFold(user, author) || state == "COMMENTED" || state == "PENDING" {
+			continue
+		}
+		if _, ok := decisions[user]; !ok {
+			users = append(users, user)
+		}
+		decisions[user] = review
+	}
+
+	var result []*github.PullRequestReview
+	for _, user := range users {
+		result = append(result, decisions[user])
+	}
+	return result
+}
+
+// suggestReviewers picks one reviewer for each area missing approval unless
+// one of its reviewers is already requested or reviewing. Approvers are
+// picked if an area has no reviewers. Author is never picked.
+func suggestReviewers(missing []*owners.Area, author string, requested []string, reviews []*github.PullRequestReview) []string {
+	involved := map[string]bool{}
+	for _, user := range requested {
+		involved[strings.ToLower(user)] = true
+	}
+	for _, review := range reviews {
+		involved[strings.ToLower(review.User.GetLogin())] = true
+	}
+	delete(involved, strings.ToLower(author))
+
+	var reviewers []string
+	for _, area := range missing {
+		candidates := area.Reviewers
+		if len(candidates) == 0 {
+			candidates = area.Approvers
+		}
+
+		var picked string
+		for _, candidate := range candidates {
+			if involved[strings.ToLower(candidate)] {
+				picked = ""
+				break
+			}
+			if picked == "" && !strings.EqualFold(candidate, author) {
+				picked = candidate
+			}
+		}
+		if picked != "" {
+			involved[strings.ToLower(picked)] = true
+			reviewers = append(reviewers, picked)
+		}
+	}
+	return reviewers
+}
@@ -15,11 +15,18 @@
 package pullRequestProcessor
 
 import (
-	"fmt"
 	"regexp"
 
+	"github.com/pouchcontainer/pouchrobot/assigner"
+	"github.com/pouchcontainer/pouchrobot/codeowners"
+	"github.com/pouchcontainer/pouchrobot/config"
 	"github.com/pouchcontainer/pouchrobot/gh"
+	"github.com/pouchcontainer/pouchrobot/maintainers"
+	"github.com/pouchcontainer/pouchrobot/owners"
+	"github.com/pouchcontainer/pouchrobot/repoconfig"
+	"github.com/pouchcontainer/pouchrobot/repotemplates"
 	"github.com/pouchcontainer/pouchrobot/utils"
+	"github.com/pouchcontainer/pouchrobot/utils/templates"
 
 	"github.com/sirupsen/logrus"
 )
@@ -31,13 +38,47 @@ var (
 
 // PullRequestProcessor is
 type PullRequestProcessor struct {
-	Client *gh.Client
-	Owner  string
-	Repo   string
+	Client    *gh.Client
+	Templates *templates.Renderer
+	Owner     string
+	Repo      string
+
+	// Size configures thresholds and ignored files of size labels.
+	Size config.SizeConfig
+
+	// Maintainers decides whose approving reviews count as LGTM.
+	Maintainers *maintainers.Maintainers
+
+	// IssueLinks configures linking pull requests with issues they fix.
+	IssueLinks config.IssueLinksConfig
+
+	// RepoConfig provides settings from per repository config file. Built-in
+	// defaults are used if it is nil.
+	RepoConfig *repoconfig.Loader
+
+	// RepoTemplates loads pull request template of repo to check pull request
+	// description against. Only length of description is checked if it is nil.
+	RepoTemplates *repotemplates.Loader
+
+	// Owners loads OWNERS files of repository. Approvals are not checked if it is nil.
+	Owners *owners.Loader
+
+	// RequestOwnerReviewers requests reviewers from OWNERS files when a pull request is opened.
+	RequestOwnerReviewers bool
+
+	// CodeOwners loads CODEOWNERS file to derive area labels. No labels are derived if it is nil.
+	CodeOwners *codeowners.Loader
+
+	// Assigner requests reviewers when a pull request is opened. Reviewers are not assigned if it is nil.
+	Assigner *assigner.Assigner
+
+	// postMergeHooks are called after a pull request is merged.
+	postMergeHooks []postMergeHook
 }
 
-// Process processes pull request events
-func (prp *PullRequestProcessor) Process(data []byte) error {
+// Process processes pull request events. files lists files changed in pull
+// request, shared with other handlers of the event.
+func (prp *PullRequestProcessor) Process(data []byte, files gh.FileLister) error {
 	// process details
 	actionType, err := utils.ExtractActionType(data)
 	if err != nil {
@@ -54,7 +95,7 @@ func (prp *PullRequestProcessor) Process(data []byte) error {
 
 	switch actionType {
 	case "opened":
-		if err := prp.ActToPROpened(&pr); err != nil {
+		if err := prp.ActToPROpened(&pr, files); err != nil {
 			return err
 		}
 	case "labeled":
@@ -62,17 +103,46 @@ func (prp *PullRequestProcessor) Process(data []byte) error {
 			return err
 		}
 	case "review_requested":
+		reviewer, err := utils.ExactRequestedReviewer(data)
+		if err != nil {
+			return err
+		}
+		if err := prp.ActToPRReviewRequested(&pr, reviewer.GetLogin()); err != nil {
+			return err
+		}
 	case "synchronize":
-		if err := prp.ActToPRSynchronized(&pr); err != nil {
+		if err := prp.ActToPRSynchronized(&pr, files); err != nil {
 			return err
 		}
 	case "edited":
-		if err := prp.ActToPREdited(&pr); err != nil {
+		var oldBody *string
+		body, changed, err := utils.ExactPreviousBody(data)
+		if err != nil {
+			return err
+		}
+		if changed {
+			oldBody = &body
+		}
+		if err := prp.ActToPREdited(&pr, files, oldBody); err != nil {
+			return err
+		}
+	case "closed":
+		if err := prp.ActToPRClosed(&pr); err != nil {
+			return err
+		}
+	case "reopened":
+		if err := prp.ActToPRReopened(&pr, files); err != nil {
+			return err
+		}
+	case "unlabeled":
+		if err := prp.ActToPRUnlabeled(&pr); err != nil {
 			return err
 		}
-	case "pull_request_review":
+	case "assigned", "unassigned", "review_request_removed", "ready_for_review", "converted_to_draft",
+		"locked", "unlocked", "auto_merge_enabled", "auto_merge_disabled", "milestoned", "demilestoned":
+		// nothing to do with these actions.
 	default:
-		return fmt.Errorf("unknown action type %s in pull request: ", actionType)
+		logrus.Warnf("unknown action type %s in pull request, ignore it", actionType)
 	}
 	return nil
 }
@@ -0,0 +1,156 @@
+// Copyright 2018 The Pouch Robot Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pullRequestProcessor
+
+import (
+	"strings"
+
+	"github.com/pouchcontainer/pouchrobot/gh"
+	"github.com/pouchcontainer/pouchrobot/utils"
+
+	"github.com/google/go-github/github"
+	"github.com/sirupsen/logrus"
+)
+
+// ProcessReview processes pull request review events. files lists files
+// changed in pull request, shared with other handlers of the event.
+func (prp *PullRequestProcessor) ProcessReview(data []byte, files gh.FileLister) error {
+	actionType, err := utils.ExtractActionType(data)
+	if err != nil {
+		return err
+	}
+
+	logrus.Infof("received event type [pull request review], action type [%s]", actionType)
+
+	pr, err := utils.ExactPR(data)
+	if err != nil {
+		return err
+	}
+	review, err := utils.ExactReview(data)
+	if err != nil {
+		return err
+	}
+
+	switch actionType {
+	case "submitted":
+		if err := prp.ActToPRReviewSubmitted(&pr, &review); err != nil {
+			return err
+		}
+	case "dismissed":
+		if err := prp.updateReviewLabels(&pr); err != nil {
+			return err
+		}
+	case "edited":
+	default:
+		return nil
+	}
+	return prp.CheckApprovals(&pr, files, false)
+}
+
+// ActToPRReviewSubmitted acts to the event that a review is submitted.
+func (prp *PullRequestProcessor) ActToPRReviewSubmitted(pr *github.PullRequest, review *github.PullRequestReview) error {
+	logrus.Debugf("review %s submitted by %s in pull request %d", review.GetState(), review.User.GetLogin(), *(pr.Number))
+	return prp.updateReviewLabels(pr)
+}
+
+// ActToPRReviewRequested acts to the event that review is requested from
+// reviewer. Requesting review again from a reviewer who requested changes
+// means author has addressed them.
+func (prp *PullRequestProcessor) ActToPRReviewRequested(pr *github.PullRequest, reviewer string) error {
+	decisions, err := prp.listDecisions(pr)
+	if err != nil {
+		return err
+	}
+	return prp.updateChangesRequestedLabel(pr, decisions, reviewer)
+}
+
+// updateReviewLabels updates LGTM and status/changes-requested labels of pull
+// request from the latest decisions of reviewers.
+func (prp *PullRequestProcessor) updateReviewLabels(pr *github.PullRequest) error {
+	decisions, err := prp.listDecisions(pr)
+	if err != nil {
+		return err
+	}
+	if err := prp.updateLGTMLabel(pr, decisions); err != nil {
+		return err
+	}
+	return prp.updateChangesRequestedLabel(pr, decisions, "")
+}
+
+// listDecisions lists the latest decisive review of each reviewer of pull request.
+func (prp *PullRequestProcessor) listDecisions(pr *github.PullRequest) ([]*github.PullRequestReview, error) {
+	reviews, err := prp.Client.ListPRReviews(*(pr.Number))
+	if err != nil {
+		return nil, err
+	}
+	return latestDecisions(reviews, pr.User.GetLogin()), nil
+}
+
+// updateLGTMLabel adds LGTM label to pull request if the latest decision of
+// any maintainer is approval, and removes it otherwise, like after approval
+// is dismissed or the maintainer requests changes later.
+func (prp *PullRequestProcessor) updateLGTMLabel(pr *github.PullRequest, decisions []*github.PullRequestReview) error {
+	if prp.Maintainers == nil {
+		return nil
+	}
+
+	approved := false
+	for _, review := range decisions {
+		if review.GetState() == "APPROVED" && prp.Maintainers.IsMaintainer(review.User.GetLogin()) {
+			approved = true
+			break
+		}
+	}
+	return prp.setLabel(*(pr.Number), utils.LGTMLabel, approved)
+}
+
+// updateChangesRequestedLabel adds status/changes-requested label to pull
+// request if any reviewer other than resolved requests changes on the head
+// commit, and removes the label otherwise. Changes requested on previous
+// commits are considered as addressed by the push.
+func (prp *PullRequestProcessor) updateChangesRequestedLabel(pr *github.PullRequest, decisions []*github.PullRequestReview, resolved string) error {
+	requested := false
+	for _, review := range decisions {
+		if review.GetState() != "CHANGES_REQUESTED" || strings.EqualFold(review.User.GetLogin(), resolved) {
+			continue
+		}
+		if review.GetCommitID() == pr.Head.GetSHA() {
+			requested = true
+			break
+		}
+	}
+	return prp.setLabel(*(pr.Number), utils.ChangesRequestedLabel, requested)
+}
+
+// setLabel adds label to issue num if want is true, and removes it otherwise.
+func (prp *PullRequestProcessor) setLabel(num int, label string, want bool) error {
+	hasLabel := prp.Client.IssueHasLabel(num, label)
+	if want && !hasLabel {
+		return prp.Client.AddLabelsToIssue(num, []string{label})
+	}
+	if !want && hasLabel {
+		return prp.Client.RemoveLabelForIssue(num, label)
+	}
+	return nil
+}
+
+// removeChangesRequestedLabel removes status/changes-requested label from
+// pull request since author pushed new commits.
+func (prp *PullRequestProcessor) removeChangesRequestedLabel(pr *github.PullRequest) error {
+	if !prp.Client.IssueHasLabel(*(pr.Number), utils.ChangesRequestedLabel) {
+		return nil
+	}
+	return prp.Client.RemoveLabelForIssue(*(pr.Number), utils.ChangesRequestedLabel)
+}
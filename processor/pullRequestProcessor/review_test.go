@@ -0,0 +1,299 @@
+// Copyright 2018 The Pouch Robot Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pullRequestProcessor
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/pouchcontainer/pouchrobot/gh/ghtest"
+	"github.com/pouchcontainer/pouchrobot/maintainers"
+	"github.com/pouchcontainer/pouchrobot/utils"
+
+	"github.com/google/go-github/github"
+)
+
+const (
+	labelsPath           = "/repos/pouchcontainer/pouch/issues/12/labels"
+	changesRequestedPath = labelsPath + "/status/changes-requested"
+	lgtmPath             = labelsPath + "/LGTM"
+)
+
+func review(user, state, sha string) *github.PullRequestReview {
+	return &github.PullRequestReview{
+		User:     &github.User{Login: github.String(user)},
+		State:    github.String(state),
+		CommitID: github.String(sha),
+	}
+}
+
+func TestLatestDecisions(t *testing.T) {
+	tests := []struct {
+		name    string
+		reviews []*github.PullRequestReview
+		want    []string
+	}{
+		{
+			name: "no reviews",
+		},
+		{
+			name: "later decision wins",
+			reviews: []*github.PullRequestReview{
+				review("alice", "CHANGES_REQUESTED", "a"),
+				review("alice", "APPROVED", "b"),
+			},
+			want: []string{"alice APPROVED"},
+		},
+		{
+			name: "comments keep decision",
+			reviews: []*github.PullRequestReview{
+				review("alice", "CHANGES_REQUESTED", "a"),
+				review("alice", "COMMENTED", "b"),
+				review("alice", "PENDING", "b"),
+			},
+			want: []string{"alice CHANGES_REQUESTED"},
+		},
+		{
+			name: "order of first reviews",
+			reviews: []*github.PullRequestReview{
+				review("bob", "APPROVED", "a"),
+				review("alice", "CHANGES_REQUESTED", "a"),
+				review("bob", "CHANGES_REQUESTED", "b"),
+			},
+			want: []string{"bob CHANGES_REQUESTED", "alice CHANGES_REQUESTED"},
+		},
+		{
+			name: "author and unknown users are skipped",
+			reviews: []*github.PullRequestReview{
+				review("Contributor", "APPROVED", "a"),
+				review("", "APPROVED", "a"),
+				review("alice", "COMMENTED", "a"),
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []string
+			for _, review := range latestDecisions(tt.reviews, "contributor") {
+				got = append(got, review.User.GetLogin()+" "+review.GetState())
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("latestDecisions() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+// newReviewTest returns a processor on server and pull request 12 with head
+// commit "head". Pull request has labels and reviews.
+func newReviewTest(server *ghtest.Server, labels []string, reviews []*github.PullRequestReview) (*PullRequestProcessor, *github.PullRequest) {
+	issue := &github.Issue{Number: github.Int(12)}
+	for _, label := range labels {
+		issue.Labels = append(issue.Labels, github.Label{Name: github.String(label)})
+	}
+	server.Issues[12] = issue
+	server.Reviews[12] = reviews
+
+	prp := &PullRequestProcessor{
+		Client:      server.Client("pouchcontainer", "pouch"),
+		Owner:       "pouchcontainer",
+		Repo:        "pouch",
+		Maintainers: maintainers.New(nil, "", "", 0, []string{"allensun"}),
+	}
+	pr := &github.PullRequest{
+		Number: github.Int(12),
+		User:   &github.User{Login: github.String("contributor")},
+		Head:   &github.PullRequestBranch{SHA: github.String("head")},
+	}
+	return prp, pr
+}
+
+// recordedWithBody returns requests recorded by server with bodies, like
+// `POST /repos/pouchcontainer/pouch/issues/12/labels ["LGTM"]`.
+func recordedWithBody(server *ghtest.Server) []string {
+	var got []string
+	for _, request := range server.Requests {
+		got = append(got, strings.TrimSpace(request.Method+" "+request.Path+" "+string(request.Body)))
+	}
+	return got
+}
+
+func TestActToPRReviewSubmitted(t *testing.T) {
+	tests := []struct {
+		name    string
+		review  *github.PullRequestReview
+		reviews []*github.PullRequestReview
+		labels  []string
+		want    []string
+	}{
+		{
+			name:    "approved by maintainer",
+			review:  review("allensun", "approved", "head"),
+			reviews: []*github.PullRequestReview{review("allensun", "APPROVED", "head")},
+			want:    []string{`POST ` + labelsPath + ` ["LGTM"]`},
+		},
+		{
+			name:    "approved by maintainer again",
+			review:  review("allensun", "approved", "head"),
+			reviews: []*github.PullRequestReview{review("allensun", "APPROVED", "head")},
+			labels:  []string{utils.LGTMLabel},
+		},
+		{
+			name:    "maintainer requests changes after approval",
+			review:  review("allensun", "changes_requested", "head"),
+			reviews: []*github.PullRequestReview{review("allensun", "APPROVED", "old"), review("allensun", "CHANGES_REQUESTED", "head")},
+			labels:  []string{utils.LGTMLabel},
+			want:    []string{"DELETE " + lgtmPath, `POST ` + labelsPath + ` ["status/changes-requested"]`},
+		},
+		{
+			name:    "maintainer comments after approval",
+			review:  review("allensun", "commented", "head"),
+			reviews: []*github.PullRequestReview{review("allensun", "APPROVED", "head"), review("allensun", "COMMENTED", "head")},
+			labels:  []string{utils.LGTMLabel},
+		},
+		{
+			name:    "approved by others",
+			review:  review("bob", "approved", "head"),
+			reviews: []*github.PullRequestReview{review("bob", "APPROVED", "head")},
+		},
+		{
+			name:    "changes requested on head",
+			review:  review("bob", "changes_requested", "head"),
+			reviews: []*github.PullRequestReview{review("bob", "CHANGES_REQUESTED", "head")},
+			want:    []string{`POST ` + labelsPath + ` ["status/changes-requested"]`},
+		},
+		{
+			name:    "changes requested already labeled",
+			review:  review("bob", "changes_requested", "head"),
+			reviews: []*github.PullRequestReview{review("bob", "CHANGES_REQUESTED", "head")},
+			labels:  []string{utils.ChangesRequestedLabel},
+		},
+		{
+			name:    "changes requested on previous commit",
+			review:  review("alice", "commented", "head"),
+			reviews: []*github.PullRequestReview{review("bob", "CHANGES_REQUESTED", "old"), review("alice", "COMMENTED", "head")},
+			labels:  []string{utils.ChangesRequestedLabel},
+			want:    []string{"DELETE " + changesRequestedPath},
+		},
+		{
+			name:    "changes addressed by approval",
+			review:  review("bob", "approved", "head"),
+			reviews: []*github.PullRequestReview{review("bob", "CHANGES_REQUESTED", "head"), review("bob", "APPROVED", "head")},
+			labels:  []string{utils.ChangesRequestedLabel},
+			want:    []string{"DELETE " + changesRequestedPath},
+		},
+		{
+			name:    "comment after requesting changes",
+			review:  review("bob", "commented", "head"),
+			reviews: []*github.PullRequestReview{review("bob", "CHANGES_REQUESTED", "head"), review("bob", "COMMENTED", "head")},
+			labels:  []string{utils.ChangesRequestedLabel},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := ghtest.NewServer()
+			defer server.Close()
+			prp, pr := newReviewTest(server, tt.labels, tt.reviews)
+
+			if err := prp.ActToPRReviewSubmitted(pr, tt.review); err != nil {
+				t.Fatalf("ActToPRReviewSubmitted() error = %v", err)
+			}
+			if got := recordedWithBody(server); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ActToPRReviewSubmitted() requests = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestActToPRReviewRequested(t *testing.T) {
+	tests := []struct {
+		name     string
+		reviewer string
+		reviews  []*github.PullRequestReview
+		want     []string
+	}{
+		{
+			name:     "requested again from reviewer requesting changes",
+			reviewer: "bob",
+			reviews:  []*github.PullRequestReview{review("bob", "CHANGES_REQUESTED", "head")},
+			want:     []string{"DELETE " + changesRequestedPath},
+		},
+		{
+			name:     "another reviewer still requests changes",
+			reviewer: "bob",
+			reviews:  []*github.PullRequestReview{review("bob", "CHANGES_REQUESTED", "head"), review("alice", "CHANGES_REQUESTED", "head")},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := ghtest.NewServer()
+			defer server.Close()
+			prp, pr := newReviewTest(server, []string{utils.ChangesRequestedLabel}, tt.reviews)
+
+			if err := prp.ActToPRReviewRequested(pr, tt.reviewer); err != nil {
+				t.Fatalf("ActToPRReviewRequested() error = %v", err)
+			}
+			if got := recordedWithBody(server); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ActToPRReviewRequested() requests = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestProcessReviewDismissed(t *testing.T) {
+	tests := []struct {
+		name    string
+		reviews []*github.PullRequestReview
+		labels  []string
+		want    []string
+	}{
+		{
+			name:    "approval of maintainer dismissed",
+			reviews: []*github.PullRequestReview{review("allensun", "DISMISSED", "head")},
+			labels:  []string{utils.LGTMLabel},
+			want:    []string{"DELETE " + lgtmPath},
+		},
+		{
+			name:    "request of changes dismissed",
+			reviews: []*github.PullRequestReview{review("allensun", "APPROVED", "head"), review("bob", "DISMISSED", "head")},
+			labels:  []string{utils.LGTMLabel, utils.ChangesRequestedLabel},
+			want:    []string{"DELETE " + changesRequestedPath},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := ghtest.NewServer()
+			defer server.Close()
+			prp, pr := newReviewTest(server, tt.labels, tt.reviews)
+
+			data, err := json.Marshal(map[string]interface{}{
+				"action":       "dismissed",
+				"pull_request": pr,
+				"review":       tt.reviews[0],
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if err := prp.ProcessReview(data, nil); err != nil {
+				t.Fatalf("ProcessReview() error = %v", err)
+			}
+			if got := recordedWithBody(server); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ProcessReview() requests = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,58 @@
+// Copyright 2018 The Pouch Robot Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pullRequestProcessor
+
+import (
+	"github.com/pouchcontainer/pouchrobot/gh"
+	"github.com/pouchcontainer/pouchrobot/processor/pullRequestProcessor/open"
+	"github.com/pouchcontainer/pouchrobot/utils"
+
+	"github.com/google/go-github/github"
+)
+
+// SizeSummary is the data to render comment of pull request size.
+type SizeSummary struct {
+	open.Size
+
+	// Author is the GitHub login of the pull request author.
+	Author string
+
+	// Label is the size label of pull request.
+	Label string
+}
+
+// attachSizeComment attaches or updates a comment which breaks down size of
+// pull request by code, tests and docs, if it is enabled.
+func (prp *PullRequestProcessor) attachSizeComment(pr *github.PullRequest, files gh.FileLister) error {
+	if !prp.Size.Comment {
+		return nil
+	}
+
+	changed, err := files.ListFiles(*(pr.Number))
+	if err != nil {
+		return err
+	}
+	size := open.MeasureSize(changed, prp.Size.Ignore)
+
+	body, err := prp.Templates.Render(utils.PRSizeCommentID, SizeSummary{
+		Size:   size,
+		Author: pr.User.GetLogin(),
+		Label:  open.SizeLabel(size.Total(), prp.Size.Thresholds),
+	})
+	if err != nil {
+		return err
+	}
+	return prp.Client.UpsertComment(*(pr.Number), utils.PRSizeCommentID, body)
+}
@@ -16,20 +16,27 @@ package pullRequestProcessor
 
 import (
 	"context"
-	"fmt"
 	"strings"
 
+	"github.com/pouchcontainer/pouchrobot/gh"
 	"github.com/pouchcontainer/pouchrobot/processor/pullRequestProcessor/open"
 	"github.com/pouchcontainer/pouchrobot/utils"
+	"github.com/pouchcontainer/pouchrobot/utils/matcher"
+	"github.com/pouchcontainer/pouchrobot/utils/templates"
 
 	"github.com/google/go-github/github"
 )
 
 // ActToPRSynchronized acts to event that a pr is synchronized.
-func (prp *PullRequestProcessor) ActToPRSynchronized(syncPR *github.PullRequest) error {
+func (prp *PullRequestProcessor) ActToPRSynchronized(syncPR *github.PullRequest, files gh.FileLister) error {
 	prp.removeConflictLabel(syncPR)
-	prp.changeSizeLabel(syncPR)
+	prp.removeChangesRequestedLabel(syncPR)
+	prp.changeSizeLabel(syncPR, files)
+	prp.changeFileLabels(syncPR, files)
+	// commit status of template check is bound to head commit.
+	prp.checkDescription(syncPR)
 	prp.changeSignCommitComment(syncPR)
+	prp.CheckApprovals(syncPR, files, false)
 	return nil
 }
 
@@ -58,52 +65,83 @@ func (prp *PullRequestProcessor) removeConflictLabel(syncPR *github.PullRequest)
 	return nil
 }
 
-func (prp *PullRequestProcessor) changeSizeLabel(pr *github.PullRequest) error {
+func (prp *PullRequestProcessor) changeSizeLabel(pr *github.PullRequest, files gh.FileLister) error {
 	// check if we need to change the PR size label
-	newSizeLabel := open.ParseToGetPRSize(pr)
-
-	if prp.Client.IssueHasLabel(*(pr.Number), newSizeLabel) {
-		// pull request already has newSize label, do nothing
-		return nil
+	changed, err := files.ListFiles(*(pr.Number))
+	if err != nil {
+		return err
 	}
+	newSizeLabel := open.SizeLabel(open.MeasureSize(changed, prp.Size.Ignore).Total(), prp.Size.Thresholds)
+	prp.attachSizeComment(pr, files)
 
-	// pull request has no newSizeLabel, do following things:
-	// remove original size label
-	// add newSizeLabel
-
-	originalLabels, err := prp.Client.GetLabelsInIssue(*(pr.Number))
+	originalLabels, err := prp.Client.GetStrLabelsInIssue(*(pr.Number))
 	if err != nil {
 		return err
 	}
 
+	// remove original size labels except newSizeLabel
+	hasNewSizeLabel := false
 	for _, label := range originalLabels {
-		if strings.HasPrefix(*(label.Name), utils.SizeLabelPrefix) {
-			prp.Client.RemoveLabelForIssue(*(pr.Number), label.GetName())
-			break
+		if label == newSizeLabel {
+			hasNewSizeLabel = true
+			continue
+		}
+		if strings.HasPrefix(label, utils.SizeLabelPrefix) {
+			prp.Client.RemoveLabelForIssue(*(pr.Number), label)
 		}
 	}
 
-	newLabels := []string{newSizeLabel}
-	prp.Client.AddLabelsToIssue(*(pr.Number), newLabels)
-
-	return nil
+	if hasNewSizeLabel {
+		return nil
+	}
+	return prp.Client.AddLabelsToIssue(*(pr.Number), []string{newSizeLabel})
 }
 
-// RemoveConflictComment removes a conflict comment for a pull request
-func (prp *PullRequestProcessor) RemoveConflictComment(ctx context.Context, num int) error {
-	prComments, err := prp.Client.ListComments(num)
+// changeFileLabels makes labels derived from changed files follow the
+// current file set of pull request: labels of files no longer changed are
+// removed, and labels of newly changed files are added.
+func (prp *PullRequestProcessor) changeFileLabels(pr *github.PullRequest, files gh.FileLister) error {
+	managed := matcher.Current().FileLabels()
+	if prp.CodeOwners != nil {
+		managed = append(managed, prp.CodeOwners.AreaLabels()...)
+	}
+	if len(managed) == 0 {
+		return nil
+	}
+
+	// labels derived from title are kept even if they are also derived from files.
+	// without the file list we can not tell which labels are stale.
+	wanted, err := prp.generateLabels(pr, files)
 	if err != nil {
 		return err
 	}
-	for _, comment := range prComments {
-		commentBody := *(comment.Body)
-		subBody := utils.PRConflictSubStr
-		if strings.HasSuffix(commentBody, subBody) {
-			// remove all if there are more than one
-			prp.Client.RemoveComment(*(comment.ID))
+
+	currentLabels, err := prp.Client.GetStrLabelsInIssue(*(pr.Number))
+	if err != nil {
+		return err
+	}
+
+	for _, label := range currentLabels {
+		if utils.SliceContainsElement(managed, label) && !utils.SliceContainsElement(wanted, label) {
+			prp.Client.RemoveLabelForIssue(*(pr.Number), label)
 		}
 	}
-	return nil
+
+	var newLabels []string
+	for _, label := range wanted {
+		if utils.SliceContainsElement(managed, label) && !utils.SliceContainsElement(currentLabels, label) {
+			newLabels = append(newLabels, label)
+		}
+	}
+	if len(newLabels) == 0 {
+		return nil
+	}
+	return prp.Client.AddLabelsToIssue(*(pr.Number), newLabels)
+}
+
+// RemoveConflictComment removes a conflict comment for a pull request
+func (prp *PullRequestProcessor) RemoveConflictComment(ctx context.Context, num int) error {
+	return prp.Client.RemoveMarkedComments(num, utils.PRConflictCommentID)
 }
 
 // changeSignCommitComment changes comments of being signed off.
@@ -121,17 +159,16 @@ func (prp *PullRequestProcessor) changeSignCommitComment(pr *github.PullRequest)
 		}
 	}
 
-	// try to remove sign off commits if there are any.
-	prp.Client.RmCommentsViaStr(*(pr.Number), utils.PRNeedsSignOffStr)
-
+	// try to remove sign off comments if there are any.
 	if !needSignoff {
-		return nil
+		return prp.Client.RemoveMarkedComments(*(pr.Number), utils.PRNeedsSignOffID)
 	}
 
-	body := fmt.Sprintf(utils.PRNeedsSignOff, *(pr.User.Login))
-	newComment := &github.IssueComment{
-		Body: &body,
+	body, err := prp.Templates.Render(utils.PRNeedsSignOffID, templates.Data{
+		Author: *(pr.User.Login),
+	})
+	if err != nil {
+		return err
 	}
-
-	return prp.Client.AddCommentToPR(*(pr.Number), newComment)
+	return prp.Client.UpsertComment(*(pr.Number), utils.PRNeedsSignOffID, body)
 }
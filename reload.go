@@ -0,0 +1,158 @@
+// Copyright 2018 The Pouch Robot Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"os"
+	"os/signal"
+	"reflect"
+	"syscall"
+	"time"
+
+	"github.com/pouchcontainer/pouchrobot/config"
+	"github.com/pouchcontainer/pouchrobot/docgenerator"
+	"github.com/pouchcontainer/pouchrobot/labels"
+
+	"github.com/sirupsen/logrus"
+)
+
+// ConfigPollInterval is the interval to check whether config file is changed.
+const ConfigPollInterval = 10 * time.Second
+
+// WatchConfig reloads config file in path on SIGHUP or when the file is changed.
+func (s *Server) WatchConfig(path string) {
+	logrus.Infof("start to watch config file %s", path)
+
+	hup := make(chan os.Signal, 1)
+	signal.Notify(hup, syscall.SIGHUP)
+
+	modTime := configModTime(path)
+	ticker := time.NewTicker(ConfigPollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-hup:
+			logrus.Infof("reload config file %s on SIGHUP", path)
+		case <-ticker.C:
+			// the file could be replaced rather than modified, like a
+			// ConfigMap mounted in Kubernetes, so modification time is compared.
+			t := configModTime(path)
+			if t.IsZero() || t.Equal(modTime) {
+				continue
+			}
+			logrus.Infof("reload config file %s on change", path)
+		}
+
+		modTime = configModTime(path)
+		if err := s.reloadFile(path); err != nil {
+			logrus.Errorf("keep config loaded before, failed to reload: %v", err)
+		}
+	}
+}
+
+// reloadFile loads config file in path and makes it in effect. Config in
+// effect is kept if the file is invalid.
+func (s *Server) reloadFile(path string) error {
+	cfg, err := config.Load(path)
+	if err != nil {
+		return err
+	}
+	if err := s.Reload(cfg); err != nil {
+		return err
+	}
+	logrus.Infof("effective config is %s", cfg)
+	return nil
+}
+
+// configModTime returns modification time of config file, or zero time if
+// the file could not be accessed.
+func configModTime(path string) time.Time {
+	info, err := os.Stat(path)
+	if err != nil {
+		return time.Time{}
+	}
+	return info.ModTime()
+}
+
+// Reload makes cfg in effect. Everything which could fail is built before
+// any setting is replaced, so that config in effect is kept on failure. The
+// built settings are then published at once while holding the lock, and
+// events picking the processor up afterwards are handled with cfg.
+// Owner, repo, access token, listen address, lifecycle management, store and
+// time zone and jitter of scheduler need a restart to take effect.
+// Changed schedules are picked up by scheduler within a minute.
+func (s *Server) Reload(cfg config.Config) error {
+	labelMatcher, err := newLabelMatcher(cfg.LabelRules)
+	if err != nil {
+		return err
+	}
+	declaredLabels, err := labels.Load(cfg.LabelsConfig.File)
+	if err != nil {
+		return err
+	}
+	p, err := s.newProcessor(cfg)
+	if err != nil {
+		return err
+	}
+	docGenerate := cfg.DocGenerateConfig
+	if err := docgenerator.CheckGenerationHour(docGenerate.GenerationHour); err != nil {
+		return err
+	}
+
+	s.Lock()
+	old := s.config
+	s.docGenerator.Reload(docGenerate.RootDir, docGenerate.SwaggerPath, docGenerate.APIDocPath,
+		docGenerate.GenerationHour, docGenerate.CliDocGeneratorCmd)
+	s.renderer.Reset(cfg.TemplatesConfig.Dir, cfg.TemplatesConfig.Language)
+	s.maintainers.Reload(cfg.MaintainersConfig.File, cfg.MaintainersConfig.Format,
+		time.Duration(cfg.MaintainersConfig.RefreshMinutes)*time.Minute, cfg.MaintainersConfig.Fallback)
+	s.repoConfig.SetDefaults(repoSettings(cfg), labelMatcher)
+	s.fetcher.SetCommitsGap(cfg.FetcherConfig.CommitsGap)
+	s.reporter.SetSchedule(cfg.WeeklyReportConfig.ReportDay, cfg.WeeklyReportConfig.ReportHour)
+	s.processor = p
+	s.config = cfg
+	s.labels = declaredLabels
+	s.Unlock()
+
+	// maintainers file could be switched.
+	s.scheduler.Run(maintainersJob)
+
+	warnRestartNeeded(old, cfg)
+	go s.labelSyncer.Check(declaredLabels)
+	logrus.Infof("succeed in reloading config")
+	return nil
+}
+
+// warnRestartNeeded warns about changed settings which are not reloaded.
+func warnRestartNeeded(old, cfg config.Config) {
+	changed := map[string]bool{
+		"owner":       old.Owner != cfg.Owner,
+		"repo":        old.Repo != cfg.Repo,
+		"accessToken": old.AccessToken != cfg.AccessToken,
+		"httpListen":  old.HTTPListen != cfg.HTTPListen,
+		"lifecycle":   !reflect.DeepEqual(old.LifecycleConfig, cfg.LifecycleConfig),
+		"moreInfo":    !reflect.DeepEqual(old.MoreInfoConfig, cfg.MoreInfoConfig),
+
+		"scheduler.timeZone":      old.SchedulerConfig.TimeZone != cfg.SchedulerConfig.TimeZone,
+		"scheduler.jitterSeconds": old.SchedulerConfig.JitterSeconds != cfg.SchedulerConfig.JitterSeconds,
+		"store.file":              old.StoreConfig.File != cfg.StoreConfig.File,
+	}
+	for name, isChanged := range changed {
+		if isChanged {
+			logrus.Warnf("%s is changed in config, restart robot to make it in effect", name)
+		}
+	}
+}
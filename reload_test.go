@@ -0,0 +1,171 @@
+// Copyright 2018 The Pouch Robot Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"io/ioutil"
+	"net/url"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/pouchcontainer/pouchrobot/config"
+	"github.com/pouchcontainer/pouchrobot/gh/ghtest"
+)
+
+func testConfig() config.Config {
+	cfg := config.Config{Owner: "pouchcontainer", Repo: "pouch", AccessToken: "token"}
+	cfg.TemplatesConfig.Language = "en"
+	cfg.WeeklyReportConfig.ReportDay = "Friday"
+	cfg.DocGenerateConfig.GenerationHour = 6
+	return cfg
+}
+
+// newTestServer returns a server of cfg, which talks to a fake GitHub API.
+func newTestServer(t *testing.T, cfg config.Config) (*Server, func()) {
+	s, err := NewServer(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	api := ghtest.NewServer()
+	s.client.BaseURL, _ = url.Parse(api.URL + "/")
+	return s, api.Close
+}
+
+// assertConfig checks that cfg is the config in effect of server s.
+func assertConfig(t *testing.T, s *Server, cfg config.Config) {
+	t.Helper()
+	if !reflect.DeepEqual(s.config, cfg) {
+		t.Errorf("config = %+v, want %+v", s.config, cfg)
+	}
+	if got := s.renderer.Language(); got != cfg.TemplatesConfig.Language {
+		t.Errorf("language = %s, want %s", got, cfg.TemplatesConfig.Language)
+	}
+	if got := s.reporter.ReportDay; got != cfg.WeeklyReportConfig.ReportDay {
+		t.Errorf("report day = %s, want %s", got, cfg.WeeklyReportConfig.ReportDay)
+	}
+	if got, want := s.docGenerator.GenerationHour, cfg.DocGenerateConfig.GenerationHour; got != want {
+		t.Errorf("generation hour = %d, want %d", got, want)
+	}
+}
+
+func TestReload(t *testing.T) {
+	tests := []struct {
+		name    string
+		modify  func(c *config.Config)
+		wantErr bool
+	}{
+		{
+			name: "valid",
+			modify: func(c *config.Config) {
+				c.TemplatesConfig.Language = "zh"
+				c.WeeklyReportConfig.ReportDay = "Monday"
+				c.DocGenerateConfig.GenerationHour = 3
+			},
+		},
+		{
+			name: "missing labels file",
+			modify: func(c *config.Config) {
+				c.TemplatesConfig.Language = "zh"
+				c.LabelsConfig.File = "testdata/missing.yaml"
+			},
+			wantErr: true,
+		},
+		{
+			name: "missing rules file",
+			modify: func(c *config.Config) {
+				c.WeeklyReportConfig.ReportDay = "Monday"
+				c.RulesConfig.File = "testdata/missing.yaml"
+			},
+			wantErr: true,
+		},
+		{
+			name: "invalid generation hour",
+			modify: func(c *config.Config) {
+				c.TemplatesConfig.Language = "zh"
+				c.DocGenerateConfig.GenerationHour = 24
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			old := testConfig()
+			s, closeAPI := newTestServer(t, old)
+			defer closeAPI()
+			oldProcessor := s.processor
+
+			cfg := testConfig()
+			tt.modify(&cfg)
+			err := s.Reload(cfg)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Reload() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				assertConfig(t, s, old)
+				if s.processor != oldProcessor {
+					t.Errorf("processor is replaced by an invalid config")
+				}
+				return
+			}
+			assertConfig(t, s, cfg)
+			if s.processor == oldProcessor {
+				t.Errorf("processor is not rebuilt")
+			}
+		})
+	}
+}
+
+func TestReloadFile(t *testing.T) {
+	dir, err := ioutil.TempDir("", "pouchrobot")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "config.json")
+
+	old := testConfig()
+	s, closeAPI := newTestServer(t, old)
+	defer closeAPI()
+
+	for _, tt := range []struct {
+		name string
+		data string
+	}{
+		{name: "unparsable", data: `{"owner": "pouchcontainer",`},
+		{name: "invalid", data: `{"owner": "pouchcontainer", "repo": "pouch", "accessToken": "token", "weeklyReport": {"reportDay": "Someday"}}`},
+		{name: "failing reload", data: `{"owner": "pouchcontainer", "repo": "pouch", "accessToken": "token", "labels": {"file": "testdata/missing.yaml"}}`},
+	} {
+		if err := ioutil.WriteFile(path, []byte(tt.data), 0644); err != nil {
+			t.Fatal(err)
+		}
+		if err := s.reloadFile(path); err == nil {
+			t.Errorf("%s: reloadFile() error = nil, want an error", tt.name)
+		}
+		assertConfig(t, s, old)
+	}
+
+	data := `{"owner": "pouchcontainer", "repo": "pouch", "accessToken": "token", "weeklyReport": {"reportDay": "Monday"}}`
+	if err := ioutil.WriteFile(path, []byte(data), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.reloadFile(path); err != nil {
+		t.Fatalf("reloadFile() error = %v", err)
+	}
+	if got := s.reporter.ReportDay; got != "Monday" {
+		t.Errorf("report day = %s, want Monday", got)
+	}
+}
@@ -0,0 +1,212 @@
+// Copyright 2018 The Pouch Robot Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package repoconfig
+
+import (
+	"encoding/json"
+	"sync"
+
+	"github.com/pouchcontainer/pouchrobot/gh"
+	"github.com/pouchcontainer/pouchrobot/utils"
+	"github.com/pouchcontainer/pouchrobot/utils/matcher"
+
+	"github.com/sirupsen/logrus"
+)
+
+// StatusContext is the context of commit status which reports whether per
+// repository config file in the commit is valid.
+const StatusContext = "pouchrobot/config"
+
+// Loader loads per repository config file from default branch of repository,
+// and merges it over settings of daemon. It is refreshed on pushes to default
+// branch. Settings loaded before are kept if file becomes invalid.
+type Loader struct {
+	sync.RWMutex
+
+	client *gh.Client
+
+	// defaults are settings of daemon.
+	defaults Settings
+
+	// defaultMatcher is the label matcher of daemon, which is restored when
+	// per repository config file declares no label rules.
+	defaultMatcher *matcher.Matcher
+
+	// file is the per repository config file loaded last. It is nil if
+	// repository has no config file.
+	file *File
+
+	// current are settings in effect.
+	current Settings
+}
+
+// NewLoader creates a loader with settings of daemon. The current label
+// matcher is taken as label matcher of daemon.
+func NewLoader(client *gh.Client, defaults Settings) *Loader {
+	return &Loader{
+		client:         client,
+		defaults:       defaults,
+		defaultMatcher: matcher.Current(),
+		current:        defaults,
+	}
+}
+
+// Current returns settings in effect.
+func (l *Loader) Current() Settings {
+	l.RLock()
+	defer l.RUnlock()
+	return l.current
+}
+
+// SetDefaults replaces settings and label matcher of daemon, and merges the
+// config file loaded last over them again.
+func (l *Loader) SetDefaults(defaults Settings, defaultMatcher *matcher.Matcher) {
+	l.Lock()
+	l.defaults = defaults
+	l.defaultMatcher = defaultMatcher
+	file := l.file
+	l.Unlock()
+
+	l.apply(file)
+}
+
+// Refresh loads per repository config file from default branch. If sha is not
+// empty, the file is loaded at sha, which is the commit pushed to default
+// branch, and whether it is valid is reported as a commit status on it.
+func (l *Loader) Refresh(sha string) error {
+	data, err := l.client.GetFileContentAt(Path, sha)
+	if err != nil && !gh.IsNotFound(err) {
+		return err
+	}
+
+	var file *File
+	if err == nil {
+		if file, err = Parse(data); err != nil {
+			logrus.Errorf("keep settings loaded before: %v", err)
+			l.report(sha, err)
+			return err
+		}
+	}
+
+	l.apply(file)
+	l.report(sha, nil)
+	return nil
+}
+
+// apply merges file over settings of daemon, and makes them in effect.
+func (l *Loader) apply(file *File) {
+	l.RLock()
+	settings := l.defaults.Merge(file)
+	m := l.defaultMatcher
+	l.RUnlock()
+
+	if file != nil && len(file.LabelRules) != 0 {
+		// rules are validated when parsing file.
+		if fileMatcher, err := matcher.New(file.LabelRules); err == nil {
+			m = fileMatcher
+		}
+	}
+	matcher.SetCurrent(m)
+
+	l.Lock()
+	l.file = file
+	l.current = settings
+	l.Unlock()
+	logrus.Infof("loaded settings of repository: %+v", settings)
+}
+
+// report creates commit status on sha about whether config file is valid.
+func (l *Loader) report(sha string, err error) {
+	if sha == "" {
+		return
+	}
+	if err != nil {
+		l.client.CreateStatus(sha, "failure", StatusContext, err.Error())
+		return
+	}
+	l.client.CreateStatus(sha, "success", StatusContext, Path+" is valid")
+}
+
+// HandleEvent refreshes settings on pushes to default branch, and validates
+// config file changed by pull requests, so that maintainers are told whether
+// it is valid before merging. files lists files changed in pull request,
+// shared with other handlers of the event.
+func (l *Loader) HandleEvent(eventType string, data []byte, files gh.FileLister) error {
+	switch eventType {
+	case "push":
+		var push struct {
+			Ref        string `json:"ref"`
+			After      string `json:"after"`
+			Repository struct {
+				DefaultBranch string `json:"default_branch"`
+			} `json:"repository"`
+		}
+		if err := json.Unmarshal(data, &push); err != nil {
+			return err
+		}
+		if push.Ref != "refs/heads/"+push.Repository.DefaultBranch {
+			return nil
+		}
+		return l.Refresh(push.After)
+	case "pull_request":
+		action, err := utils.ExtractActionType(data)
+		if err != nil {
+			return err
+		}
+		if action != "opened" && action != "synchronize" && action != "reopened" {
+			return nil
+		}
+		pr, err := utils.ExactPR(data)
+		if err != nil {
+			return err
+		}
+		return l.validatePR(pr.GetNumber(), pr.Head.GetSHA(), files)
+	}
+	return nil
+}
+
+// validatePR reports whether config file is valid on head of pull request
+// num if pull request changes it.
+func (l *Loader) validatePR(num int, sha string, files gh.FileLister) error {
+	if num == 0 || sha == "" {
+		return nil
+	}
+	changedFiles, err := files.ListFiles(num)
+	if err != nil {
+		return err
+	}
+	changed := false
+	for _, file := range changedFiles {
+		if file.GetFilename() == Path {
+			changed = true
+			break
+		}
+	}
+	if !changed {
+		return nil
+	}
+
+	data, err := l.client.GetFileContentAt(Path, sha)
+	if err != nil {
+		if gh.IsNotFound(err) {
+			l.report(sha, nil)
+			return nil
+		}
+		return err
+	}
+	_, err = Parse(data)
+	l.report(sha, err)
+	return nil
+}
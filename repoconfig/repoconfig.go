@@ -0,0 +1,139 @@
+// Copyright 2018 The Pouch Robot Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package repoconfig
+
+import (
+	"fmt"
+
+	"github.com/pouchcontainer/pouchrobot/config"
+	"github.com/pouchcontainer/pouchrobot/utils"
+	"github.com/pouchcontainer/pouchrobot/utils/matcher"
+
+	"gopkg.in/yaml.v2"
+)
+
+// Path is the path of per repository config file in repository.
+const Path = ".github/pouchrobot.yml"
+
+// File is the per repository config file. Fields which are not set keep
+// values of daemon.
+type File struct {
+	// CommitsGap is the number of commits a pull request could be behind
+	// master before robot requests a rebase.
+	CommitsGap *int `yaml:"commitsGap"`
+
+	// TitleMinLength is the minimum length of issue and pull request titles.
+	TitleMinLength *int `yaml:"titleMinLength"`
+
+	// DescriptionMinLength is the minimum length of issue and pull request descriptions.
+	DescriptionMinLength *int `yaml:"descriptionMinLength"`
+
+	// LabelRules replace label rules of daemon if it is not empty.
+	LabelRules []config.LabelRule `yaml:"labelRules"`
+
+	// WeeklyReport overrides schedule of weekly report.
+	WeeklyReport *WeeklyReport `yaml:"weeklyReport"`
+}
+
+// WeeklyReport is the schedule of weekly report.
+type WeeklyReport struct {
+	// ReportDay is the weekday to report, like "Friday".
+	ReportDay string `yaml:"reportDay"`
+
+	// ReportHour is the hour to report on ReportDay.
+	ReportHour *int `yaml:"reportHour"`
+}
+
+// Settings are settings in effect, which are settings of daemon merged
+// with per repository config file. Zero values of lengths and gap mean
+// built-in defaults of their consumers.
+type Settings struct {
+	CommitsGap           int
+	TitleMinLength       int
+	DescriptionMinLength int
+	LabelRules           []config.LabelRule
+	ReportDay            string
+	ReportHour           int
+}
+
+// Parse parses and validates per repository config file in data. Unknown
+// fields are rejected, since they are likely typos.
+func Parse(data []byte) (*File, error) {
+	file := &File{}
+	if err := yaml.UnmarshalStrict(data, file); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %v", Path, err)
+	}
+	if err := file.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid %s: %v", Path, err)
+	}
+	return file, nil
+}
+
+// Validate checks values in file.
+func (f *File) Validate() error {
+	for name, value := range map[string]*int{
+		"commitsGap":           f.CommitsGap,
+		"titleMinLength":       f.TitleMinLength,
+		"descriptionMinLength": f.DescriptionMinLength,
+	} {
+		if value != nil && *value <= 0 {
+			return fmt.Errorf("%s must be positive, got %d", name, *value)
+		}
+	}
+
+	if len(f.LabelRules) != 0 {
+		if _, err := matcher.New(f.LabelRules); err != nil {
+			return err
+		}
+	}
+
+	if report := f.WeeklyReport; report != nil {
+		if report.ReportDay != "" && !utils.IsWeekday(report.ReportDay) {
+			return fmt.Errorf("weeklyReport.reportDay must be a weekday like Friday, got %q", report.ReportDay)
+		}
+		if report.ReportHour != nil && (*report.ReportHour < 0 || *report.ReportHour > 23) {
+			return fmt.Errorf("weeklyReport.reportHour must be in 0-23, got %d", *report.ReportHour)
+		}
+	}
+	return nil
+}
+
+// Merge returns settings overridden by fields set in file.
+func (s Settings) Merge(f *File) Settings {
+	if f == nil {
+		return s
+	}
+	if f.CommitsGap != nil {
+		s.CommitsGap = *f.CommitsGap
+	}
+	if f.TitleMinLength != nil {
+		s.TitleMinLength = *f.TitleMinLength
+	}
+	if f.DescriptionMinLength != nil {
+		s.DescriptionMinLength = *f.DescriptionMinLength
+	}
+	if len(f.LabelRules) != 0 {
+		s.LabelRules = f.LabelRules
+	}
+	if report := f.WeeklyReport; report != nil {
+		if report.ReportDay != "" {
+			s.ReportDay = report.ReportDay
+		}
+		if report.ReportHour != nil {
+			s.ReportHour = *report.ReportHour
+		}
+	}
+	return s
+}
@@ -0,0 +1,146 @@
+// Copyright 2018 The Pouch Robot Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package repoconfig
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/pouchcontainer/pouchrobot/config"
+	"github.com/pouchcontainer/pouchrobot/gh/ghtest"
+
+	"github.com/google/go-github/github"
+)
+
+func TestParseAndMerge(t *testing.T) {
+	data := `
+commitsGap: 30
+titleMinLength: 10
+labelRules:
+- label: areas/network
+  paths: ["network/**"]
+weeklyReport:
+  reportHour: 9
+`
+	file, err := Parse([]byte(data))
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+
+	defaults := Settings{CommitsGap: 20, DescriptionMinLength: 100, ReportDay: "Friday", ReportHour: 17}
+	want := Settings{
+		CommitsGap:           30,
+		TitleMinLength:       10,
+		DescriptionMinLength: 100,
+		LabelRules:           []config.LabelRule{{Label: "areas/network", Paths: []string{"network/**"}}},
+		ReportDay:            "Friday",
+		ReportHour:           9,
+	}
+	if got := defaults.Merge(file); !reflect.DeepEqual(got, want) {
+		t.Errorf("Merge() = %+v, want %+v", got, want)
+	}
+	if got := defaults.Merge(nil); !reflect.DeepEqual(got, defaults) {
+		t.Errorf("Merge(nil) = %+v, want %+v", got, defaults)
+	}
+}
+
+func TestParseInvalid(t *testing.T) {
+	tests := []struct {
+		name string
+		data string
+		err  string
+	}{
+		{name: "unknown field", data: "commitGap: 30", err: "commitGap"},
+		{name: "not positive", data: "titleMinLength: 0", err: "titleMinLength must be positive"},
+		{name: "weekday", data: "weeklyReport:\n  reportDay: friday", err: "reportDay"},
+		{name: "hour", data: "weeklyReport:\n  reportHour: 24", err: "reportHour"},
+		{name: "label rule", data: "labelRules:\n- label: a\n  regexes: ['(']", err: "a"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Parse([]byte(tt.data))
+			if err == nil || !strings.Contains(err.Error(), tt.err) {
+				t.Errorf("Parse() error = %v, want %q", err, tt.err)
+			}
+		})
+	}
+}
+
+func TestLoaderSetDefaults(t *testing.T) {
+	file, err := Parse([]byte("commitsGap: 30"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	l := NewLoader(nil, Settings{CommitsGap: 20, ReportDay: "Friday", ReportHour: 17})
+	l.apply(file)
+
+	l.SetDefaults(Settings{CommitsGap: 10, ReportDay: "Monday", ReportHour: 9}, l.defaultMatcher)
+	want := Settings{CommitsGap: 30, ReportDay: "Monday", ReportHour: 9}
+	if got := l.Current(); !reflect.DeepEqual(got, want) {
+		t.Errorf("Current() after SetDefaults() = %+v, want %+v", got, want)
+	}
+}
+
+func TestLoaderRefresh(t *testing.T) {
+	const sha = "0123abc"
+	tests := []struct {
+		name      string
+		atSHA     string
+		sha       string
+		wantGap   int
+		wantState string
+	}{
+		{name: "default branch", wantGap: 30},
+		{name: "pushed commit", atSHA: "commitsGap: 40", sha: sha, wantGap: 40, wantState: "success"},
+		{name: "invalid pushed commit", atSHA: "commitGap: 40", sha: sha, wantGap: 20, wantState: "failure"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := ghtest.NewServer()
+			defer server.Close()
+			// default branch may have moved on since sha was pushed.
+			server.Files[Path] = "commitsGap: 30"
+			if tt.atSHA != "" {
+				server.FilesAt[sha] = map[string]string{Path: tt.atSHA}
+			}
+
+			l := NewLoader(server.Client("pouchcontainer", "pouch"), Settings{CommitsGap: 20})
+			l.Refresh(tt.sha)
+			if got := l.Current().CommitsGap; got != tt.wantGap {
+				t.Errorf("CommitsGap after Refresh(%q) = %d, want %d", tt.sha, got, tt.wantGap)
+			}
+
+			if tt.wantState == "" {
+				if len(server.Requests) != 0 {
+					t.Errorf("Refresh() requests = %q, want none", server.Recorded())
+				}
+				return
+			}
+			if len(server.Requests) != 1 || server.Requests[0].Path != "/repos/pouchcontainer/pouch/statuses/"+sha {
+				t.Fatalf("Refresh() requests = %q, want a status on %s", server.Recorded(), sha)
+			}
+			var status github.RepoStatus
+			if err := json.Unmarshal(server.Requests[0].Body, &status); err != nil {
+				t.Fatal(err)
+			}
+			if status.GetState() != tt.wantState {
+				t.Errorf("status = %s, want %s", status.GetState(), tt.wantState)
+			}
+		})
+	}
+}
@@ -17,10 +17,13 @@ package reporter
 import (
 	"fmt"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/google/go-github/github"
 	"github.com/pouchcontainer/pouchrobot/gh"
+	"github.com/pouchcontainer/pouchrobot/repoconfig"
+	"github.com/pouchcontainer/pouchrobot/store"
 	"github.com/pouchcontainer/pouchrobot/utils"
 
 	"github.com/sirupsen/logrus"
@@ -32,6 +35,9 @@ import (
 type Reporter struct {
 	client *gh.Client
 
+	// scheduleLock protects ReportDay and ReportHour, which could be reloaded.
+	scheduleLock sync.RWMutex
+
 	// ReportDay representing which is the weekly report generation day.
 	ReportDay string
 
@@ -43,9 +49,34 @@ type Reporter struct {
 
 	// Repo is the repository name.
 	repo string
+
+	// RepoConfig provides schedule of weekly report from per repository
+	// config file. ReportDay and ReportHour are used if it is nil.
+	RepoConfig *repoconfig.Loader
+
+	// Store keeps repo data of the last weekly report and review comments
+	// recorded from webhook events across restarts.
+	Store store.Store
 }
 
-var statsLastWeek = &StatsLastWeek{}
+const (
+	// Bucket is the bucket of store keeping states of reporter.
+	Bucket = "reporter"
+
+	// ReviewCommentsBucket is the bucket of store keeping review comments
+	// recorded from webhook events, keyed by comment ID.
+	ReviewCommentsBucket = "review-comments"
+
+	// statsLastWeekKey is the key of repo data of the last weekly report.
+	statsLastWeekKey = "stats-last-week"
+)
+
+// legacyFirstCommitCommentSuffix is the suffix of first contribution comments
+// which were posted before comments were tagged with hidden markers.
+var legacyFirstCommitCommentSuffix = `👏  We really appreciate it.
+Just remind that you have read the contribution guide: https://github.com/%s/%s/blob/master/CONTRIBUTING.md
+If you didn't, you should do that first. If done, welcome again and please enjoy hacking! 🍻
+`
 
 // New initializes a brand new reporter.
 func New(client *gh.Client, day string, hour int) *Reporter {
@@ -55,35 +86,67 @@ func New(client *gh.Client, day string, hour int) *Reporter {
 		repo:       client.Repo(),
 		ReportDay:  day,
 		ReportHour: hour,
+		Store:      store.NewMemory(),
 	}
 }
 
-// Run starts to work on reporting things for repo.
-func (r *Reporter) Run() {
-	logrus.Infof("start to run reporter")
+// SetSchedule replaces weekday and hour to report of daemon.
+func (r *Reporter) SetSchedule(day string, hour int) {
+	r.scheduleLock.Lock()
+	defer r.scheduleLock.Unlock()
+	r.ReportDay = day
+	r.ReportHour = hour
+}
 
-	// initialize fork, star and watch informations
-	go r.initRepoInfo(statsLastWeek)
+// Init gets repository status at the start time of robot, which the first
+// weekly report is compared with. Repo data of the last weekly report is
+// kept if it is in store, so that restarts do not reset changes in report.
+func (r *Reporter) Init() {
+	logrus.Infof("start to initialize reporter")
+
+	var lastWeek StatsLastWeek
+	if found, err := r.Store.Get(Bucket, statsLastWeekKey, &lastWeek); err != nil {
+		logrus.Errorf("failed to get repo data of last weekly report: %v", err)
+	} else if found {
+		logrus.Infof("load repo data of last weekly report: %+v", lastWeek)
+		return
+	}
 
-	// Wait time goes to Friday.
-	for {
-		if time.Now().Weekday().String() == r.ReportDay {
-			hour, _, _ := time.Now().Clock()
-			logrus.Infof("now time is %s:%d", "Friday", hour)
-			if hour == r.ReportHour {
-				break
-			}
-		}
-		time.Sleep(30 * time.Minute)
+	if err := r.initRepoInfo(&lastWeek); err != nil {
+		logrus.Errorf("failed to get repo data at start: %v", err)
+		return
+	}
+	if err := r.Store.Put(Bucket, statsLastWeekKey, lastWeek); err != nil {
+		logrus.Errorf("failed to save repo data at start: %v", err)
+	}
+}
+
+// Schedule returns the cron expression of weekly report in effect, which
+// could be changed by per repository config file or reloaded config at any
+// time. It is empty if report day is not set.
+func (r *Reporter) Schedule() string {
+	reportDay, reportHour := r.schedule()
+	if reportDay == "" {
+		return ""
 	}
+	return fmt.Sprintf("0 %d * * %s", reportHour, reportDay)
+}
 
-	for {
-		// only fixed day, code will enter this for loop block.
-		go r.weeklyReport()
+// Report posts weekly report as an issue. It is run by scheduler.
+func (r *Reporter) Report() error {
+	return r.weeklyReport()
+}
 
-		// report one issue every week.
-		time.Sleep(7 * 24 * time.Hour)
+// schedule returns weekday and hour to report in effect.
+func (r *Reporter) schedule() (string, int) {
+	if r.RepoConfig != nil {
+		settings := r.RepoConfig.Current()
+		return settings.ReportDay, settings.ReportHour
 	}
+
+	r.scheduleLock.RLock()
+	defer r.scheduleLock.RUnlock()
+	return r.ReportDay, r.ReportHour
 }
 
 func (r *Reporter) weeklyReport() error {
@@ -94,18 +157,25 @@ func (r *Reporter) weeklyReport() error {
 		return err
 	}
 
+	if _, err := r.Store.Get(Bucket, statsLastWeekKey, &wr.LastWeek); err != nil {
+		logrus.Errorf("failed to get repo data of last weekly report: %v", err)
+	}
+
 	// start to post an issue which represents the weekly report, like:
 	// https://github.com/alibaba/pouch/issues/2067
 	issueTitle := fmt.Sprintf("WeeklyReport of %s from %s to %s", wr.repo, wr.StartDate, wr.EndDate)
 	issueBody := wr.String()
+	if err := r.client.CreateIssue(issueTitle, issueBody); err != nil {
+		return err
+	}
 
-	// after constructing weekly report, use this week data to replace stats last week
-	statsLastWeek.Contributors = wr.Contributors
-	statsLastWeek.Star = wr.Star
-	statsLastWeek.Fork = wr.Fork
-	statsLastWeek.Watch = wr.Watch
-
-	return r.client.CreateIssue(issueTitle, issueBody)
+	// after posting weekly report, use this week data to replace stats last week
+	return r.Store.Put(Bucket, statsLastWeekKey, StatsLastWeek{
+		Contributors: wr.Contributors,
+		Star:         wr.Star,
+		Fork:         wr.Fork,
+		Watch:        wr.Watch,
+	})
 }
 
 func (r *Reporter) constructWeekReport() (WeekReport, error) {
@@ -177,7 +247,7 @@ func (r *Reporter) setContributorAndPRSummary(wr *WeekReport, issueSearchResult
 		}
 		// determine whether this is a new contributor via pull request comments.
 		for _, comment := range comments {
-			if comment.Body != nil && strings.HasSuffix(*comment.Body, utils.FirstCommitCommentSubStr) {
+			if isFirstCommitComment(comment) {
 				wr.NewContributors = append(wr.NewContributors, *pr.User.Login)
 				break
 			}
@@ -224,14 +294,27 @@ func (r *Reporter) setContributorAndPRSummary(wr *WeekReport, issueSearchResult
 	wr.NewContributors = utils.UniqueElementSlice(wr.NewContributors)
 }
 
+// isFirstCommitComment returns true if comment is the one robot posted to
+// thank a first time contributor.
+func isFirstCommitComment(comment *github.IssueComment) bool {
+	if comment.Body == nil {
+		return false
+	}
+	if strings.Contains(*comment.Body, gh.CommentMarker(utils.FirstCommitCommentID)) {
+		return true
+	}
+	// comments posted before markers were introduced.
+	return strings.HasSuffix(*comment.Body, legacyFirstCommitCommentSuffix)
+}
+
 // initRepoInfo gets repo's status at the start time of robot.
 // This will leads to inaccuracy of first week.
 // But week after first one will be correct.
-func (r *Reporter) initRepoInfo(lastweek *StatsLastWeek) {
+func (r *Reporter) initRepoInfo(lastweek *StatsLastWeek) error {
 	// get repository details
 	repo, err := r.client.GetRepository()
 	if err != nil {
-		return
+		return err
 	}
 
 	// list contributors of repository
@@ -247,4 +330,5 @@ func (r *Reporter) initRepoInfo(lastweek *StatsLastWeek) {
 	lastweek.Watch = *(repo.SubscribersCount)
 	lastweek.Star = *(repo.StargazersCount)
 	lastweek.Fork = *(repo.ForksCount)
+	return nil
 }
@@ -2,8 +2,12 @@ package reporter
 
 import (
 	"fmt"
+	"strconv"
+	"strings"
 	"time"
 
+	"github.com/pouchcontainer/pouchrobot/store"
+
 	"github.com/google/go-github/github"
 	"github.com/sirupsen/logrus"
 )
@@ -74,6 +78,69 @@ func (r *Reporter) CalculateReviews(wr *WeekReport) {
 
 	// set PRReviewsByUser in WeekReport
 	wr.PRReviewsByUser = prReviewsByUser
+	wr.ReviewCommentsByUser = r.countReviewComments(time.Now().Add(-7 * 24 * time.Hour))
+
+	logrus.Infof("succeed in calculating pull request reviews, see: %v, review comments: %v", prReviewsByUser, wr.ReviewCommentsByUser)
+}
+
+// reviewComment is a review comment recorded from webhook events.
+type reviewComment struct {
+	User      string    `json:"user"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+// RecordReviewComment records a review comment on a pull request, which is
+// counted in code review statistics of the next weekly report. Replies from
+// pull request author are not reviews, and are ignored.
+func (r *Reporter) RecordReviewComment(comment *github.PullRequestComment, author string) {
+	if comment == nil || comment.ID == nil || comment.User == nil {
+		return
+	}
+	user := comment.User.GetLogin()
+	if user == "" || strings.EqualFold(user, author) || comment.User.GetType() == "Bot" {
+		return
+	}
 
-	logrus.Info("succeed in calculating pull request reviews, see: %v", prReviewsByUser)
+	if err := r.Store.Put(ReviewCommentsBucket, strconv.Itoa(*(comment.ID)), reviewComment{
+		User:      user,
+		CreatedAt: comment.GetCreatedAt(),
+	}); err != nil {
+		logrus.Errorf("failed to record review comment %d: %v", *(comment.ID), err)
+	}
+}
+
+// countReviewComments counts recorded review comments by user since time since.
+// Comments before since are dropped, since they are never counted again.
+func (r *Reporter) countReviewComments(since time.Time) map[string]int {
+	result := map[string]int{}
+	ids, err := r.Store.Keys(ReviewCommentsBucket)
+	if err != nil {
+		logrus.Errorf("failed to list recorded review comments: %v", err)
+		return result
+	}
+
+	if err := r.Store.Batch(func(tx store.Store) error {
+		for _, id := range ids {
+			var comment reviewComment
+			found, err := tx.Get(ReviewCommentsBucket, id, &comment)
+			if err != nil {
+				logrus.Errorf("failed to get recorded review comment %s: %v", id, err)
+				continue
+			}
+			if !found {
+				continue
+			}
+			if comment.CreatedAt.Before(since) {
+				if err := tx.Delete(ReviewCommentsBucket, id); err != nil {
+					logrus.Errorf("failed to delete review comment %s: %v", id, err)
+				}
+				continue
+			}
+			result[comment.User]++
+		}
+		return nil
+	}); err != nil {
+		logrus.Errorf("failed to drop review comments before %s: %v", since, err)
+	}
+	return result
 }
@@ -60,14 +60,20 @@ type WeekReport struct {
 	// PRReviewsByUser defines that all pull request reviews submitted between time StartDate and EndDate.
 	// PRReviewsByUser has a type map, the key is User, Value is the number of pull reuqest reviews of single User.
 	PRReviewsByUser map[string]int
+
+	// ReviewCommentsByUser defines the number of review comments of each user between time StartDate and EndDate.
+	ReviewCommentsByUser map[string]int
+
+	// LastWeek is repo data of the last weekly report, which changes are compared with.
+	LastWeek StatsLastWeek
 }
 
 // StatsLastWeek collects repo data from last week.
 type StatsLastWeek struct {
-	Watch        int
-	Star         int
-	Fork         int
-	Contributors int
+	Watch        int `json:"watch"`
+	Star         int `json:"star"`
+	Fork         int `json:"fork"`
+	Contributors int `json:"contributors"`
 }
 
 // SimplePR represents
@@ -115,10 +121,10 @@ func (wr *WeekReport) getRepoUpdateContent() string {
 |:-----:|:----:|:----:|:------------:|:----------:|:-------------:|
 `
 	repoUpdate += fmt.Sprintf("|%d (↑%d)|%d (↑%d)|%d (↑%d)|%d (↑%d)|%d|%d|\n\n",
-		wr.Watch, wr.Watch-statsLastWeek.Watch,
-		wr.Star, wr.Star-statsLastWeek.Star,
-		wr.Fork, wr.Fork-statsLastWeek.Fork,
-		wr.Contributors, wr.Contributors-statsLastWeek.Contributors,
+		wr.Watch, wr.Watch-wr.LastWeek.Watch,
+		wr.Star, wr.Star-wr.LastWeek.Star,
+		wr.Fork, wr.Fork-wr.LastWeek.Fork,
+		wr.Contributors, wr.Contributors-wr.LastWeek.Contributors,
 		wr.NumOfNewIssues, wr.NumOfClosedIssues)
 
 	wholeContent := header + foreword + repoUpdate
@@ -171,32 +177,37 @@ func (wr *WeekReport) getPRReviewContent() string {
 
 	foreword := "This project encourages everyone to participant in code review, in order to improve software quality. Every week @pouchrobot would automatically help to count pull request reviews of single github user as the following. So, try to help review code in this project.\n\n"
 
-	tableHeader := `| Contributor ID | Pull Request Reviews |
-|:--------: | :--------:|
+	tableHeader := `| Contributor ID | Pull Request Reviews | Review Comments |
+|:--------: | :--------:| :--------:|
 `
 
 	tableContent := ""
 
-	// sort the users
-	length := len(wr.PRReviewsByUser)
-	users := make([]string, 0, length)
-	reviewNums := make([]int, 0, length)
-	for user, num := range wr.PRReviewsByUser {
+	// sort the users, users who only left review comments are listed as well.
+	users := make([]string, 0, len(wr.PRReviewsByUser))
+	for user := range wr.PRReviewsByUser {
 		users = append(users, user)
-		reviewNums = append(reviewNums, num)
 	}
+	for user := range wr.ReviewCommentsByUser {
+		if _, ok := wr.PRReviewsByUser[user]; !ok {
+			users = append(users, user)
+		}
+	}
+	length := len(users)
 	for i := 0; i < length-1; i++ {
 		for j := i + 1; j < length; j++ {
-			if reviewNums[i] < reviewNums[j] {
-				reviewNums[i], reviewNums[j] = reviewNums[j], reviewNums[i]
+			if wr.PRReviewsByUser[users[i]] < wr.PRReviewsByUser[users[j]] ||
+				(wr.PRReviewsByUser[users[i]] == wr.PRReviewsByUser[users[j]] && wr.ReviewCommentsByUser[users[i]] < wr.ReviewCommentsByUser[users[j]]) ||
+				// users with the same numbers are sorted by login, so that report is stable.
+				(wr.PRReviewsByUser[users[i]] == wr.PRReviewsByUser[users[j]] && wr.ReviewCommentsByUser[users[i]] == wr.ReviewCommentsByUser[users[j]] && users[i] > users[j]) {
 				users[i], users[j] = users[j], users[i]
 			}
 		}
 	}
 
 	// after sorting, construct table content via sorted data
-	for i := 0; i < length; i++ {
-		tableRow := fmt.Sprintf("|@%s|%d|\n", users[i], reviewNums[i])
+	for _, user := range users {
+		tableRow := fmt.Sprintf("|@%s|%d|%d|\n", user, wr.PRReviewsByUser[user], wr.ReviewCommentsByUser[user])
 		tableContent += tableRow
 	}
 
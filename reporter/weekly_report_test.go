@@ -0,0 +1,69 @@
+// Copyright 2018 The Pouch Robot Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package reporter
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestGetPRReviewContent(t *testing.T) {
+	tests := []struct {
+		name     string
+		reviews  map[string]int
+		comments map[string]int
+		want     []string
+	}{
+		{
+			name: "nobody reviews",
+		},
+		{
+			name:    "reviews only",
+			reviews: map[string]int{"alice": 1, "bob": 3},
+			want:    []string{"|@bob|3|0|", "|@alice|1|0|"},
+		},
+		{
+			name:     "review comments break ties",
+			reviews:  map[string]int{"alice": 2, "bob": 2},
+			comments: map[string]int{"alice": 1, "bob": 5},
+			want:     []string{"|@bob|2|5|", "|@alice|2|1|"},
+		},
+		{
+			name:     "users only leaving review comments",
+			reviews:  map[string]int{"alice": 1},
+			comments: map[string]int{"carol": 4, "dave": 4},
+			want:     []string{"|@alice|1|0|", "|@carol|0|4|", "|@dave|0|4|"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			wr := &WeekReport{PRReviewsByUser: tt.reviews, ReviewCommentsByUser: tt.comments}
+			content := wr.getPRReviewContent()
+
+			if !strings.Contains(content, "| Contributor ID | Pull Request Reviews | Review Comments |") {
+				t.Errorf("getPRReviewContent() misses review comments column:\n%s", content)
+			}
+			var got []string
+			for _, line := range strings.Split(content, "\n") {
+				if strings.HasPrefix(line, "|@") {
+					got = append(got, line)
+				}
+			}
+			if strings.Join(got, "\n") != strings.Join(tt.want, "\n") {
+				t.Errorf("getPRReviewContent() rows = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
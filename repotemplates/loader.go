@@ -0,0 +1,164 @@
+// Copyright 2018 The Pouch Robot Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package repotemplates
+
+import (
+	"path"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pouchcontainer/pouchrobot/gh"
+
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	// IssueTemplateFile is the path of the single issue template.
+	IssueTemplateFile = ".github/ISSUE_TEMPLATE.md"
+
+	// IssueTemplateDir is the directory of multiple issue templates and forms.
+	IssueTemplateDir = ".github/ISSUE_TEMPLATE"
+)
+
+// PullRequestTemplateFiles are paths GitHub looks up pull request template in order.
+var PullRequestTemplateFiles = []string{
+	".github/PULL_REQUEST_TEMPLATE.md",
+	"PULL_REQUEST_TEMPLATE.md",
+	"docs/PULL_REQUEST_TEMPLATE.md",
+}
+
+// CacheDuration is how long loaded templates are cached.
+const CacheDuration = 30 * time.Minute
+
+// DefaultOptionalSections are titles of sections which are optional by default.
+var DefaultOptionalSections = []string{
+	"Anything else we need to know",
+	"Additional context",
+	"Screenshots",
+	"Special notes for reviews",
+}
+
+// Loader loads issue templates from the default branch of repository and caches them.
+type Loader struct {
+	sync.Mutex
+
+	client   *gh.Client
+	optional []string
+
+	issueTemplates []*Template
+	loadedAt       time.Time
+
+	pullRequestTemplate *Template
+	prLoadedAt          time.Time
+}
+
+// NewLoader initializes a brand new templates loader. Sections whose titles
+// contain any of optional are not required, DefaultOptionalSections are
+// used if optional is empty.
+func NewLoader(client *gh.Client, optional []string) *Loader {
+	if len(optional) == 0 {
+		optional = DefaultOptionalSections
+	}
+	return &Loader{
+		client:   client,
+		optional: optional,
+	}
+}
+
+// IssueTemplates returns issue templates of repository. The single issue
+// template goes first if there is one.
+func (l *Loader) IssueTemplates() []*Template {
+	l.Lock()
+	defer l.Unlock()
+
+	if !l.loadedAt.IsZero() && time.Since(l.loadedAt) < CacheDuration {
+		return l.issueTemplates
+	}
+
+	var templates []*Template
+	if data, err := l.client.GetFileContent(IssueTemplateFile); err == nil {
+		templates = append(templates, ParseMarkdown(IssueTemplateFile, data))
+	} else if !gh.IsNotFound(err) {
+		return l.issueTemplates
+	}
+
+	files, err := l.client.ListDirectory(IssueTemplateDir)
+	if err != nil && !gh.IsNotFound(err) {
+		return l.issueTemplates
+	}
+	for _, file := range files {
+		if t := l.load(file); t != nil {
+			templates = append(templates, t)
+		}
+	}
+
+	for _, t := range templates {
+		t.SetOptional(l.optional)
+	}
+	l.issueTemplates, l.loadedAt = templates, time.Now()
+	return templates
+}
+
+// PullRequestTemplate returns pull request template of repository.
+// It returns nil if there is none.
+func (l *Loader) PullRequestTemplate() *Template {
+	l.Lock()
+	defer l.Unlock()
+
+	if !l.prLoadedAt.IsZero() && time.Since(l.prLoadedAt) < CacheDuration {
+		return l.pullRequestTemplate
+	}
+
+	var tmpl *Template
+	for _, file := range PullRequestTemplateFiles {
+		data, err := l.client.GetFileContent(file)
+		if err != nil {
+			if gh.IsNotFound(err) {
+				continue
+			}
+			return l.pullRequestTemplate
+		}
+		tmpl = ParseMarkdown(file, data)
+		tmpl.SetOptional(l.optional)
+		break
+	}
+	l.pullRequestTemplate, l.prLoadedAt = tmpl, time.Now()
+	return tmpl
+}
+
+// load loads a template file in issue template directory.
+func (l *Loader) load(file string) *Template {
+	ext := strings.ToLower(path.Ext(file))
+	// config.yml configures the template chooser, which is not a template.
+	if ext != ".md" && ext != ".yml" && ext != ".yaml" || strings.HasPrefix(path.Base(file), "config.") {
+		return nil
+	}
+
+	data, err := l.client.GetFileContent(file)
+	if err != nil {
+		return nil
+	}
+	if ext == ".md" {
+		return ParseMarkdown(file, data)
+	}
+
+	t, err := ParseForm(file, data)
+	if err != nil {
+		logrus.Warnf("%v", err)
+		return nil
+	}
+	return t
+}
@@ -0,0 +1,156 @@
+// Copyright 2018 The Pouch Robot Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package repotemplates
+
+import (
+	"io/ioutil"
+	"net/http"
+	"reflect"
+	"testing"
+
+	"github.com/pouchcontainer/pouchrobot/gh/ghtest"
+)
+
+func TestPullRequestTemplate(t *testing.T) {
+	tests := []struct {
+		name     string
+		files    map[string]string
+		errors   map[string]int
+		wantName string
+	}{
+		{
+			name:     "github directory",
+			files:    map[string]string{".github/PULL_REQUEST_TEMPLATE.md": "### What", "docs/PULL_REQUEST_TEMPLATE.md": "### Why"},
+			wantName: "PULL_REQUEST_TEMPLATE.md",
+		},
+		{
+			name:     "docs directory",
+			files:    map[string]string{"docs/PULL_REQUEST_TEMPLATE.md": "### Why"},
+			wantName: "PULL_REQUEST_TEMPLATE.md",
+		},
+		{
+			name: "no template",
+		},
+		{
+			name:   "failure",
+			files:  map[string]string{"docs/PULL_REQUEST_TEMPLATE.md": "### Why"},
+			errors: map[string]int{"/repos/pouchcontainer/pouch/contents/PULL_REQUEST_TEMPLATE.md": http.StatusInternalServerError},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := ghtest.NewServer()
+			defer server.Close()
+			for path, content := range tt.files {
+				server.Files[path] = content
+			}
+			for path, status := range tt.errors {
+				server.Errors[path] = status
+			}
+
+			tmpl := NewLoader(server.Client("pouchcontainer", "pouch"), nil).PullRequestTemplate()
+			if tt.wantName == "" {
+				if tmpl != nil {
+					t.Errorf("PullRequestTemplate() = %+v, want nil", tmpl)
+				}
+				return
+			}
+			if tmpl == nil || tmpl.Name != tt.wantName || len(tmpl.Sections) != 1 {
+				t.Fatalf("PullRequestTemplate() = %+v, want template %s with a section", tmpl, tt.wantName)
+			}
+			wantTitle := "What"
+			if _, ok := tt.files[".github/PULL_REQUEST_TEMPLATE.md"]; !ok {
+				wantTitle = "Why"
+			}
+			if tmpl.Sections[0].Title != wantTitle {
+				t.Errorf("section of PullRequestTemplate() = %q, want %q from the first template found", tmpl.Sections[0].Title, wantTitle)
+			}
+		})
+	}
+}
+
+func TestPullRequestTemplateKeptOnFailure(t *testing.T) {
+	server := ghtest.NewServer()
+	defer server.Close()
+	server.Files[".github/PULL_REQUEST_TEMPLATE.md"] = "### What"
+
+	loader := NewLoader(server.Client("pouchcontainer", "pouch"), nil)
+	if loader.PullRequestTemplate() == nil {
+		t.Fatal("PullRequestTemplate() = nil, want template")
+	}
+
+	// expire cache, and fail the next load.
+	loader.prLoadedAt = loader.prLoadedAt.Add(-2 * CacheDuration)
+	server.Errors["/repos/pouchcontainer/pouch/contents/.github/PULL_REQUEST_TEMPLATE.md"] = http.StatusInternalServerError
+	if loader.PullRequestTemplate() == nil {
+		t.Errorf("PullRequestTemplate() = nil, want template loaded before kept")
+	}
+}
+
+func TestRepoPullRequestTemplate(t *testing.T) {
+	data, err := ioutil.ReadFile("../.github/PULL_REQUEST_TEMPLATE.md")
+	if err != nil {
+		t.Fatal(err)
+	}
+	tmpl := ParseMarkdown(".github/PULL_REQUEST_TEMPLATE.md", data)
+	tmpl.SetOptional(DefaultOptionalSections)
+
+	tests := []struct {
+		name string
+		body string
+		want []string
+	}{
+		{
+			name: "template left unchanged",
+			body: string(data),
+			want: []string{
+				"Ⅰ. Describe what this PR did",
+				"Ⅱ. Does this pull request fix one issue?",
+				"Ⅲ. Why don't you add test cases (unit test/integration test)? (你真的觉得不需要加测试吗？)",
+				"Ⅳ. Describe how to verify it",
+			},
+		},
+		{
+			name: "filled",
+			body: `### Ⅰ. Describe what this PR did
+support label sync
+### Ⅱ. Does this pull request fix one issue?
+fixes #15
+### Ⅲ. Why don't you add test cases (unit test/integration test)? (你真的觉得不需要加测试吗？)
+added
+### Ⅳ. Describe how to verify it
+run go test`,
+		},
+		{
+			name: "hints are not content",
+			body: `### Ⅰ. Describe what this PR did
+support label sync
+### Ⅱ. Does this pull request fix one issue?
+<!--If that, add "fixes #xxxx" below in the next line, for example, fixes #15. Otherwise, add "NONE" -->
+### Ⅲ. Why don't you add test cases (unit test/integration test)? (你真的觉得不需要加测试吗？)
+added
+### Ⅳ. Describe how to verify it
+run go test`,
+			want: []string{"Ⅱ. Does this pull request fix one issue?"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tmpl.Missing(tt.body); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Missing() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,311 @@
+// Copyright 2018 The Pouch Robot Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package repotemplates
+
+import (
+	"bufio"
+	"fmt"
+	"path"
+	"regexp"
+	"strings"
+	"unicode"
+
+	"gopkg.in/yaml.v2"
+)
+
+var (
+	// headingRegexes match section headings like "### Title", "**Title**" and "**Title**:".
+	headingRegexes = []*regexp.Regexp{
+		regexp.MustCompile(`^#{1,6}\s+(.+?)\s*#*$`),
+		regexp.MustCompile(`^\*\*(.+?)\*\*:?$`),
+		regexp.MustCompile(`^__(.+?)__:?$`),
+	}
+
+	// numberingRegex matches numbering of a section title like "1.", "Ⅳ." or "iv)".
+	numberingRegex = regexp.MustCompile(`^([0-9]+|[ⅰ-ⅿⅠ-Ⅿ]+|[ivx]+)\s*[.)、]\s*`)
+
+	// htmlCommentRegex matches HTML comments, which are hints in templates.
+	htmlCommentRegex = regexp.MustCompile(`(?s)<!--.*?-->`)
+
+	// emptyListItemRegex matches a list item without content like "-" or "1.".
+	emptyListItemRegex = regexp.MustCompile(`^([-*+]|[0-9]+\.)$`)
+)
+
+// noResponse is the content GitHub fills in an empty field of issue forms.
+const noResponse = "_No response_"
+
+// Section is a section of a template.
+type Section struct {
+	// Title is the title of section.
+	Title string
+
+	// Placeholder are lines of content in template, which are not regarded
+	// as real content when they are left unchanged.
+	Placeholder []string
+
+	// Required means the section must be filled with real content.
+	Required bool
+}
+
+// Template is an issue or pull request template of repository.
+type Template struct {
+	// Name is the name of template in front matter, or its file name.
+	Name string
+
+	// Labels are labels which template applies to new issues.
+	Labels []string
+
+	// Sections are sections of template.
+	Sections []*Section
+}
+
+// MissingSections is the data to render comment of missing template sections.
+type MissingSections struct {
+	// Author is the GitHub login of the issue or pull request author.
+	Author string
+
+	// Template is the name of template.
+	Template string
+
+	// Missing are titles of required sections which are missing or left as placeholder.
+	Missing []string
+}
+
+// frontMatter is the YAML header of templates in .github/ISSUE_TEMPLATE.
+type frontMatter struct {
+	Name   string      `yaml:"name"`
+	Labels interface{} `yaml:"labels"`
+}
+
+// ParseMarkdown parses a markdown template, all of its sections are required.
+func ParseMarkdown(filePath string, data []byte) *Template {
+	content := string(data)
+	t := &Template{Name: path.Base(filePath)}
+
+	// front matter is enclosed by lines of "---".
+	if strings.HasPrefix(content, "---\n") || strings.HasPrefix(content, "---\r\n") {
+		parts := regexp.MustCompile(`(?m)^---\s*$`).Split(content, 3)
+		if len(parts) == 3 {
+			var fm frontMatter
+			if err := yaml.Unmarshal([]byte(parts[1]), &fm); err == nil {
+				if fm.Name != "" {
+					t.Name = fm.Name
+				}
+				t.Labels = stringList(fm.Labels)
+			}
+			content = parts[2]
+		}
+	}
+
+	for _, s := range parseSections(content) {
+		t.Sections = append(t.Sections, &Section{
+			Title:       s.title,
+			Placeholder: s.lines,
+			Required:    true,
+		})
+	}
+	return t
+}
+
+// form is an issue form in .github/ISSUE_TEMPLATE.
+type form struct {
+	Name   string      `yaml:"name"`
+	Labels interface{} `yaml:"labels"`
+	Body   []struct {
+		Type       string `yaml:"type"`
+		Attributes struct {
+			Label string `yaml:"label"`
+			Value string `yaml:"value"`
+		} `yaml:"attributes"`
+		Validations struct {
+			Required bool `yaml:"required"`
+		} `yaml:"validations"`
+	} `yaml:"body"`
+}
+
+// ParseForm parses a YAML issue form. GitHub renders each field of it as a
+// section titled by label of the field.
+func ParseForm(filePath string, data []byte) (*Template, error) {
+	var f form
+	if err := yaml.Unmarshal(data, &f); err != nil {
+		return nil, fmt.Errorf("failed to parse issue form %s: %v", filePath, err)
+	}
+
+	t := &Template{Name: f.Name, Labels: stringList(f.Labels)}
+	if t.Name == "" {
+		t.Name = path.Base(filePath)
+	}
+	for _, field := range f.Body {
+		if field.Type == "markdown" || field.Attributes.Label == "" {
+			continue
+		}
+		t.Sections = append(t.Sections, &Section{
+			Title:       field.Attributes.Label,
+			Placeholder: nonEmptyLines(field.Attributes.Value),
+			Required:    field.Validations.Required,
+		})
+	}
+	return t, nil
+}
+
+// SetOptional marks sections whose titles contain any of titles as optional.
+func (t *Template) SetOptional(titles []string) {
+	for _, section := range t.Sections {
+		normalized := normalizeTitle(section.Title)
+		for _, title := range titles {
+			if strings.Contains(normalized, normalizeTitle(title)) {
+				section.Required = false
+				break
+			}
+		}
+	}
+}
+
+// Missing returns titles of required sections which are absent from body
+// or only contain placeholder text.
+func (t *Template) Missing(body string) []string {
+	filled := map[string][]string{}
+	for _, s := range parseSections(body) {
+		filled[normalizeTitle(s.title)] = append(filled[normalizeTitle(s.title)], s.lines...)
+	}
+
+	var missing []string
+	for _, section := range t.Sections {
+		if !section.Required {
+			continue
+		}
+		lines, ok := filled[normalizeTitle(section.Title)]
+		if !ok || !hasContent(lines, section.Placeholder) {
+			missing = append(missing, section.Title)
+		}
+	}
+	return missing
+}
+
+// Best returns the template which body most likely follows, which has the
+// most section titles present in body. The first template wins a tie.
+func Best(templates []*Template, body string) *Template {
+	present := map[string]bool{}
+	for _, s := range parseSections(body) {
+		present[normalizeTitle(s.title)] = true
+	}
+
+	var best *Template
+	bestCount := -1
+	for _, t := range templates {
+		count := 0
+		for _, section := range t.Sections {
+			if present[normalizeTitle(section.Title)] {
+				count++
+			}
+		}
+		if count > bestCount {
+			best, bestCount = t, count
+		}
+	}
+	return best
+}
+
+type rawSection struct {
+	title string
+	lines []string
+}
+
+// parseSections splits markdown into sections by headings. Text before the
+// first heading and HTML comments are dropped.
+func parseSections(content string) []rawSection {
+	content = htmlCommentRegex.ReplaceAllString(content, "")
+
+	var sections []rawSection
+	scanner := bufio.NewScanner(strings.NewReader(content))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if title, ok := heading(line); ok {
+			sections = append(sections, rawSection{title: title})
+			continue
+		}
+		if line == "" || len(sections) == 0 {
+			continue
+		}
+		last := &sections[len(sections)-1]
+		last.lines = append(last.lines, line)
+	}
+	return sections
+}
+
+func heading(line string) (string, bool) {
+	for _, regex := range headingRegexes {
+		if match := regex.FindStringSubmatch(line); match != nil {
+			return strings.TrimSpace(match[1]), true
+		}
+	}
+	return "", false
+}
+
+// normalizeTitle makes titles comparable regardless of numbering, case and punctuation.
+func normalizeTitle(title string) string {
+	title = strings.ToLower(strings.TrimSpace(title))
+	title = numberingRegex.ReplaceAllString(title, "")
+	fields := strings.FieldsFunc(title, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsNumber(r)
+	})
+	return strings.Join(fields, " ")
+}
+
+// hasContent returns true if lines contain anything other than placeholder.
+func hasContent(lines, placeholder []string) bool {
+	unchanged := map[string]bool{}
+	for _, line := range placeholder {
+		unchanged[line] = true
+	}
+	for _, line := range lines {
+		if unchanged[line] || line == noResponse || emptyListItemRegex.MatchString(line) {
+			continue
+		}
+		return true
+	}
+	return false
+}
+
+func nonEmptyLines(text string) []string {
+	var lines []string
+	for _, line := range strings.Split(text, "\n") {
+		if line = strings.TrimSpace(line); line != "" {
+			lines = append(lines, line)
+		}
+	}
+	return lines
+}
+
+// stringList converts a YAML field which is either a comma separated
+// string or a list of strings.
+func stringList(value interface{}) []string {
+	var list []string
+	switch v := value.(type) {
+	case string:
+		for _, item := range strings.Split(v, ",") {
+			if item = strings.TrimSpace(item); item != "" {
+				list = append(list, item)
+			}
+		}
+	case []interface{}:
+		for _, item := range v {
+			if s, ok := item.(string); ok && s != "" {
+				list = append(list, s)
+			}
+		}
+	}
+	return list
+}
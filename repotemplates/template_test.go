@@ -0,0 +1,125 @@
+// Copyright 2018 The Pouch Robot Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package repotemplates
+
+import (
+	"reflect"
+	"testing"
+)
+
+const issueTemplate = `### Ⅰ. Issue Description
+
+
+### Ⅱ. Describe what happened
+
+
+### Ⅳ. How to reproduce it (as minimally and precisely as possible)
+
+1.
+2.
+3.
+
+### Ⅴ. Anything else we need to know?
+
+
+### Ⅵ. Environment:
+
+- pouch version (use ` + "`pouch version`" + `):
+- OS (e.g. from /etc/os-release):
+`
+
+func TestMissing(t *testing.T) {
+	tmpl := ParseMarkdown(".github/ISSUE_TEMPLATE.md", []byte(issueTemplate))
+	tmpl.SetOptional(DefaultOptionalSections)
+
+	tests := []struct {
+		name string
+		body string
+		want []string
+	}{
+		{
+			name: "all filled",
+			body: `### Ⅰ. Issue Description
+container fails to start
+### Ⅱ. Describe what happened
+it exits with 127
+### Ⅳ. How to reproduce it (as minimally and precisely as possible)
+1. pouch run busybox
+### Ⅵ. Environment:
+- pouch version (use ` + "`pouch version`" + `): 1.0.0
+- OS (e.g. from /etc/os-release):`,
+			want: nil,
+		},
+		{
+			name: "placeholder left and numbering changed",
+			body: `**1. Issue Description**
+container fails to start
+### Ⅳ. How to reproduce it
+1.
+2.
+### Ⅵ. Environment:
+- pouch version (use ` + "`pouch version`" + `):
+- OS (e.g. from /etc/os-release):`,
+			want: []string{"Ⅱ. Describe what happened", "Ⅳ. How to reproduce it (as minimally and precisely as possible)", "Ⅵ. Environment:"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tmpl.Missing(tt.body); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Missing() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseForm(t *testing.T) {
+	tmpl, err := ParseForm(".github/ISSUE_TEMPLATE/bug.yml", []byte(`name: Bug report
+labels: [kind/bug]
+body:
+- type: markdown
+  attributes:
+    value: Thanks for reporting!
+- type: textarea
+  attributes:
+    label: What happened?
+  validations:
+    required: true
+- type: input
+  attributes:
+    label: Version
+`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if tmpl.Name != "Bug report" || !reflect.DeepEqual(tmpl.Labels, []string{"kind/bug"}) || len(tmpl.Sections) != 2 {
+		t.Fatalf("ParseForm() = %+v", tmpl)
+	}
+
+	body := "### What happened?\n\n_No response_\n\n### Version\n\n_No response_"
+	if got, want := tmpl.Missing(body), []string{"What happened?"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Missing() = %q, want %q", got, want)
+	}
+}
+
+func TestBest(t *testing.T) {
+	bug := ParseMarkdown("bug_report.md", []byte("---\nname: Bug report\nlabels: kind/bug\n---\n**Describe the bug**\n\n**To Reproduce**\n"))
+	feature := ParseMarkdown("feature_request.md", []byte("**Describe the solution you'd like**\n"))
+	if bug.Name != "Bug report" || !reflect.DeepEqual(bug.Labels, []string{"kind/bug"}) {
+		t.Fatalf("ParseMarkdown() front matter = %+v", bug)
+	}
+	if got := Best([]*Template{feature, bug}, "**Describe the bug**\nit crashes"); got != bug {
+		t.Errorf("Best() = %s, want %s", got.Name, bug.Name)
+	}
+}
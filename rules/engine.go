@@ -0,0 +1,236 @@
+// Copyright 2018 The Pouch Robot Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rules
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pouchcontainer/pouchrobot/gh"
+	"github.com/pouchcontainer/pouchrobot/utils"
+	"github.com/pouchcontainer/pouchrobot/utils/templates"
+
+	"github.com/sirupsen/logrus"
+)
+
+// commentIDPrefix is the prefix of comment id of comments posted by rules.
+// Name of rule is appended to it.
+const commentIDPrefix = "rule-"
+
+// Client is the part of GitHub client rules need. It is satisfied by *gh.Client.
+type Client interface {
+	Login() (string, error)
+	AddLabelsToIssue(num int, labels []string) error
+	RemoveLabelForIssue(num int, label string) error
+	UpsertComment(num int, id string, body string) error
+	AssignIssueToUsers(num int, users []string) error
+	CloseIssue(num int) error
+}
+
+// Renderer renders comment templates. It is satisfied by *templates.Renderer.
+type Renderer interface {
+	Render(name string, data interface{}) (string, error)
+}
+
+// Engine takes actions of rules declared in a rules file on matching events.
+// Rules file is reloaded once it is modified.
+type Engine struct {
+	sync.Mutex
+
+	client   Client
+	renderer Renderer
+	owner    string
+	repo     string
+
+	// path is the path of rules file.
+	path string
+
+	// modTime is the modification time of rules file when rules are loaded.
+	modTime time.Time
+
+	// rules are rules loaded from rules file.
+	rules []Rule
+}
+
+// New creates an engine with rules in file path. It fails if rules file is invalid.
+func New(client Client, renderer Renderer, owner, repo, path string) (*Engine, error) {
+	e := &Engine{
+		client:   client,
+		renderer: renderer,
+		owner:    owner,
+		repo:     repo,
+		path:     path,
+	}
+	if err := e.reload(); err != nil {
+		return nil, err
+	}
+	return e, nil
+}
+
+// Rules returns rules in rules file. Rules file is reloaded if it is
+// modified, and rules loaded before are kept if it becomes invalid.
+func (e *Engine) Rules() []Rule {
+	if err := e.reload(); err != nil {
+		logrus.Errorf("failed to reload rules from %s, keep rules loaded before: %v", e.path, err)
+	}
+
+	e.Lock()
+	defer e.Unlock()
+	return e.rules
+}
+
+func (e *Engine) reload() error {
+	info, err := os.Stat(e.path)
+	if err != nil {
+		return err
+	}
+
+	e.Lock()
+	defer e.Unlock()
+	if e.rules != nil && info.ModTime().Equal(e.modTime) {
+		return nil
+	}
+
+	rules, err := Load(e.path)
+	if err != nil {
+		return err
+	}
+	if rules == nil {
+		rules = []Rule{}
+	}
+	e.rules, e.modTime = rules, info.ModTime()
+	logrus.Infof("loaded %d rules from %s", len(rules), e.path)
+	return nil
+}
+
+// HandleEvent takes actions of every rule matching a webhook event. Rules are
+// applied in order, and labels changed by a rule are seen by later rules.
+// Events triggered by robot itself are ignored, so that rules never trigger
+// each other in a loop. files lists files changed in pull request, shared
+// with other handlers of the event.
+func (e *Engine) HandleEvent(eventType string, data []byte, files gh.FileLister) error {
+	event, err := ParseEvent(eventType, data)
+	if err != nil || event == nil || event.Number == 0 {
+		return err
+	}
+	if robot, err := e.client.Login(); err == nil && strings.EqualFold(event.Sender, robot) {
+		return nil
+	}
+
+	var changed []string
+	filesListed := false
+	var filesErr, firstErr error
+	for _, rule := range e.Rules() {
+		if rule.Event != event.Type {
+			continue
+		}
+		if rule.needsFiles() && event.IsPullRequest && !filesListed {
+			filesListed = true
+			commitFiles, err := files.ListFiles(event.Number)
+			if err != nil {
+				logrus.Errorf("failed to list files of %d, skip rules with path conditions: %v", event.Number, err)
+				filesErr = err
+				if firstErr == nil {
+					firstErr = err
+				}
+			}
+			for _, file := range commitFiles {
+				changed = append(changed, file.GetFilename())
+			}
+		}
+		if rule.needsFiles() && filesErr != nil {
+			continue
+		}
+		if !rule.Match(event, changed) {
+			continue
+		}
+
+		logrus.Infof("rule %s matches %s event of %d", rule.Name, event.Type, event.Number)
+		if err := e.apply(&rule, event); err != nil {
+			logrus.Errorf("failed to apply rule %s to %d: %v", rule.Name, event.Number, err)
+			if firstErr == nil {
+				firstErr = err
+			}
+		}
+	}
+	return firstErr
+}
+
+// apply takes actions of rule on issue or pull request of event.
+func (e *Engine) apply(rule *Rule, event *Event) error {
+	num := event.Number
+	then := rule.Then
+
+	var add []string
+	for _, label := range then.AddLabels {
+		if !utils.SliceContainsElementFold(event.Labels, label) && !utils.SliceContainsElementFold(add, label) {
+			add = append(add, label)
+		}
+	}
+	if len(add) != 0 {
+		if err := e.client.AddLabelsToIssue(num, add); err != nil {
+			return err
+		}
+		event.Labels = append(event.Labels, add...)
+	}
+
+	for _, label := range then.RemoveLabels {
+		if !utils.SliceContainsElementFold(event.Labels, label) {
+			continue
+		}
+		if err := e.client.RemoveLabelForIssue(num, label); err != nil {
+			return err
+		}
+		event.Labels = removeFold(event.Labels, label)
+	}
+
+	if then.Comment != "" {
+		body, err := e.renderer.Render(then.Comment, templates.Data{
+			Author: event.Author,
+			Owner:  e.owner,
+			Repo:   e.repo,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to render comment template %s: %v", then.Comment, err)
+		}
+		if err := e.client.UpsertComment(num, commentIDPrefix+rule.Name, body); err != nil {
+			return err
+		}
+	}
+
+	if len(then.Assign) != 0 {
+		if err := e.client.AssignIssueToUsers(num, then.Assign); err != nil {
+			return err
+		}
+	}
+
+	if then.Close {
+		return e.client.CloseIssue(num)
+	}
+	return nil
+}
+
+func removeFold(values []string, value string) []string {
+	var result []string
+	for _, v := range values {
+		if !strings.EqualFold(v, value) {
+			result = append(result, v)
+		}
+	}
+	return result
+}
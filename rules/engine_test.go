@@ -0,0 +1,180 @@
+// Copyright 2018 The Pouch Robot Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rules
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/google/go-github/github"
+)
+
+// fakeClient records calls robot makes to GitHub.
+type fakeClient struct {
+	files   []string
+	listErr error
+	calls   []string
+}
+
+func (c *fakeClient) Login() (string, error) { return "pouchrobot", nil }
+
+func (c *fakeClient) ListFiles(num int) ([]*github.CommitFile, error) {
+	if c.listErr != nil {
+		return nil, c.listErr
+	}
+	var files []*github.CommitFile
+	for _, name := range c.files {
+		files = append(files, &github.CommitFile{Filename: github.String(name)})
+	}
+	return files, nil
+}
+
+func (c *fakeClient) AddLabelsToIssue(num int, labels []string) error {
+	c.calls = append(c.calls, fmt.Sprintf("add %d %v", num, labels))
+	return nil
+}
+
+func (c *fakeClient) RemoveLabelForIssue(num int, label string) error {
+	c.calls = append(c.calls, fmt.Sprintf("remove %d %s", num, label))
+	return nil
+}
+
+func (c *fakeClient) UpsertComment(num int, id string, body string) error {
+	c.calls = append(c.calls, fmt.Sprintf("comment %d %s %s", num, id, body))
+	return nil
+}
+
+func (c *fakeClient) AssignIssueToUsers(num int, users []string) error {
+	c.calls = append(c.calls, fmt.Sprintf("assign %d %v", num, users))
+	return nil
+}
+
+func (c *fakeClient) CloseIssue(num int) error {
+	c.calls = append(c.calls, fmt.Sprintf("close %d", num))
+	return nil
+}
+
+type fakeRenderer struct{}
+
+func (fakeRenderer) Render(name string, data interface{}) (string, error) {
+	return fmt.Sprintf("%s(%+v)", name, data), nil
+}
+
+func TestHandleEvent(t *testing.T) {
+	tests := []struct {
+		eventType string
+		fixture   string
+		files     []string
+		listErr   error
+		want      []string
+	}{
+		{
+			eventType: "pull_request",
+			fixture:   "pull_request_opened.json",
+			files:     []string{"network/bridge.go"},
+			want: []string{
+				"add 12 [first-time-contributor]",
+				"comment 12 rule-first-timer first-contribution({Author:newcomer Owner:pouchcontainer Repo:pouch MinLength:0 Gap:0 Threshold:0 BuildURL: Duration:0})",
+				"add 12 [areas/network]",
+				"add 12 [kind/feature]",
+			},
+		},
+		{
+			eventType: "pull_request",
+			fixture:   "pull_request_opened.json",
+			listErr:   fmt.Errorf("rate limited"),
+			want: []string{
+				"add 12 [first-time-contributor]",
+				"comment 12 rule-first-timer first-contribution({Author:newcomer Owner:pouchcontainer Repo:pouch MinLength:0 Gap:0 Threshold:0 BuildURL: Duration:0})",
+				"add 12 [kind/feature]",
+			},
+		},
+		{
+			eventType: "issues",
+			fixture:   "issues_opened.json",
+			want:      []string{"add 34 [status/needs-triage]"},
+		},
+		{
+			eventType: "issues",
+			fixture:   "issues_labeled.json",
+			want:      []string{"remove 34 status/needs-triage", "assign 34 [bugmaster]"},
+		},
+		{
+			eventType: "issue_comment",
+			fixture:   "issue_comment_created.json",
+			want:      []string{"add 56 [duplicate]", "close 56"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.fixture, func(t *testing.T) {
+			data, err := ioutil.ReadFile(filepath.Join("testdata", tt.fixture))
+			if err != nil {
+				t.Fatal(err)
+			}
+			client := &fakeClient{files: tt.files, listErr: tt.listErr}
+			engine, err := New(client, fakeRenderer{}, "pouchcontainer", "pouch", filepath.Join("testdata", "rules.yaml"))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if err := engine.HandleEvent(tt.eventType, data, client); err != tt.listErr {
+				t.Fatalf("HandleEvent() error = %v, want %v", err, tt.listErr)
+			}
+			if !reflect.DeepEqual(client.calls, tt.want) {
+				t.Errorf("HandleEvent() calls = %q, want %q", client.calls, tt.want)
+			}
+		})
+	}
+}
+
+func TestReload(t *testing.T) {
+	dir, err := ioutil.TempDir("", "rules")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "rules.json")
+	write := func(content string, modTime time.Time) {
+		if err := ioutil.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.Chtimes(path, modTime, modTime); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	now := time.Now()
+	write(`[{"name": "a", "event": "issues", "then": {"close": true}}]`, now.Add(-time.Hour))
+	engine, err := New(&fakeClient{}, fakeRenderer{}, "pouchcontainer", "pouch", path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	write(`[{"name": "b", "event": "issues", "then": {"close": true}}]`, now)
+	if rules := engine.Rules(); len(rules) != 1 || rules[0].Name != "b" {
+		t.Errorf("Rules() = %+v, want rule b after file is modified", rules)
+	}
+
+	write(`[{"name": "c"}]`, now.Add(time.Hour))
+	if rules := engine.Rules(); len(rules) != 1 || rules[0].Name != "b" {
+		t.Errorf("Rules() = %+v, want rule b kept when file becomes invalid", rules)
+	}
+}
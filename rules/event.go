@@ -0,0 +1,119 @@
+// Copyright 2018 The Pouch Robot Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rules
+
+import (
+	"encoding/json"
+)
+
+// Event is an issue or pull request event which rules match against.
+type Event struct {
+	// Type is the webhook event type.
+	Type string
+
+	// Action is the action type of event.
+	Action string
+
+	// Number is the number of issue or pull request.
+	Number int
+
+	// IsPullRequest is true if event is about a pull request.
+	IsPullRequest bool
+
+	// Title is the title of issue or pull request.
+	Title string
+
+	// Body is the body of issue or pull request, or body of comment for comment events.
+	Body string
+
+	// Author is the author of issue or pull request, or author of comment for comment events.
+	Author string
+
+	// AuthorAssociation is the association of Author with repo.
+	AuthorAssociation string
+
+	// Sender is the user who triggers event.
+	Sender string
+
+	// Labels are labels attached to issue or pull request.
+	Labels []string
+}
+
+type user struct {
+	Login string `json:"login"`
+}
+
+type item struct {
+	Number            int    `json:"number"`
+	Title             string `json:"title"`
+	Body              string `json:"body"`
+	User              user   `json:"user"`
+	AuthorAssociation string `json:"author_association"`
+	Labels            []struct {
+		Name string `json:"name"`
+	} `json:"labels"`
+	PullRequest *struct{} `json:"pull_request"`
+}
+
+type comment struct {
+	Body              string `json:"body"`
+	User              user   `json:"user"`
+	AuthorAssociation string `json:"author_association"`
+}
+
+// ParseEvent parses webhook payload data of event type eventType. It returns
+// nil if event is not about an issue or pull request.
+func ParseEvent(eventType string, data []byte) (*Event, error) {
+	var payload struct {
+		Action      string   `json:"action"`
+		Issue       *item    `json:"issue"`
+		PullRequest *item    `json:"pull_request"`
+		Comment     *comment `json:"comment"`
+		Sender      user     `json:"sender"`
+	}
+	if err := json.Unmarshal(data, &payload); err != nil {
+		return nil, err
+	}
+
+	target := payload.Issue
+	isPR := target != nil && target.PullRequest != nil
+	if payload.PullRequest != nil {
+		target, isPR = payload.PullRequest, true
+	}
+	if target == nil {
+		return nil, nil
+	}
+
+	event := &Event{
+		Type:              eventType,
+		Action:            payload.Action,
+		Number:            target.Number,
+		IsPullRequest:     isPR,
+		Title:             target.Title,
+		Body:              target.Body,
+		Author:            target.User.Login,
+		AuthorAssociation: target.AuthorAssociation,
+		Sender:            payload.Sender.Login,
+	}
+	for _, label := range target.Labels {
+		event.Labels = append(event.Labels, label.Name)
+	}
+	if payload.Comment != nil {
+		event.Body = payload.Comment.Body
+		event.Author = payload.Comment.User.Login
+		event.AuthorAssociation = payload.Comment.AuthorAssociation
+	}
+	return event, nil
+}
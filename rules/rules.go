@@ -0,0 +1,237 @@
+// Copyright 2018 The Pouch Robot Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rules
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/pouchcontainer/pouchrobot/utils"
+	"github.com/pouchcontainer/pouchrobot/utils/glob"
+
+	"gopkg.in/yaml.v2"
+)
+
+// Rule automates an action of robot: when an event matches conditions of
+// rule, actions of rule are taken on the issue or pull request.
+type Rule struct {
+	// Name identifies rule in logs and in markers of its comments.
+	Name string `json:"name" yaml:"name"`
+
+	// Event is the webhook event type, like "issues", "pull_request" or "issue_comment".
+	Event string `json:"event" yaml:"event"`
+
+	// Actions are action types of event, like "opened" or "labeled".
+	// Rule matches all actions if it is empty.
+	Actions []string `json:"actions" yaml:"actions"`
+
+	// If are conditions which must all be satisfied.
+	If Conditions `json:"if" yaml:"if"`
+
+	// Then are actions to take once rule matches.
+	Then Action `json:"then" yaml:"then"`
+
+	// title and body are compiled regular expressions of If.Title and If.Body.
+	title *regexp.Regexp
+	body  *regexp.Regexp
+}
+
+// Conditions are conditions on an event. Empty conditions are always satisfied.
+type Conditions struct {
+	// Labels must all be attached to issue or pull request.
+	Labels []string `json:"labels" yaml:"labels"`
+
+	// NotLabels must not be attached to issue or pull request.
+	NotLabels []string `json:"notLabels" yaml:"notLabels"`
+
+	// AuthorAssociations are associations of author with repo, like
+	// "FIRST_TIME_CONTRIBUTOR" or "MEMBER", and one of them must match.
+	AuthorAssociations []string `json:"authorAssociations" yaml:"authorAssociations"`
+
+	// Title is a regular expression which must match title.
+	Title string `json:"title" yaml:"title"`
+
+	// Body is a regular expression which must match body, or body of
+	// comment for comment events.
+	Body string `json:"body" yaml:"body"`
+
+	// Paths are glob patterns, and a file changed by pull request must match
+	// one of them. Issues never satisfy it.
+	Paths []string `json:"paths" yaml:"paths"`
+}
+
+// Action is what a rule does to an issue or pull request.
+type Action struct {
+	// AddLabels are labels to add.
+	AddLabels []string `json:"addLabels" yaml:"addLabels"`
+
+	// RemoveLabels are labels to remove.
+	RemoveLabels []string `json:"removeLabels" yaml:"removeLabels"`
+
+	// Comment is the name of comment template to post.
+	Comment string `json:"comment" yaml:"comment"`
+
+	// Assign are users to assign to.
+	Assign []string `json:"assign" yaml:"assign"`
+
+	// Close closes issue or pull request.
+	Close bool `json:"close" yaml:"close"`
+}
+
+// Load reads rules from file in path. A file with suffix .yml or .yaml is
+// parsed as YAML, otherwise as JSON.
+func Load(path string) ([]Rule, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return Parse(filepath.Ext(path), data)
+}
+
+// Parse parses and validates rules in data. ext is the file extension
+// deciding format of data.
+func Parse(ext string, data []byte) ([]Rule, error) {
+	var rules []Rule
+	switch strings.ToLower(ext) {
+	case ".yml", ".yaml":
+		err := yaml.Unmarshal(data, &rules)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse rules: %v", err)
+		}
+	default:
+		err := json.Unmarshal(data, &rules)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse rules: %v", err)
+		}
+	}
+	if err := Validate(rules); err != nil {
+		return nil, err
+	}
+	return rules, nil
+}
+
+// Validate checks that every rule has a unique name, an event, valid
+// conditions and at least one action. Regular expressions of rules are
+// compiled in place.
+func Validate(rules []Rule) error {
+	names := map[string]bool{}
+	for i := range rules {
+		if err := rules[i].Compile(); err != nil {
+			return err
+		}
+		rule := rules[i]
+		if rule.Name == "" {
+			return fmt.Errorf("rule %d has no name", i)
+		}
+		if names[rule.Name] {
+			return fmt.Errorf("rule %s is declared twice", rule.Name)
+		}
+		names[rule.Name] = true
+
+		if rule.Event == "" {
+			return fmt.Errorf("rule %s has no event", rule.Name)
+		}
+		for _, pattern := range rule.If.Paths {
+			if _, err := glob.Compile(pattern); err != nil {
+				return fmt.Errorf("rule %s has invalid path pattern %q: %v", rule.Name, pattern, err)
+			}
+		}
+
+		then := rule.Then
+		if len(then.AddLabels) == 0 && len(then.RemoveLabels) == 0 && then.Comment == "" && len(then.Assign) == 0 && !then.Close {
+			return fmt.Errorf("rule %s has no action", rule.Name)
+		}
+	}
+	return nil
+}
+
+// Compile compiles regular expressions of conditions of rule, which Match
+// needs. Rules from Parse are already compiled.
+func (r *Rule) Compile() error {
+	var err error
+	if r.title, err = compileCondition(r.If.Title); err != nil {
+		return fmt.Errorf("rule %s has invalid regular expression %q: %v", r.Name, r.If.Title, err)
+	}
+	if r.body, err = compileCondition(r.If.Body); err != nil {
+		return fmt.Errorf("rule %s has invalid regular expression %q: %v", r.Name, r.If.Body, err)
+	}
+	return nil
+}
+
+// compileCondition compiles expr, and returns nil if expr is empty.
+func compileCondition(expr string) (*regexp.Regexp, error) {
+	if expr == "" {
+		return nil, nil
+	}
+	return regexp.Compile(expr)
+}
+
+// Match returns true if event satisfies rule. files are files changed by
+// pull request, which are only needed by rules with path conditions. Rule
+// must be compiled by Compile before.
+func (r *Rule) Match(event *Event, files []string) bool {
+	if r.Event != event.Type {
+		return false
+	}
+	if len(r.Actions) != 0 && !utils.SliceContainsElementFold(r.Actions, event.Action) {
+		return false
+	}
+
+	for _, label := range r.If.Labels {
+		if !utils.SliceContainsElementFold(event.Labels, label) {
+			return false
+		}
+	}
+	for _, label := range r.If.NotLabels {
+		if utils.SliceContainsElementFold(event.Labels, label) {
+			return false
+		}
+	}
+	if len(r.If.AuthorAssociations) != 0 && !utils.SliceContainsElementFold(r.If.AuthorAssociations, event.AuthorAssociation) {
+		return false
+	}
+	if r.title != nil && !r.title.MatchString(event.Title) {
+		return false
+	}
+	if r.body != nil && !r.body.MatchString(event.Body) {
+		return false
+	}
+
+	if len(r.If.Paths) != 0 {
+		if !event.IsPullRequest {
+			return false
+		}
+		matched := false
+		for _, file := range files {
+			if glob.Match(r.If.Paths, file) {
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			return false
+		}
+	}
+	return true
+}
+
+// needsFiles returns true if rule needs files changed by pull request to match.
+func (r *Rule) needsFiles() bool {
+	return len(r.If.Paths) != 0
+}
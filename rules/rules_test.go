@@ -0,0 +1,90 @@
+// Copyright 2018 The Pouch Robot Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rules
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name string
+		data string
+		err  string
+	}{
+		{name: "valid", data: `[{"name": "a", "event": "issues", "then": {"close": true}}]`},
+		{name: "no name", data: `[{"event": "issues", "then": {"close": true}}]`, err: "has no name"},
+		{name: "duplicated", data: `[{"name": "a", "event": "issues", "then": {"close": true}}, {"name": "a", "event": "issues", "then": {"close": true}}]`, err: "declared twice"},
+		{name: "no event", data: `[{"name": "a", "then": {"close": true}}]`, err: "has no event"},
+		{name: "bad regex", data: `[{"name": "a", "event": "issues", "if": {"title": "("}, "then": {"close": true}}]`, err: "invalid regular expression"},
+		{name: "no action", data: `[{"name": "a", "event": "issues"}]`, err: "has no action"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Parse(".json", []byte(tt.data))
+			if tt.err == "" && err != nil {
+				t.Errorf("Parse() error = %v, want nil", err)
+			}
+			if tt.err != "" && (err == nil || !strings.Contains(err.Error(), tt.err)) {
+				t.Errorf("Parse() error = %v, want %q", err, tt.err)
+			}
+		})
+	}
+}
+
+func TestMatch(t *testing.T) {
+	rule := Rule{
+		Name:    "docs",
+		Event:   "pull_request",
+		Actions: []string{"opened"},
+		If: Conditions{
+			NotLabels: []string{"do-not-merge"},
+			Title:     "^docs",
+			Paths:     []string{"docs/**"},
+		},
+		Then: Action{AddLabels: []string{"areas/docs"}},
+	}
+	if err := rule.Compile(); err != nil {
+		t.Fatal(err)
+	}
+	base := Event{Type: "pull_request", Action: "opened", IsPullRequest: true, Title: "docs: fix typo"}
+
+	tests := []struct {
+		name  string
+		event func(e *Event)
+		files []string
+		want  bool
+	}{
+		{name: "match", files: []string{"docs/api.md"}, want: true},
+		{name: "other event", event: func(e *Event) { e.Type = "issues" }, files: []string{"docs/api.md"}},
+		{name: "other action", event: func(e *Event) { e.Action = "edited" }, files: []string{"docs/api.md"}},
+		{name: "excluded label", event: func(e *Event) { e.Labels = []string{"do-not-merge"} }, files: []string{"docs/api.md"}},
+		{name: "title", event: func(e *Event) { e.Title = "feature: docs" }, files: []string{"docs/api.md"}},
+		{name: "paths", files: []string{"daemon/daemon.go"}},
+		{name: "issue", event: func(e *Event) { e.IsPullRequest = false }, files: []string{"docs/api.md"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			event := base
+			if tt.event != nil {
+				tt.event(&event)
+			}
+			if got := rule.Match(&event, tt.files); got != tt.want {
+				t.Errorf("Match() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
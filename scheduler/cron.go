@@ -0,0 +1,233 @@
+// Copyright 2018 The Pouch Robot Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package scheduler
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// maxSearch bounds the search of next activation of a schedule, so that a
+// schedule which never fires, like "0 0 30 2 *", does not loop forever.
+const maxSearch = 5 * 366 * 24 * time.Hour
+
+// Schedule is a parsed cron expression with five fields: minute, hour, day of
+// month, month and day of week. Each field is "*", a number, a range like
+// "1-5", a step like "*/3" or "0-30/10", or a list of them separated by
+// commas. Months and days of week could be names like "Jan" and "Friday".
+type Schedule struct {
+	expr string
+
+	minute, hour, dom, month, dow uint64
+
+	// domStar and dowStar record whether day fields are "*". A day matches
+	// if either day field matches when both are restricted, as cron does.
+	domStar, dowStar bool
+}
+
+// field describes the range and names of a field in cron expression.
+type field struct {
+	name     string
+	min, max int
+	names    map[string]int
+}
+
+var (
+	minuteField = field{name: "minute", min: 0, max: 59}
+	hourField   = field{name: "hour", min: 0, max: 23}
+	domField    = field{name: "day of month", min: 1, max: 31}
+	monthField  = field{name: "month", min: 1, max: 12, names: map[string]int{
+		"jan": 1, "feb": 2, "mar": 3, "apr": 4, "may": 5, "jun": 6,
+		"jul": 7, "aug": 8, "sep": 9, "oct": 10, "nov": 11, "dec": 12,
+	}}
+	dowField = field{name: "day of week", min: 0, max: 6, names: map[string]int{
+		"sun": 0, "mon": 1, "tue": 2, "wed": 3, "thu": 4, "fri": 5, "sat": 6,
+	}}
+)
+
+// Parse parses a cron expression.
+func Parse(expr string) (*Schedule, error) {
+	fields := strings.Fields(expr)
+	if len(fields) != 5 {
+		return nil, fmt.Errorf("cron expression %q must have 5 fields, got %d", expr, len(fields))
+	}
+
+	s := &Schedule{
+		expr:    expr,
+		domStar: fields[2] == "*",
+		dowStar: fields[4] == "*",
+	}
+	for i, f := range []struct {
+		bits *uint64
+		desc field
+	}{
+		{&s.minute, minuteField},
+		{&s.hour, hourField},
+		{&s.dom, domField},
+		{&s.month, monthField},
+		{&s.dow, dowField},
+	} {
+		bits, err := parseField(fields[i], f.desc)
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s in cron expression %q: %v", f.desc.name, expr, err)
+		}
+		*f.bits = bits
+	}
+	return s, nil
+}
+
+// Every returns a cron expression running about every d. Cron could not
+// express every interval, so d is rounded down to minutes dividing an hour,
+// hours dividing a day or a day, like 45 minutes to 30 minutes.
+func Every(d time.Duration) string {
+	minutes := int(d / time.Minute)
+	switch {
+	case minutes < 60:
+		return fmt.Sprintf("*/%d * * * *", largestDivisor(60, minutes))
+	case minutes < 24*60:
+		return fmt.Sprintf("0 */%d * * *", largestDivisor(24, minutes/60))
+	default:
+		return "0 0 * * *"
+	}
+}
+
+// largestDivisor returns the largest divisor of n which is not more than
+// max, or 1 if max is less than 1.
+func largestDivisor(n, max int) int {
+	for d := max; d > 1; d-- {
+		if n%d == 0 {
+			return d
+		}
+	}
+	return 1
+}
+
+// parseField parses a field into a bit set of matching values.
+func parseField(text string, f field) (uint64, error) {
+	var bits uint64
+	for _, part := range strings.Split(text, ",") {
+		rangeText, step := part, 1
+		if i := strings.Index(part, "/"); i >= 0 {
+			var err error
+			rangeText = part[:i]
+			if step, err = strconv.Atoi(part[i+1:]); err != nil || step <= 0 {
+				return 0, fmt.Errorf("invalid step %q", part[i+1:])
+			}
+		}
+
+		start, end := f.min, f.max
+		if rangeText != "*" {
+			bounds := strings.SplitN(rangeText, "-", 2)
+			var err error
+			if start, err = parseValue(bounds[0], f); err != nil {
+				return 0, err
+			}
+			end = start
+			if len(bounds) == 2 {
+				if end, err = parseValue(bounds[1], f); err != nil {
+					return 0, err
+				}
+			} else if step != 1 {
+				// "5/10" means from 5 to the max with step 10.
+				end = f.max
+			}
+			if start > end {
+				return 0, fmt.Errorf("invalid range %q", rangeText)
+			}
+		}
+
+		for v := start; v <= end; v += step {
+			bits |= 1 << uint(v)
+		}
+	}
+	return bits, nil
+}
+
+// parseValue parses a number or a name in field.
+func parseValue(text string, f field) (int, error) {
+	if f.names != nil && len(text) >= 3 {
+		// full names like "Friday" and "January" are accepted as well.
+		if v, ok := f.names[strings.ToLower(text[:3])]; ok && (len(text) == 3 || strings.EqualFold(text, f.fullName(v))) {
+			return v, nil
+		}
+	}
+
+	v, err := strconv.Atoi(text)
+	if err != nil {
+		return 0, fmt.Errorf("invalid value %q", text)
+	}
+	// 7 is Sunday as well in day of week.
+	if f.name == dowField.name && v == 7 {
+		v = 0
+	}
+	if v < f.min || v > f.max {
+		return 0, fmt.Errorf("value %d out of range [%d, %d]", v, f.min, f.max)
+	}
+	return v, nil
+}
+
+// fullName returns the English name of value v in field.
+func (f field) fullName(v int) string {
+	if f.name == monthField.name {
+		return time.Month(v).String()
+	}
+	return time.Weekday(v).String()
+}
+
+// String returns the cron expression of schedule.
+func (s *Schedule) String() string {
+	return s.expr
+}
+
+// Next returns the first activation time of schedule after t, in location
+// of t. Zero time is returned if schedule never fires.
+func (s *Schedule) Next(t time.Time) time.Time {
+	loc := t.Location()
+	t = t.Truncate(time.Minute).Add(time.Minute)
+	limit := t.Add(maxSearch)
+
+	for t.Before(limit) {
+		if s.month&(1<<uint(t.Month())) == 0 {
+			t = time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, loc)
+			continue
+		}
+		if !s.matchDay(t) {
+			t = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, loc)
+			continue
+		}
+		if s.hour&(1<<uint(t.Hour())) == 0 {
+			t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour()+1, 0, 0, 0, loc)
+			continue
+		}
+		if s.minute&(1<<uint(t.Minute())) == 0 {
+			t = t.Add(time.Minute)
+			continue
+		}
+		return t
+	}
+	return time.Time{}
+}
+
+// matchDay returns whether day of t matches day of month and day of week.
+func (s *Schedule) matchDay(t time.Time) bool {
+	domMatch := s.dom&(1<<uint(t.Day())) != 0
+	dowMatch := s.dow&(1<<uint(t.Weekday())) != 0
+	if s.domStar || s.dowStar {
+		return domMatch && dowMatch
+	}
+	return domMatch || dowMatch
+}
@@ -0,0 +1,99 @@
+// Copyright 2018 The Pouch Robot Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package scheduler
+
+import (
+	"testing"
+	"time"
+)
+
+func TestNext(t *testing.T) {
+	shanghai, err := time.LoadLocation("Asia/Shanghai")
+	if err != nil {
+		t.Skipf("time zone database is not available: %v", err)
+	}
+	// 2018-09-05 is a Wednesday.
+	now := time.Date(2018, 9, 5, 10, 30, 20, 0, time.UTC)
+
+	tests := []struct {
+		name string
+		expr string
+		now  time.Time
+		want time.Time
+	}{
+		{name: "every 3 minutes", expr: "*/3 * * * *", now: now, want: time.Date(2018, 9, 5, 10, 33, 0, 0, time.UTC)},
+		{name: "daily", expr: "0 6 * * *", now: now, want: time.Date(2018, 9, 6, 6, 0, 0, 0, time.UTC)},
+		{name: "weekday name", expr: "0 17 * * Friday", now: now, want: time.Date(2018, 9, 7, 17, 0, 0, 0, time.UTC)},
+		{name: "short weekday name", expr: "0 17 * * fri", now: now, want: time.Date(2018, 9, 7, 17, 0, 0, 0, time.UTC)},
+		{name: "sunday as 7", expr: "0 0 * * 7", now: now, want: time.Date(2018, 9, 9, 0, 0, 0, 0, time.UTC)},
+		{name: "range and list", expr: "15,45 9-11 * * 1-5", now: now, want: time.Date(2018, 9, 5, 10, 45, 0, 0, time.UTC)},
+		{name: "month", expr: "0 0 1 Jan *", now: now, want: time.Date(2019, 1, 1, 0, 0, 0, 0, time.UTC)},
+		{name: "day of month or day of week", expr: "0 0 10 * Mon", now: now, want: time.Date(2018, 9, 10, 0, 0, 0, 0, time.UTC)},
+		{name: "time zone", expr: "0 17 * * Fri", now: now.In(shanghai), want: time.Date(2018, 9, 7, 17, 0, 0, 0, shanghai)},
+		{name: "never", expr: "0 0 30 2 *", now: now, want: time.Time{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, err := Parse(tt.expr)
+			if err != nil {
+				t.Fatalf("Parse(%q) error = %v", tt.expr, err)
+			}
+			if got := s.Next(tt.now); !got.Equal(tt.want) {
+				t.Errorf("Next() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseInvalid(t *testing.T) {
+	for _, expr := range []string{
+		"",
+		"* * * *",
+		"60 * * * *",
+		"* 24 * * *",
+		"* * 0 * *",
+		"* * * 13 *",
+		"* * * * Fridays",
+		"*/0 * * * *",
+		"10-5 * * * *",
+	} {
+		if _, err := Parse(expr); err == nil {
+			t.Errorf("Parse(%q) succeeded, want error", expr)
+		}
+	}
+}
+
+func TestEvery(t *testing.T) {
+	tests := []struct {
+		interval time.Duration
+		want     string
+	}{
+		{interval: 0, want: "*/1 * * * *"},
+		{interval: 5 * time.Minute, want: "*/5 * * * *"},
+		{interval: 45 * time.Minute, want: "*/30 * * * *"},
+		{interval: time.Hour, want: "0 */1 * * *"},
+		{interval: 5 * time.Hour, want: "0 */4 * * *"},
+		{interval: 48 * time.Hour, want: "0 0 * * *"},
+	}
+	for _, tt := range tests {
+		got := Every(tt.interval)
+		if got != tt.want {
+			t.Errorf("Every(%v) = %q, want %q", tt.interval, got, tt.want)
+		}
+		if _, err := Parse(got); err != nil {
+			t.Errorf("Every(%v) = %q, which is invalid: %v", tt.interval, got, err)
+		}
+	}
+}
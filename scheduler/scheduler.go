@@ -0,0 +1,309 @@
+// Copyright 2018 The Pouch Robot Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package scheduler
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/rand"
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// RecheckInterval is the interval to check whether schedule of a job is
+// changed, like by reloaded config or per repository config file.
+var RecheckInterval = time.Minute
+
+// SpecFunc returns the cron expression of a job in effect. An empty
+// expression pauses the job.
+type SpecFunc func() string
+
+// Scheduler runs jobs on cron schedules in a time zone. A job is skipped if
+// its last run has not finished, and its last run time is kept in State so
+// that restarts neither skip nor repeat runs.
+type Scheduler struct {
+	sync.Mutex
+
+	location *time.Location
+	jitter   time.Duration
+	state    State
+
+	jobs    map[string]*job
+	stop    chan struct{}
+	started bool
+}
+
+// job is a job added to scheduler. Fields except name, spec and run are
+// protected by lock of scheduler.
+type job struct {
+	name string
+	spec SpecFunc
+	run  func() error
+
+	schedule string
+	lastRun  time.Time
+	nextRun  time.Time
+	running  bool
+}
+
+// JobStatus is the status of a job listed by scheduler.
+type JobStatus struct {
+	Name     string     `json:"name"`
+	Schedule string     `json:"schedule"`
+	LastRun  *time.Time `json:"lastRun,omitempty"`
+	NextRun  *time.Time `json:"nextRun,omitempty"`
+	Running  bool       `json:"running"`
+}
+
+// New creates a scheduler. Each run of jobs is delayed by a random duration
+// in [0, jitter), so that robots of many repositories do not call GitHub at
+// the same time. Last run times are not kept if state is nil.
+func New(location *time.Location, jitter time.Duration, state State) *Scheduler {
+	if location == nil {
+		location = time.Local
+	}
+	return &Scheduler{
+		location: location,
+		jitter:   jitter,
+		state:    state,
+		jobs:     map[string]*job{},
+		stop:     make(chan struct{}),
+	}
+}
+
+// Add adds a job named name, which runs on schedule returned by spec. It
+// fails if the current schedule is invalid or the name is used.
+func (s *Scheduler) Add(name string, spec SpecFunc, run func() error) error {
+	if expr := spec(); expr != "" {
+		if _, err := Parse(expr); err != nil {
+			return fmt.Errorf("invalid schedule of job %s: %v", name, err)
+		}
+	}
+
+	s.Lock()
+	defer s.Unlock()
+	if _, ok := s.jobs[name]; ok {
+		return fmt.Errorf("job %s is added already", name)
+	}
+	j := &job{name: name, spec: spec, run: run}
+	s.jobs[name] = j
+	if s.started {
+		go s.loop(j)
+	}
+	return nil
+}
+
+// Start starts to run jobs.
+func (s *Scheduler) Start() {
+	s.Lock()
+	defer s.Unlock()
+	if s.started {
+		return
+	}
+	s.started = true
+
+	logrus.Infof("start to run scheduler in time zone %s", s.location)
+	for _, j := range s.jobs {
+		go s.loop(j)
+	}
+}
+
+// Stop stops scheduling jobs. Runs in progress are not interrupted.
+func (s *Scheduler) Stop() {
+	s.Lock()
+	defer s.Unlock()
+	if s.started {
+		close(s.stop)
+		s.started = false
+	}
+}
+
+// Run runs job name at once, unless its last run has not finished.
+func (s *Scheduler) Run(name string) error {
+	s.Lock()
+	j, ok := s.jobs[name]
+	s.Unlock()
+	if !ok {
+		return fmt.Errorf("job %s is not added", name)
+	}
+	s.fire(j)
+	return nil
+}
+
+// Jobs returns status of jobs sorted by name.
+func (s *Scheduler) Jobs() []JobStatus {
+	s.Lock()
+	defer s.Unlock()
+
+	jobs := make([]JobStatus, 0, len(s.jobs))
+	for _, j := range s.jobs {
+		status := JobStatus{Name: j.name, Schedule: j.schedule, Running: j.running}
+		if !j.lastRun.IsZero() {
+			lastRun := j.lastRun.In(s.location)
+			status.LastRun = &lastRun
+		}
+		if !j.nextRun.IsZero() {
+			nextRun := j.nextRun.In(s.location)
+			status.NextRun = &nextRun
+		}
+		jobs = append(jobs, status)
+	}
+	sort.Slice(jobs, func(i, k int) bool { return jobs[i].Name < jobs[k].Name })
+	return jobs
+}
+
+// ServeHTTP lists jobs and their next runs in JSON.
+func (s *Scheduler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(s.Jobs()); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
+
+// loop waits for runs of job until scheduler stops.
+func (s *Scheduler) loop(j *job) {
+	if s.state != nil {
+		lastRun, err := s.state.LastRun(j.name)
+		if err != nil {
+			logrus.Errorf("failed to get last run of job %s: %v", j.name, err)
+		}
+		// job could have been run by Run before its loop starts.
+		s.Lock()
+		if lastRun.After(j.lastRun) {
+			j.lastRun = lastRun
+		}
+		s.Unlock()
+	}
+
+	recheck := time.NewTicker(RecheckInterval)
+	defer recheck.Stop()
+
+	// a run missed while robot is down is caught up at start only.
+	catchUp := true
+	for {
+		expr := j.spec()
+		next := s.plan(j, expr, catchUp)
+		catchUp = false
+
+		// a paused job waits on a nil channel, which never fires.
+		var timer *time.Timer
+		var fire <-chan time.Time
+		if !next.IsZero() {
+			timer = time.NewTimer(time.Until(next))
+			fire = timer.C
+		}
+
+		running := s.wait(j, expr, fire, recheck.C)
+		if timer != nil {
+			timer.Stop()
+		}
+		if !running {
+			return
+		}
+	}
+}
+
+// wait runs job when fire fires, and returns true after the run is started
+// or schedule of job is changed. It returns false when scheduler stops.
+func (s *Scheduler) wait(j *job, expr string, fire, recheck <-chan time.Time) bool {
+	for {
+		select {
+		case <-fire:
+			s.fire(j)
+			return true
+		case <-recheck:
+			if j.spec() != expr {
+				logrus.Infof("schedule of job %s is changed", j.name)
+				return true
+			}
+		case <-s.stop:
+			return false
+		}
+	}
+}
+
+// plan computes and records the next run of job on schedule expr.
+func (s *Scheduler) plan(j *job, expr string, catchUp bool) time.Time {
+	s.Lock()
+	defer s.Unlock()
+
+	j.schedule = expr
+	j.nextRun = time.Time{}
+	if expr == "" {
+		return j.nextRun
+	}
+	schedule, err := Parse(expr)
+	if err != nil {
+		logrus.Errorf("pause job %s: %v", j.name, err)
+		return j.nextRun
+	}
+
+	lastRun := time.Time{}
+	if catchUp {
+		lastRun = j.lastRun
+	}
+	next := nextRun(schedule, lastRun, time.Now().In(s.location))
+	if !next.IsZero() && s.jitter > 0 {
+		next = next.Add(time.Duration(rand.Int63n(int64(s.jitter))))
+	}
+	j.nextRun = next
+	return next
+}
+
+// nextRun returns the next run time of schedule after now. It is now if the
+// activation after lastRun is missed.
+func nextRun(schedule *Schedule, lastRun, now time.Time) time.Time {
+	if !lastRun.IsZero() {
+		if missed := schedule.Next(lastRun.In(now.Location())); !missed.IsZero() && missed.Before(now) {
+			return now
+		}
+	}
+	return schedule.Next(now)
+}
+
+// fire runs job in background unless its last run has not finished.
+func (s *Scheduler) fire(j *job) {
+	s.Lock()
+	if j.running {
+		s.Unlock()
+		logrus.Warnf("skip job %s, since its last run started at %s has not finished", j.name, j.lastRun)
+		return
+	}
+	now := time.Now()
+	j.running = true
+	j.lastRun = now
+	s.Unlock()
+
+	if s.state != nil {
+		if err := s.state.SetLastRun(j.name, now); err != nil {
+			logrus.Errorf("failed to record last run of job %s: %v", j.name, err)
+		}
+	}
+
+	go func() {
+		logrus.Infof("start to run job %s", j.name)
+		if err := j.run(); err != nil {
+			logrus.Errorf("failed to run job %s: %v", j.name, err)
+		}
+
+		s.Lock()
+		j.running = false
+		s.Unlock()
+	}()
+}
@@ -0,0 +1,183 @@
+// Copyright 2018 The Pouch Robot Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package scheduler
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/pouchcontainer/pouchrobot/store"
+)
+
+func TestNextRun(t *testing.T) {
+	schedule, err := Parse("0 17 * * Fri")
+	if err != nil {
+		t.Fatal(err)
+	}
+	// 2018-09-08 is a Saturday.
+	now := time.Date(2018, 9, 8, 9, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name    string
+		lastRun time.Time
+		want    time.Time
+	}{
+		{name: "never run", want: time.Date(2018, 9, 14, 17, 0, 0, 0, time.UTC)},
+		{name: "missed while down", lastRun: time.Date(2018, 8, 31, 17, 0, 5, 0, time.UTC), want: now},
+		{name: "run before restart", lastRun: time.Date(2018, 9, 7, 17, 0, 5, 0, time.UTC), want: time.Date(2018, 9, 14, 17, 0, 0, 0, time.UTC)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := nextRun(schedule, tt.lastRun, now); !got.Equal(tt.want) {
+				t.Errorf("nextRun() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestStoreState(t *testing.T) {
+	dir, err := ioutil.TempDir("", "scheduler")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "store.json")
+	s, err := store.Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	state := NewStoreState(s)
+	if lastRun, err := state.LastRun("fetcher"); err != nil || !lastRun.IsZero() {
+		t.Fatalf("LastRun() of empty state = %v, %v", lastRun, err)
+	}
+
+	now := time.Date(2018, 9, 7, 17, 0, 0, 0, time.UTC)
+	if err := state.SetLastRun("fetcher", now); err != nil {
+		t.Fatalf("SetLastRun() error = %v", err)
+	}
+
+	// last run is kept across restarts.
+	s, err = store.Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	lastRun, err := NewStoreState(s).LastRun("fetcher")
+	if err != nil || !lastRun.Equal(now) {
+		t.Errorf("LastRun() = %v, %v, want %v", lastRun, err, now)
+	}
+}
+
+func TestAddAndJobs(t *testing.T) {
+	s := New(time.UTC, 0, nil)
+	run := func() error { return nil }
+
+	if err := s.Add("fetcher", func() string { return "*/3 * * * *" }, run); err != nil {
+		t.Fatalf("Add() error = %v", err)
+	}
+	if err := s.Add("fetcher", func() string { return "*/3 * * * *" }, run); err == nil {
+		t.Errorf("Add() succeeded with a duplicated name")
+	}
+	if err := s.Add("report", func() string { return "0 17 * * Fridays" }, run); err == nil {
+		t.Errorf("Add() succeeded with an invalid schedule")
+	}
+	if err := s.Add("paused", func() string { return "" }, run); err != nil {
+		t.Errorf("Add() error = %v", err)
+	}
+
+	jobs := s.Jobs()
+	if len(jobs) != 2 || jobs[0].Name != "fetcher" || jobs[1].Name != "paused" {
+		t.Errorf("Jobs() = %+v, want fetcher and paused", jobs)
+	}
+}
+
+// memoryState keeps last run times in memory.
+type memoryState map[string]time.Time
+
+func (m memoryState) LastRun(job string) (time.Time, error) {
+	return m[job], nil
+}
+
+func (m memoryState) SetLastRun(job string, t time.Time) error {
+	m[job] = t
+	return nil
+}
+
+func TestCatchUpMissedRun(t *testing.T) {
+	state := memoryState{"fetcher": time.Now().Add(-time.Hour)}
+	s := New(time.UTC, 0, state)
+
+	ran := make(chan struct{}, 1)
+	if err := s.Add("fetcher", func() string { return "*/3 * * * *" }, func() error {
+		ran <- struct{}{}
+		return nil
+	}); err != nil {
+		t.Fatal(err)
+	}
+	s.Start()
+	defer s.Stop()
+
+	select {
+	case <-ran:
+	case <-time.After(5 * time.Second):
+		t.Fatalf("missed run is not caught up at start")
+	}
+	// last run is recorded before job runs.
+	if lastRun := state["fetcher"]; time.Since(lastRun) > time.Minute {
+		t.Errorf("last run is not recorded, got %v", lastRun)
+	}
+}
+
+func TestRun(t *testing.T) {
+	state := memoryState{}
+	s := New(time.UTC, 0, state)
+
+	ran := make(chan struct{})
+	release := make(chan struct{})
+	if err := s.Add("maintainers", func() string { return "0 * * * *" }, func() error {
+		ran <- struct{}{}
+		<-release
+		return nil
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.Run("unknown"); err == nil {
+		t.Errorf("Run() succeeded with an unknown job")
+	}
+
+	if err := s.Run("maintainers"); err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+	select {
+	case <-ran:
+	case <-time.After(5 * time.Second):
+		t.Fatalf("job is not run at once")
+	}
+	if state["maintainers"].IsZero() {
+		t.Errorf("last run is not recorded")
+	}
+
+	// a run is skipped while the last one has not finished.
+	s.Run("maintainers")
+	select {
+	case <-ran:
+		t.Errorf("job runs while its last run has not finished")
+	case <-time.After(100 * time.Millisecond):
+	}
+	close(release)
+}
@@ -0,0 +1,57 @@
+// Copyright 2018 The Pouch Robot Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package scheduler
+
+import (
+	"time"
+
+	"github.com/pouchcontainer/pouchrobot/store"
+)
+
+// Bucket is the bucket of store keeping last run times of jobs.
+const Bucket = "schedules"
+
+// State keeps the last run time of jobs across restarts, so that a job
+// missed while robot is down is run at start, and a job which has run is not
+// run again.
+type State interface {
+	// LastRun returns the last run time of job, or zero time if job never runs.
+	LastRun(job string) (time.Time, error)
+
+	// SetLastRun records the last run time of job.
+	SetLastRun(job string, t time.Time) error
+}
+
+// storeState keeps last run times of jobs in a store.
+type storeState struct {
+	store store.Store
+}
+
+// NewStoreState creates a state kept in bucket Bucket of s.
+func NewStoreState(s store.Store) State {
+	return &storeState{store: s}
+}
+
+// LastRun returns the last run time of job.
+func (s *storeState) LastRun(job string) (time.Time, error) {
+	var lastRun time.Time
+	_, err := s.store.Get(Bucket, job, &lastRun)
+	return lastRun, err
+}
+
+// SetLastRun records the last run time of job.
+func (s *storeState) SetLastRun(job string, t time.Time) error {
+	return s.store.Put(Bucket, job, t)
+}
@@ -18,14 +18,30 @@ import (
 	"io/ioutil"
 	"net/http"
 	"strings"
+	"sync"
+	"time"
 
+	"github.com/pouchcontainer/pouchrobot/assigner"
 	"github.com/pouchcontainer/pouchrobot/ci"
+	"github.com/pouchcontainer/pouchrobot/codeowners"
 	"github.com/pouchcontainer/pouchrobot/config"
 	"github.com/pouchcontainer/pouchrobot/docgenerator"
 	"github.com/pouchcontainer/pouchrobot/fetcher"
 	"github.com/pouchcontainer/pouchrobot/gh"
+	"github.com/pouchcontainer/pouchrobot/labels"
+	"github.com/pouchcontainer/pouchrobot/lifecycle"
+	"github.com/pouchcontainer/pouchrobot/maintainers"
+	"github.com/pouchcontainer/pouchrobot/owners"
+	"github.com/pouchcontainer/pouchrobot/plugins"
 	"github.com/pouchcontainer/pouchrobot/processor"
+	"github.com/pouchcontainer/pouchrobot/repoconfig"
 	"github.com/pouchcontainer/pouchrobot/reporter"
+	"github.com/pouchcontainer/pouchrobot/repotemplates"
+	"github.com/pouchcontainer/pouchrobot/rules"
+	"github.com/pouchcontainer/pouchrobot/scheduler"
+	"github.com/pouchcontainer/pouchrobot/store"
+	"github.com/pouchcontainer/pouchrobot/utils/matcher"
+	"github.com/pouchcontainer/pouchrobot/utils/templates"
 	"github.com/pouchcontainer/pouchrobot/utils/translators"
 
 	"github.com/gorilla/mux"
@@ -35,12 +51,36 @@ import (
 // DefaultAddress is the default address daemon will listen to.
 var DefaultAddress = ":6789"
 
+// names of jobs run by scheduler, which are keys of scheduler.jobs in config.
+const (
+	fetcherJob      = "fetcher"
+	weeklyReportJob = "weekly-report"
+	docGeneratorJob = "doc-generator"
+	lifecycleJob    = "lifecycle"
+	moreInfoJob     = "more-info"
+	maintainersJob  = "maintainers"
+)
+
 // Server refers to a daemon server interating with github repos.
 type Server struct {
+	// RWMutex protects processor and config, which are replaced when config
+	// is reloaded, together with settings of the other modules.
+	sync.RWMutex
+
 	// listenAddress is the address which is used to accepting requests.
 	listenAddress string
 
-	// processor processes webhook event from GitHub.
+	// config is the config in effect.
+	config config.Config
+
+	// client is the GitHub client shared by all modules.
+	client *gh.Client
+
+	// renderer renders robot comments, which is reset when config is reloaded.
+	renderer *templates.Renderer
+
+	// processor processes webhook event from GitHub. It is rebuilt when
+	// config is reloaded, and events in flight are finished by the old one.
 	processor *processor.Processor
 
 	// fetcher does periodical work to check repo's status on GitHub.
@@ -54,15 +94,55 @@ type Server struct {
 
 	// docGenerator auto generates docs for repo.
 	docGenerator *docgenerator.Generator
+
+	// scheduler runs fetcher, reporter and doc generator periodically.
+	scheduler *scheduler.Scheduler
+
+	// lifecycle marks inactive issues and pull requests stale and closes them at last.
+	// It is nil if lifecycle management is disabled.
+	lifecycle *lifecycle.Manager
+
+	// moreInfoChecker follows up issues which need more information from authors.
+	// It is nil if it is disabled.
+	moreInfoChecker *lifecycle.MoreInfoChecker
+
+	// labelSyncer checks whether declared labels exist in repo.
+	labelSyncer *labels.Syncer
+
+	// labels are labels declared for repo.
+	labels []labels.Label
+
+	// maintainers periodically loads maintainers from repo.
+	maintainers *maintainers.Maintainers
+
+	// repoConfig loads per repository config file from repo.
+	repoConfig *repoconfig.Loader
 }
 
 // NewServer constructs a brand new robot server
 func NewServer(config config.Config) (*Server, error) {
 	ghClient := gh.NewClient(config.Owner, config.Repo, config.AccessToken)
-	translator := translators.NewBaiduTranslator(translators.BaiduTranslatorOptions{
-		Appid: config.TranslatorConfig.BaiduConfig.AppID,
-		Key:   config.TranslatorConfig.BaiduConfig.Key,
-	})
+	renderer := templates.New(config.TemplatesConfig.Dir, config.TemplatesConfig.Language)
+	robotStore, err := openStore(config.StoreConfig)
+	if err != nil {
+		return nil, err
+	}
+	repoMaintainers := maintainers.New(ghClient,
+		config.MaintainersConfig.File, config.MaintainersConfig.Format,
+		time.Duration(config.MaintainersConfig.RefreshMinutes)*time.Minute,
+		config.MaintainersConfig.Fallback,
+	)
+
+	labelMatcher, err := newLabelMatcher(config.LabelRules)
+	if err != nil {
+		return nil, err
+	}
+	matcher.SetCurrent(labelMatcher)
+
+	declaredLabels, err := labels.Load(config.LabelsConfig.File)
+	if err != nil {
+		return nil, err
+	}
 
 	docGenerator, err := docgenerator.New(ghClient,
 		config.Owner, config.Repo,
@@ -73,22 +153,235 @@ func NewServer(config config.Config) (*Server, error) {
 	if err != nil {
 		return nil, err
 	}
-	return &Server{
-		listenAddress: config.HTTPListen,
-		processor:     processor.New(ghClient, translator, config.Owner, config.Repo),
-		fetcher:       fetcher.New(ghClient, config.FetcherConfig.CommitsGap),
-		ciNotifier:    ci.New(ghClient, config.Owner, config.Repo),
-		reporter:      reporter.New(ghClient, config.WeeklyReportConfig.ReportDay, config.WeeklyReportConfig.ReportHour),
-		docGenerator:  docGenerator,
-	}, nil
+
+	// repoConfig merges per repository config file over settings of daemon.
+	// It is created after label matcher of daemon is set.
+	repoConfig := repoconfig.NewLoader(ghClient, repoSettings(config))
+
+	var lifecycleManager *lifecycle.Manager
+	if config.LifecycleConfig.Enabled {
+		lifecycleManager = lifecycle.New(ghClient, renderer, config.LifecycleConfig)
+	}
+	var moreInfoChecker *lifecycle.MoreInfoChecker
+	if config.MoreInfoConfig.Enabled {
+		moreInfoChecker = lifecycle.NewMoreInfoChecker(ghClient, renderer, config.MoreInfoConfig)
+	}
+
+	weeklyReporter := reporter.New(ghClient, config.WeeklyReportConfig.ReportDay, config.WeeklyReportConfig.ReportHour)
+	weeklyReporter.RepoConfig = repoConfig
+	weeklyReporter.Store = robotStore
+
+	prFetcher := fetcher.New(ghClient, renderer, config.FetcherConfig.CommitsGap)
+	prFetcher.RepoConfig = repoConfig
+	prFetcher.Store = robotStore
+
+	jobScheduler, err := newScheduler(config.SchedulerConfig, robotStore)
+	if err != nil {
+		return nil, err
+	}
+
+	s := &Server{
+		listenAddress:   config.HTTPListen,
+		config:          config,
+		client:          ghClient,
+		renderer:        renderer,
+		fetcher:         prFetcher,
+		ciNotifier:      ci.New(ghClient, renderer, config.Owner, config.Repo),
+		reporter:        weeklyReporter,
+		docGenerator:    docGenerator,
+		scheduler:       jobScheduler,
+		labelSyncer:     labels.NewSyncer(ghClient),
+		labels:          declaredLabels,
+		maintainers:     repoMaintainers,
+		lifecycle:       lifecycleManager,
+		moreInfoChecker: moreInfoChecker,
+		repoConfig:      repoConfig,
+	}
+
+	p, err := s.newProcessor(config)
+	if err != nil {
+		return nil, err
+	}
+	s.processor = p
+
+	type scheduledJob struct {
+		name string
+		spec scheduler.SpecFunc
+		run  func() error
+	}
+	jobs := []scheduledJob{
+		{fetcherJob, func() string { return fetcher.DefaultSchedule }, prFetcher.Fetch},
+		{weeklyReportJob, weeklyReporter.Schedule, weeklyReporter.Report},
+		{docGeneratorJob, docGenerator.Schedule, docGenerator.Generate},
+		{maintainersJob, repoMaintainers.Schedule, repoMaintainers.Refresh},
+	}
+	if lifecycleManager != nil {
+		jobs = append(jobs, scheduledJob{lifecycleJob, func() string { return lifecycle.DefaultSchedule }, lifecycleManager.CheckAll})
+	}
+	if moreInfoChecker != nil {
+		jobs = append(jobs, scheduledJob{moreInfoJob, func() string { return lifecycle.DefaultSchedule }, moreInfoChecker.CheckAll})
+	}
+	for _, job := range jobs {
+		if err := jobScheduler.Add(job.name, s.jobSpec(job.name, job.spec), job.run); err != nil {
+			return nil, err
+		}
+	}
+	return s, nil
+}
+
+// openStore opens the store persisted to file in config, or a store in
+// memory if no file is configured.
+func openStore(cfg config.StoreConfig) (*store.FileStore, error) {
+	if cfg.File == "" {
+		logrus.Warnf("no store file is configured, bookkeeping of robot is lost on restart")
+		return store.NewMemory(), nil
+	}
+	return store.Open(cfg.File)
+}
+
+// newScheduler creates a scheduler of periodical jobs, which keeps last run
+// times of jobs in robotStore.
+func newScheduler(cfg config.SchedulerConfig, robotStore store.Store) (*scheduler.Scheduler, error) {
+	location := time.Local
+	if cfg.TimeZone != "" {
+		var err error
+		if location, err = time.LoadLocation(cfg.TimeZone); err != nil {
+			return nil, err
+		}
+	}
+	return scheduler.New(location, time.Duration(cfg.JitterSeconds)*time.Second, scheduler.NewStoreState(robotStore)), nil
+}
+
+// jobSpec returns schedule of job name, which is overridden by config in
+// effect if it is set there.
+func (s *Server) jobSpec(name string, spec scheduler.SpecFunc) scheduler.SpecFunc {
+	return func() string {
+		s.RLock()
+		expr, ok := s.config.SchedulerConfig.Jobs[name]
+		s.RUnlock()
+		if ok {
+			return expr
+		}
+		return spec()
+	}
+}
+
+// newProcessor constructs a processor of webhook events with features
+// enabled in config. Long running workers of server are shared by processors,
+// so that a processor could be rebuilt when config is reloaded.
+func (s *Server) newProcessor(config config.Config) (*processor.Processor, error) {
+	ghClient := s.client
+	renderer := s.renderer
+	translator := translators.NewBaiduTranslator(translators.BaiduTranslatorOptions{
+		Appid: config.TranslatorConfig.BaiduConfig.AppID,
+		Key:   config.TranslatorConfig.BaiduConfig.Key,
+	})
+
+	p := processor.New(ghClient, translator, renderer, s.maintainers, config.Owner, config.Repo)
+	p.RepoConfig = s.repoConfig
+	p.IssueProcessor.RepoConfig = s.repoConfig
+	p.PullRequestProcessor.RepoConfig = s.repoConfig
+	p.Lifecycle = s.lifecycle
+	p.Reporter = s.reporter
+
+	if config.RulesConfig.File != "" {
+		ruleEngine, err := rules.New(ghClient, renderer, config.Owner, config.Repo, config.RulesConfig.File)
+		if err != nil {
+			return nil, err
+		}
+		p.Rules = ruleEngine
+	}
+
+	if len(config.Plugins) != 0 {
+		pluginManager, err := plugins.New(ghClient, s.maintainers, config.Plugins)
+		if err != nil {
+			return nil, err
+		}
+		p.Plugins = pluginManager
+	}
+	p.PullRequestProcessor.Size = config.SizeConfig
+	p.PullRequestProcessor.IssueLinks = config.IssueLinksConfig
+	if config.MergeConfig.Thanks {
+		p.PullRequestProcessor.RegisterPostMergeHook("thanks", p.PullRequestProcessor.ThankContributor)
+	}
+
+	repoTemplates := repotemplates.NewLoader(ghClient, config.TemplateCheckConfig.OptionalSections)
+	if config.TemplateCheckConfig.Issues {
+		p.IssueProcessor.RepoTemplates = repoTemplates
+	}
+	if config.TemplateCheckConfig.PullRequests {
+		p.PullRequestProcessor.RepoTemplates = repoTemplates
+	}
+
+	ownersLoader := owners.NewLoader(ghClient)
+	codeOwnersLoader := codeowners.NewLoader(ghClient, config.CodeOwnersConfig.File, config.CodeOwnersConfig.Areas)
+	if config.CodeOwnersConfig.Enabled {
+		p.PullRequestProcessor.CodeOwners = codeOwnersLoader
+	}
+	if config.OwnersConfig.Enabled {
+		p.PullRequestProcessor.Owners = ownersLoader
+		p.PullRequestProcessor.RequestOwnerReviewers = config.OwnersConfig.RequestReviewers
+	}
+	if config.ReviewersConfig.Enabled {
+		reviewerAssigner, err := assigner.New(ghClient, config.ReviewersConfig,
+			assigner.OwnersSource(ownersLoader),
+			assigner.HistorySource(ghClient),
+		)
+		if err != nil {
+			return nil, err
+		}
+		if config.CodeOwnersConfig.Enabled {
+			reviewerAssigner.AddSource(assigner.CodeOwnersSource(codeOwnersLoader, ghClient))
+		}
+		p.PullRequestProcessor.Assigner = reviewerAssigner
+	}
+	return p, nil
+}
+
+// newLabelMatcher compiles label rules in config. Built-in rules are used if
+// there is no rule in config.
+func newLabelMatcher(rules []config.LabelRule) (*matcher.Matcher, error) {
+	if len(rules) == 0 {
+		rules = matcher.DefaultRules
+	}
+	return matcher.New(rules)
+}
+
+// repoSettings returns settings of daemon which per repository config file
+// could override.
+func repoSettings(config config.Config) repoconfig.Settings {
+	return repoconfig.Settings{
+		CommitsGap: config.FetcherConfig.CommitsGap,
+		LabelRules: config.LabelRules,
+		ReportDay:  config.WeeklyReportConfig.ReportDay,
+		ReportHour: config.WeeklyReportConfig.ReportHour,
+	}
+}
+
+// handleEvent handles webhook event with the processor built from config in
+// effect. The lock is not held while the event is handled, so a slow event
+// never blocks reloading or other events. Processor is replaced as a whole
+// on reload, so an event is handled by either the old or the new one.
+func (s *Server) handleEvent(eventType string, data []byte) error {
+	s.RLock()
+	p := s.processor
+	s.RUnlock()
+	return p.HandleEvent(eventType, data)
 }
 
 // Run runs the server.
 func (s *Server) Run() error {
-	// start fetcher, reporter and doc generator in goroutines
-	go s.fetcher.Run()
-	go s.reporter.Run()
-	go s.docGenerator.Run()
+	// load per repository config file, which is refreshed on pushes later.
+	go s.repoConfig.Refresh("")
+
+	// start periodical jobs in scheduler. Maintainers are cached in memory,
+	// so they are loaded at once rather than at the next scheduled run.
+	go s.reporter.Init()
+	s.scheduler.Start()
+	s.scheduler.Run(maintainersJob)
+
+	// warn about missing labels which robot and maintainers rely on.
+	go s.labelSyncer.Check(s.labels)
 
 	// start webserver
 	listenAddress := s.listenAddress
@@ -104,6 +397,9 @@ func (s *Server) Run() error {
 	// github webhook API
 	r.HandleFunc("/events", s.gitHubEventHandler).Methods("POST")
 
+	// list scheduled jobs and their next runs
+	r.Handle("/schedules", s.scheduler).Methods("GET")
+
 	// travisCI webhook API
 	r.HandleFunc("/ci_notifications", s.ciNotificationHandler).Methods("POST")
 
@@ -131,7 +427,7 @@ func (s *Server) gitHubEventHandler(w http.ResponseWriter, r *http.Request) {
 
 	r.Body.Close()
 
-	if err := s.processor.HandleEvent(eventType, data); err != nil {
+	if err := s.handleEvent(eventType, data); err != nil {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
 	}
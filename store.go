@@ -0,0 +1,156 @@
+// Copyright 2018 The Pouch Robot Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/pouchcontainer/pouchrobot/config"
+	"github.com/pouchcontainer/pouchrobot/store"
+
+	"github.com/spf13/cobra"
+)
+
+// storeCmd is the parent of all store related commands.
+var storeCmd = &cobra.Command{
+	Use:   "store",
+	Short: "Manage persistent store of robot bookkeeping",
+	Args:  cobra.NoArgs,
+}
+
+// StoreSnapshotCommand is used to implement 'store snapshot' command.
+type StoreSnapshotCommand struct {
+	cmd *cobra.Command
+
+	// output is the file to write snapshot to. Snapshot is written to
+	// stdout if it is empty.
+	output string
+}
+
+// StoreRestoreCommand is used to implement 'store restore' command.
+type StoreRestoreCommand struct {
+	cmd *cobra.Command
+
+	// input is the snapshot file to restore from.
+	input string
+}
+
+func init() {
+	snapshotCommand := &StoreSnapshotCommand{}
+	snapshotCommand.cmd = &cobra.Command{
+		Use:   "snapshot",
+		Short: "Write a snapshot of store in JSON",
+		Long: `Write a snapshot of store in JSON.
+
+The store file set by field store.file of config file is read, which is
+safe while robot is running.`,
+		Args:          cobra.NoArgs,
+		SilenceErrors: true,
+		SilenceUsage:  true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return snapshotCommand.runSnapshot(args)
+		},
+	}
+	snapshotCommand.addFlags()
+
+	restoreCommand := &StoreRestoreCommand{}
+	restoreCommand.cmd = &cobra.Command{
+		Use:   "restore",
+		Short: "Replace everything in store with a snapshot",
+		Long: `Replace everything in store with a snapshot.
+
+Robot should be stopped before restoring, otherwise the restored store could
+be overwritten by robot.`,
+		Args:          cobra.NoArgs,
+		SilenceErrors: true,
+		SilenceUsage:  true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return restoreCommand.runRestore(args)
+		},
+	}
+	restoreCommand.addFlags()
+
+	storeCmd.AddCommand(snapshotCommand.cmd, restoreCommand.cmd)
+	rootCmd.AddCommand(storeCmd)
+}
+
+// addFlags adds flags for specific command.
+func (s *StoreSnapshotCommand) addFlags() {
+	flagSet := s.cmd.Flags()
+
+	flagSet.StringVarP(&s.output, "output", "o", "", "file to write snapshot to, default is stdout")
+}
+
+func (s *StoreSnapshotCommand) runSnapshot(args []string) error {
+	robotStore, err := openConfiguredStore()
+	if err != nil {
+		return err
+	}
+
+	var w io.Writer = os.Stdout
+	if s.output != "" {
+		f, err := os.Create(s.output)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		w = f
+	}
+	return robotStore.Snapshot(w)
+}
+
+// addFlags adds flags for specific command.
+func (r *StoreRestoreCommand) addFlags() {
+	flagSet := r.cmd.Flags()
+
+	flagSet.StringVarP(&r.input, "file", "f", "", "snapshot file to restore from")
+}
+
+func (r *StoreRestoreCommand) runRestore(args []string) error {
+	if r.input == "" {
+		return fmt.Errorf("snapshot file is required, set it by --file")
+	}
+
+	robotStore, err := openConfiguredStore()
+	if err != nil {
+		return err
+	}
+
+	f, err := os.Open(r.input)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	if err := robotStore.Restore(f); err != nil {
+		return err
+	}
+	fmt.Printf("store is restored from %s\n", r.input)
+	return nil
+}
+
+// openConfiguredStore opens the store file set in config file.
+func openConfiguredStore() (*store.FileStore, error) {
+	cfg, err := config.Load(cmdCfg.ConfigFilePath)
+	if err != nil {
+		return nil, err
+	}
+	if cfg.StoreConfig.File == "" {
+		return nil, fmt.Errorf("no store file is set by field store.file of config file %s", cmdCfg.ConfigFilePath)
+	}
+	return store.Open(cfg.StoreConfig.File)
+}
@@ -0,0 +1,317 @@
+// Copyright 2018 The Pouch Robot Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package store
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+)
+
+// Store is a key/value store keeping bookkeeping of robot across restarts.
+// Keys are grouped in buckets, and values are encoded in JSON.
+type Store interface {
+	// Get decodes value of key in bucket into value. It returns false if
+	// key does not exist.
+	Get(bucket, key string, value interface{}) (bool, error)
+
+	// Put sets value of key in bucket.
+	Put(bucket, key string, value interface{}) error
+
+	// Delete deletes key in bucket. It is not an error if key does not exist.
+	Delete(bucket, key string) error
+
+	// Keys returns sorted keys in bucket.
+	Keys(bucket string) ([]string, error)
+
+	// Batch calls fn with a store whose changes are persisted at once after
+	// fn returns, instead of one by one. Changes made before fn fails are
+	// kept and persisted as well.
+	Batch(fn func(Store) error) error
+}
+
+// data is the content of a store, keyed by bucket and key.
+type data map[string]map[string]json.RawMessage
+
+// FileStore is a store kept in memory and persisted to a JSON file on
+// every change. The whole file is rewritten on persisting, so changes made
+// while the file is being written are persisted together by the next write,
+// and changes made in Batch are persisted by a single write.
+type FileStore struct {
+	sync.RWMutex
+
+	// path is the file store is persisted to. Store is not persisted if it
+	// is empty.
+	path string
+
+	data data
+
+	// version counts changes of data.
+	version uint64
+
+	// persistLock serializes writes of file.
+	persistLock sync.Mutex
+
+	// persisted is the version of data in file. It is protected by persistLock.
+	persisted uint64
+}
+
+// Open opens store persisted to file path. The file is created on the first
+// change if it does not exist.
+func Open(path string) (*FileStore, error) {
+	s := &FileStore{path: path, data: data{}}
+
+	f, err := os.Open(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return s, nil
+		}
+		return nil, err
+	}
+	defer f.Close()
+
+	if s.data, err = decode(f); err != nil {
+		return nil, fmt.Errorf("failed to load store %s: %v", path, err)
+	}
+	return s, nil
+}
+
+// NewMemory creates a store which is not persisted, which loses everything
+// when robot restarts.
+func NewMemory() *FileStore {
+	return &FileStore{data: data{}}
+}
+
+// Get decodes value of key in bucket into value.
+func (s *FileStore) Get(bucket, key string, value interface{}) (bool, error) {
+	s.RLock()
+	raw, ok := s.data[bucket][key]
+	s.RUnlock()
+
+	if !ok {
+		return false, nil
+	}
+	if err := json.Unmarshal(raw, value); err != nil {
+		return true, fmt.Errorf("failed to decode %s/%s: %v", bucket, key, err)
+	}
+	return true, nil
+}
+
+// Put sets value of key in bucket.
+func (s *FileStore) Put(bucket, key string, value interface{}) error {
+	version, err := s.put(bucket, key, value)
+	if err != nil {
+		return err
+	}
+	return s.persist(version)
+}
+
+// Delete deletes key in bucket.
+func (s *FileStore) Delete(bucket, key string) error {
+	return s.persist(s.delete(bucket, key))
+}
+
+// Batch calls fn with a store whose changes are persisted at once after fn
+// returns.
+func (s *FileStore) Batch(fn func(Store) error) error {
+	err := fn(batch{s})
+
+	s.RLock()
+	version := s.version
+	s.RUnlock()
+	if persistErr := s.persist(version); err == nil {
+		err = persistErr
+	}
+	return err
+}
+
+// put sets value of key in bucket in memory, and returns version of data
+// with the change.
+func (s *FileStore) put(bucket, key string, value interface{}) (uint64, error) {
+	raw, err := json.Marshal(value)
+	if err != nil {
+		return 0, fmt.Errorf("failed to encode %s/%s: %v", bucket, key, err)
+	}
+
+	s.Lock()
+	defer s.Unlock()
+	if s.data[bucket] == nil {
+		s.data[bucket] = map[string]json.RawMessage{}
+	}
+	s.data[bucket][key] = raw
+	s.version++
+	return s.version, nil
+}
+
+// delete deletes key in bucket in memory, and returns version of data with
+// the change, or zero if key does not exist.
+func (s *FileStore) delete(bucket, key string) uint64 {
+	s.Lock()
+	defer s.Unlock()
+	if _, ok := s.data[bucket][key]; !ok {
+		return 0
+	}
+	delete(s.data[bucket], key)
+	if len(s.data[bucket]) == 0 {
+		delete(s.data, bucket)
+	}
+	s.version++
+	return s.version
+}
+
+// Keys returns sorted keys in bucket.
+func (s *FileStore) Keys(bucket string) ([]string, error) {
+	s.RLock()
+	defer s.RUnlock()
+
+	keys := make([]string, 0, len(s.data[bucket]))
+	for key := range s.data[bucket] {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys, nil
+}
+
+// Snapshot writes everything in store to w in JSON, which could be restored
+// by Restore.
+func (s *FileStore) Snapshot(w io.Writer) error {
+	s.RLock()
+	defer s.RUnlock()
+
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(s.data)
+}
+
+// Restore replaces everything in store with a snapshot read from r.
+func (s *FileStore) Restore(r io.Reader) error {
+	restored, err := decode(r)
+	if err != nil {
+		return fmt.Errorf("failed to read snapshot: %v", err)
+	}
+
+	s.Lock()
+	s.data = restored
+	s.version++
+	version := s.version
+	s.Unlock()
+	return s.persist(version)
+}
+
+// persist writes store to its file, unless data of version has already been
+// written together with later changes. It must be called without lock held.
+func (s *FileStore) persist(version uint64) error {
+	if s.path == "" {
+		return nil
+	}
+
+	s.persistLock.Lock()
+	defer s.persistLock.Unlock()
+	if s.persisted >= version {
+		return nil
+	}
+
+	s.RLock()
+	raw, err := json.MarshalIndent(s.data, "", "  ")
+	current := s.version
+	s.RUnlock()
+	if err != nil {
+		return err
+	}
+
+	if err := writeFile(s.path, raw); err != nil {
+		return err
+	}
+	s.persisted = current
+	return nil
+}
+
+// writeFile replaces file path with raw. A temporary file is written, synced
+// and renamed, and the directory is synced at last, so that neither a crash
+// in the middle nor a power loss leaves a broken or an outdated file.
+func writeFile(path string, raw []byte) error {
+	dir := filepath.Dir(path)
+	tmp, err := ioutil.TempFile(dir, filepath.Base(path)+".tmp")
+	if err != nil {
+		return err
+	}
+	if _, err := tmp.Write(raw); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return err
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmp.Name())
+		return err
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		os.Remove(tmp.Name())
+		return err
+	}
+
+	d, err := os.Open(dir)
+	if err != nil {
+		return err
+	}
+	defer d.Close()
+	return d.Sync()
+}
+
+// batch is a store whose changes are kept in memory of FileStore, which are
+// persisted by FileStore.Batch.
+type batch struct {
+	*FileStore
+}
+
+// Put sets value of key in bucket.
+func (b batch) Put(bucket, key string, value interface{}) error {
+	_, err := b.put(bucket, key, value)
+	return err
+}
+
+// Delete deletes key in bucket.
+func (b batch) Delete(bucket, key string) error {
+	b.delete(bucket, key)
+	return nil
+}
+
+// Batch calls fn with the batch itself, whose changes are persisted by the
+// outer one.
+func (b batch) Batch(fn func(Store) error) error {
+	return fn(b)
+}
+
+// decode reads content of a store in JSON from r.
+func decode(r io.Reader) (data, error) {
+	d := data{}
+	if err := json.NewDecoder(r).Decode(&d); err != nil && err != io.EOF {
+		return nil, err
+	}
+	if d == nil {
+		// "null" decodes into a nil map.
+		d = data{}
+	}
+	return d, nil
+}
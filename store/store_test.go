@@ -0,0 +1,201 @@
+// Copyright 2018 The Pouch Robot Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package store
+
+import (
+	"bytes"
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strconv"
+	"sync"
+	"testing"
+)
+
+type stats struct {
+	Star int `json:"star"`
+	Fork int `json:"fork"`
+}
+
+func TestFileStore(t *testing.T) {
+	dir, err := ioutil.TempDir("", "store")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "store.json")
+	s, err := Open(path)
+	if err != nil {
+		t.Fatalf("Open() error = %v", err)
+	}
+
+	var got stats
+	if found, err := s.Get("reporter", "stats", &got); err != nil || found {
+		t.Fatalf("Get() of empty store = %v, %v", found, err)
+	}
+
+	want := stats{Star: 3000, Fork: 500}
+	if err := s.Put("reporter", "stats", want); err != nil {
+		t.Fatalf("Put() error = %v", err)
+	}
+	for _, key := range []string{"2", "1"} {
+		if err := s.Put("review-comments", key, key); err != nil {
+			t.Fatalf("Put() error = %v", err)
+		}
+	}
+	if err := s.Delete("review-comments", "2"); err != nil {
+		t.Fatalf("Delete() error = %v", err)
+	}
+
+	// everything is kept after reopening.
+	s, err = Open(path)
+	if err != nil {
+		t.Fatalf("Open() error = %v", err)
+	}
+	if found, err := s.Get("reporter", "stats", &got); err != nil || !found || got != want {
+		t.Errorf("Get() = %+v, %v, %v, want %+v", got, found, err, want)
+	}
+	if keys, err := s.Keys("review-comments"); err != nil || !reflect.DeepEqual(keys, []string{"1"}) {
+		t.Errorf("Keys() = %v, %v, want [1]", keys, err)
+	}
+}
+
+func TestBatch(t *testing.T) {
+	dir, err := ioutil.TempDir("", "store")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "store.json")
+	s, err := Open(path)
+	if err != nil {
+		t.Fatalf("Open() error = %v", err)
+	}
+	if err := s.Put("gaps", "1", "abc"); err != nil {
+		t.Fatal(err)
+	}
+
+	failed := errors.New("failed")
+	err = s.Batch(func(tx Store) error {
+		for _, key := range []string{"2", "3"} {
+			if err := tx.Put("gaps", key, key); err != nil {
+				return err
+			}
+		}
+		if err := tx.Delete("gaps", "1"); err != nil {
+			return err
+		}
+
+		// changes are visible in batch, while they are not persisted yet.
+		if keys, _ := tx.Keys("gaps"); !reflect.DeepEqual(keys, []string{"2", "3"}) {
+			t.Errorf("Keys() in batch = %v, want [2 3]", keys)
+		}
+		reopened, err := Open(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if keys, _ := reopened.Keys("gaps"); !reflect.DeepEqual(keys, []string{"1"}) {
+			t.Errorf("Keys() of file in batch = %v, want [1]", keys)
+		}
+		return failed
+	})
+	if err != failed {
+		t.Errorf("Batch() error = %v, want %v", err, failed)
+	}
+
+	// changes before failure are persisted.
+	reopened, err := Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if keys, _ := reopened.Keys("gaps"); !reflect.DeepEqual(keys, []string{"2", "3"}) {
+		t.Errorf("Keys() after Batch() = %v, want [2 3]", keys)
+	}
+}
+
+func TestConcurrentPut(t *testing.T) {
+	dir, err := ioutil.TempDir("", "store")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "store.json")
+	s, err := Open(path)
+	if err != nil {
+		t.Fatalf("Open() error = %v", err)
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			if err := s.Put("review-comments", strconv.Itoa(i), i); err != nil {
+				t.Errorf("Put() error = %v", err)
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	reopened, err := Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if keys, _ := reopened.Keys("review-comments"); len(keys) != 20 {
+		t.Errorf("Keys() after concurrent Put() = %v, want 20 keys", keys)
+	}
+	// temporary files are renamed to store file.
+	if files, _ := ioutil.ReadDir(dir); len(files) != 1 {
+		t.Errorf("%d files in store directory, want 1", len(files))
+	}
+}
+
+func TestSnapshotAndRestore(t *testing.T) {
+	s := NewMemory()
+	if err := s.Put("reporter", "stats", stats{Star: 3000}); err != nil {
+		t.Fatal(err)
+	}
+
+	var snapshot bytes.Buffer
+	if err := s.Snapshot(&snapshot); err != nil {
+		t.Fatalf("Snapshot() error = %v", err)
+	}
+
+	restored := NewMemory()
+	if err := restored.Put("schedules", "fetcher", "2018-09-07T17:00:00Z"); err != nil {
+		t.Fatal(err)
+	}
+	if err := restored.Restore(&snapshot); err != nil {
+		t.Fatalf("Restore() error = %v", err)
+	}
+
+	var got stats
+	if found, err := restored.Get("reporter", "stats", &got); err != nil || !found || got.Star != 3000 {
+		t.Errorf("Get() after Restore() = %+v, %v, %v", got, found, err)
+	}
+	// restore replaces everything in store.
+	if keys, _ := restored.Keys("schedules"); len(keys) != 0 {
+		t.Errorf("Keys() after Restore() = %v, want empty", keys)
+	}
+
+	if err := restored.Restore(bytes.NewBufferString("[]")); err == nil {
+		t.Errorf("Restore() succeeded with an invalid snapshot")
+	}
+}
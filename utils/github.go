@@ -64,6 +64,39 @@ func ExactIssueComment(data []byte) (github.IssueComment, error) {
 	return m.IssueComment, nil
 }
 
+// ExactReview extracts the pull request review from request body.
+func ExactReview(data []byte) (github.PullRequestReview, error) {
+	var m struct {
+		Review github.PullRequestReview `json:"review"`
+	}
+	if err := json.Unmarshal(data, &m); err != nil {
+		return github.PullRequestReview{}, err
+	}
+	return m.Review, nil
+}
+
+// ExactReviewComment extracts the pull request review comment from request body.
+func ExactReviewComment(data []byte) (github.PullRequestComment, error) {
+	var m struct {
+		Comment github.PullRequestComment `json:"comment"`
+	}
+	if err := json.Unmarshal(data, &m); err != nil {
+		return github.PullRequestComment{}, err
+	}
+	return m.Comment, nil
+}
+
+// ExactRequestedReviewer extracts the user requested to review from request body.
+func ExactRequestedReviewer(data []byte) (github.User, error) {
+	var m struct {
+		RequestedReviewer github.User `json:"requested_reviewer"`
+	}
+	if err := json.Unmarshal(data, &m); err != nil {
+		return github.User{}, err
+	}
+	return m.RequestedReviewer, nil
+}
+
 // ExactIssueLabels extracts the issue labels from request body.
 func ExactIssueLabels(data []byte) ([]string, error) {
 	var m struct {
@@ -74,3 +107,33 @@ func ExactIssueLabels(data []byte) ([]string, error) {
 	}
 	return m.Labels, nil
 }
+
+// ExactSender extracts the user who triggers the event from request body.
+func ExactSender(data []byte) (github.User, error) {
+	var m struct {
+		Sender github.User `json:"sender"`
+	}
+	if err := json.Unmarshal(data, &m); err != nil {
+		return github.User{}, err
+	}
+	return m.Sender, nil
+}
+
+// ExactPreviousBody extracts the body before edition from request body of an
+// edited event. It returns false if body is not changed.
+func ExactPreviousBody(data []byte) (string, bool, error) {
+	var m struct {
+		Changes struct {
+			Body *struct {
+				From string `json:"from"`
+			} `json:"body"`
+		} `json:"changes"`
+	}
+	if err := json.Unmarshal(data, &m); err != nil {
+		return "", false, err
+	}
+	if m.Changes.Body == nil {
+		return "", false, nil
+	}
+	return m.Changes.Body.From, true, nil
+}
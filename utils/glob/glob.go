@@ -0,0 +1,123 @@
+// Copyright 2018 The Pouch Robot Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package glob
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"sync"
+)
+
+// cache caches compiled patterns of Match, since patterns come from config
+// and are matched against every changed file of every event. An invalid
+// pattern is cached as nil.
+var cache = struct {
+	sync.RWMutex
+	regexps map[string]*regexp.Regexp
+}{regexps: map[string]*regexp.Regexp{}}
+
+// Compile converts a path pattern into a regular expression matching paths
+// relative to root of repository. Patterns follow gitignore rules with the
+// exceptions GitHub documents for CODEOWNERS:
+//
+//	*.js        files ending with .js anywhere
+//	docs/       files under any directory named docs
+//	/docs/      files under docs in root directory
+//	docs/*      files directly in docs, but not in its subdirectories
+//	**/logs     files under any directory named logs
+//	docs/**     files under docs in root directory
+func Compile(pattern string) (*regexp.Regexp, error) {
+	if strings.HasPrefix(pattern, "\\#") {
+		pattern = pattern[1:]
+	}
+
+	trimmed := strings.TrimSuffix(pattern, "/")
+	anchored := strings.HasPrefix(pattern, "/") || strings.Contains(trimmed, "/")
+	dirOnly := strings.HasSuffix(pattern, "/")
+	pattern = strings.TrimPrefix(trimmed, "/")
+	if pattern == "" {
+		return nil, fmt.Errorf("empty pattern")
+	}
+
+	var expr strings.Builder
+	if anchored {
+		expr.WriteString("^")
+	} else {
+		expr.WriteString("^(?:.*/)?")
+	}
+
+	for i := 0; i < len(pattern); i++ {
+		switch c := pattern[i]; {
+		case strings.HasPrefix(pattern[i:], "**/"):
+			expr.WriteString("(?:.*/)?")
+			i += 2
+		case strings.HasPrefix(pattern[i:], "**"):
+			expr.WriteString(".*")
+			i++
+		case c == '*':
+			expr.WriteString("[^/]*")
+		case c == '?':
+			expr.WriteString("[^/]")
+		case c == '\\' && i+1 < len(pattern):
+			i++
+			expr.WriteString(regexp.QuoteMeta(string(pattern[i])))
+		default:
+			expr.WriteString(regexp.QuoteMeta(string(c)))
+		}
+	}
+
+	switch {
+	case dirOnly:
+		expr.WriteString("/.*$")
+	case strings.HasSuffix(pattern, "/*") && !strings.HasSuffix(pattern, "/**"):
+		expr.WriteString("$")
+	default:
+		// a pattern matching a directory matches everything in it.
+		expr.WriteString("(?:/.*)?$")
+	}
+	return regexp.Compile(expr.String())
+}
+
+// Match returns true if path matches any of patterns. Invalid patterns never match.
+func Match(patterns []string, path string) bool {
+	path = strings.TrimPrefix(path, "/")
+	for _, pattern := range patterns {
+		if regex := compileCached(pattern); regex != nil && regex.MatchString(path) {
+			return true
+		}
+	}
+	return false
+}
+
+// compileCached returns the compiled pattern from cache, compiling it on
+// first use. It returns nil if pattern is invalid.
+func compileCached(pattern string) *regexp.Regexp {
+	cache.RLock()
+	regex, ok := cache.regexps[pattern]
+	cache.RUnlock()
+	if ok {
+		return regex
+	}
+
+	regex, err := Compile(pattern)
+	if err != nil {
+		regex = nil
+	}
+	cache.Lock()
+	cache.regexps[pattern] = regex
+	cache.Unlock()
+	return regex
+}
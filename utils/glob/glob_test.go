@@ -0,0 +1,70 @@
+// Copyright 2018 The Pouch Robot Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package glob
+
+import (
+	"testing"
+)
+
+func TestMatch(t *testing.T) {
+	tests := []struct {
+		pattern string
+		path    string
+		want    bool
+	}{
+		{pattern: "*.js", path: "app.js", want: true},
+		{pattern: "*.js", path: "web/static/app.js", want: true},
+		{pattern: "*.js", path: "app.json", want: false},
+		{pattern: "docs/*", path: "docs/api.md", want: true},
+		{pattern: "docs/*", path: "docs/api/v1.md", want: false},
+		{pattern: "docs/**", path: "docs/api/v1.md", want: true},
+		{pattern: "docs/**", path: "web/docs/api.md", want: false},
+		{pattern: "**/logs", path: "logs/today.log", want: true},
+		{pattern: "**/logs", path: "daemon/logs/today.log", want: true},
+		{pattern: "daemon/**/*.go", path: "daemon/mgr/container.go", want: true},
+		{pattern: "daemon/**/*.go", path: "daemon/main.go", want: true},
+		{pattern: "file?.go", path: "file1.go", want: true},
+		{pattern: "file?.go", path: "file12.go", want: false},
+		{pattern: "file?.go", path: "file/.go", want: false},
+		{pattern: "docs/", path: "docs/api.md", want: true},
+		{pattern: "docs/", path: "web/docs/api.md", want: true},
+		{pattern: "docs/", path: "docs", want: false},
+		{pattern: "/docs/", path: "web/docs/api.md", want: false},
+		{pattern: "/docs/", path: "/docs/api.md", want: true},
+		{pattern: "network", path: "network/bridge.go", want: true},
+		{pattern: "network", path: "daemon/network", want: true},
+		{pattern: "\\#notes", path: "#notes", want: true},
+		{pattern: "a.b", path: "axb", want: false},
+		{pattern: "", path: "anything", want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.pattern+" "+tt.path, func(t *testing.T) {
+			// match twice to cover the cached pattern.
+			for i := 0; i < 2; i++ {
+				if got := Match([]string{tt.pattern}, tt.path); got != tt.want {
+					t.Errorf("Match(%q, %q) = %v, want %v", tt.pattern, tt.path, got, tt.want)
+				}
+			}
+		})
+	}
+}
+
+func TestCompileInvalid(t *testing.T) {
+	for _, pattern := range []string{"", "/"} {
+		if _, err := Compile(pattern); err == nil {
+			t.Errorf("Compile(%q) error = nil, want error", pattern)
+		}
+	}
+}
@@ -0,0 +1,151 @@
+// Copyright 2018 The Pouch Robot Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package matcher
+
+import "github.com/pouchcontainer/pouchrobot/config"
+
+// DefaultRules are built-in label rules used when no rule is configured.
+// They are originally made for project PouchContainer.
+var DefaultRules = []config.LabelRule{
+	{
+		Label:    "areas/cli",
+		Keywords: []string{"cli:", "cli :", "command line", "command-line"},
+		Words:    []string{"command", "commands"},
+	},
+	{
+		Label:    "areas/docs",
+		Keywords: []string{"doc:", "docs:", "doc :", "docs :", "document"},
+	},
+	{
+		Label:    "areas/log",
+		Keywords: []string{"gelf", "fluentd", "journald", "splunk", "syslog"},
+		Words:    []string{"log", "logs", "logging", "logger"},
+	},
+	{
+		Label:    "areas/images",
+		Keywords: []string{"docker image", "image-spec", "pouch pull"},
+	},
+	{
+		Label:    "areas/monitoring",
+		Keywords: []string{"monitoring", "prometheus", "health check"},
+	},
+	{
+		Label:    "areas/network",
+		Keywords: []string{"ipvlan", "ipsec", "macvlan", "network", "overlay", "vxlan"},
+		Words:    []string{"cni", "vlan"},
+	},
+	{
+		Label:    "areas/orchestration",
+		Keywords: []string{"kubernetes", "marathon", "mesos", "swarm"},
+	},
+	{
+		Label:    "areas/runv",
+		Keywords: []string{"runv"},
+	},
+	{
+		Label:    "areas/storage",
+		Keywords: []string{"storage", "volume"},
+		Words:    []string{"csi"},
+	},
+	{
+		Label: "areas/test",
+		Words: []string{"ci", "test", "tests", "testing", "unit-test", "integration-test"},
+	},
+	{
+		Label:    "areas/typo",
+		Keywords: []string{"typo"},
+	},
+	{
+		Label:    "kind/bug",
+		Keywords: []string{"bugfix", "cannot", "can not", "can't", "error", "failure", "failed to ", "fix:"},
+		Words:    []string{"bug", "bugs"},
+	},
+	{
+		Label:    "kind/design",
+		Keywords: []string{"design"},
+	},
+	{
+		Label:    "kind/feature",
+		Keywords: []string{"feature"},
+	},
+	{
+		Label:    "kind/feature-request",
+		Keywords: []string{"feature request", "feature-request", "feature_request"},
+	},
+	{
+		Label:    "kind/panic",
+		Scopes:   []string{ScopeTitle, ScopeBody},
+		Keywords: []string{"invalid memory address or nil pointer", "panic"},
+	},
+	{
+		Label:    "kind/performance",
+		Keywords: []string{"performance"},
+	},
+	{
+		Label:    "kind/proposal",
+		Keywords: []string{"proposal"},
+	},
+	{
+		Label:    "kind/question",
+		Keywords: []string{"confusion", "does pouch", "how to", "question", "where to"},
+		Words:    []string{"can i", "can you"},
+	},
+	{
+		Label:    "kind/refactor",
+		Keywords: []string{"refactor"},
+	},
+	{
+		Label:    "os/windows",
+		Keywords: []string{"windows"},
+		Words:    []string{".net"},
+	},
+	{
+		Label:    "os/ubuntu",
+		Keywords: []string{"ubuntu"},
+	},
+	{
+		Label:    "os/macos",
+		Keywords: []string{"macos"},
+		Words:    []string{"osx"},
+	},
+	{
+		Label:    "os/centos",
+		Keywords: []string{"centos"},
+	},
+	{
+		Label:    "os/fedora",
+		Keywords: []string{"fedora"},
+	},
+	{
+		Label: "os/suse",
+		Words: []string{"suse", "opensuse"},
+	},
+	{
+		Label:    "os/freebsd",
+		Keywords: []string{"freebsd"},
+	},
+	{
+		Label:    "priority/P1",
+		Keywords: []string{"panic", "invalid memory address or nil pointer"},
+	},
+	{
+		Label:    "DO-NOT-MERGE",
+		Keywords: []string{"do not merge", "do-not-merge", "don't merge"},
+	},
+	{
+		Label:    "WeeklyReport",
+		Keywords: []string{"weekly report", "weeklyreport", "weekreport", "week report"},
+	},
+}
@@ -0,0 +1,227 @@
+// Copyright 2018 The Pouch Robot Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package matcher
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/pouchcontainer/pouchrobot/config"
+	"github.com/pouchcontainer/pouchrobot/utils"
+	"github.com/pouchcontainer/pouchrobot/utils/glob"
+)
+
+const (
+	// ScopeTitle means a rule applies to title of issue or pull request.
+	ScopeTitle = "title"
+
+	// ScopeBody means a rule applies to body of issue or pull request.
+	ScopeBody = "body"
+
+	// ScopeFiles means a rule applies to changed file paths of pull request.
+	ScopeFiles = "files"
+)
+
+// rule is a compiled config.LabelRule.
+type rule struct {
+	label    string
+	scopes   []string
+	keywords []string
+	patterns []*regexp.Regexp
+	excludes []*regexp.Regexp
+}
+
+// Matcher generates labels from text via label rules.
+type Matcher struct {
+	rules []*rule
+}
+
+// New compiles label rules into a brand new Matcher.
+// It returns an error describing the first invalid rule.
+func New(rules []config.LabelRule) (*Matcher, error) {
+	m := &Matcher{}
+	for i, r := range rules {
+		compiled, err := compile(r)
+		if err != nil {
+			return nil, fmt.Errorf("invalid label rule %d (%s): %v", i, r.Label, err)
+		}
+		m.rules = append(m.rules, compiled)
+	}
+	return m, nil
+}
+
+func compile(r config.LabelRule) (*rule, error) {
+	if r.Label == "" {
+		return nil, fmt.Errorf("label is empty")
+	}
+	if len(r.Keywords) == 0 && len(r.Words) == 0 && len(r.Regexes) == 0 && len(r.Paths) == 0 {
+		return nil, fmt.Errorf("no keywords, words, regexes or paths")
+	}
+
+	compiled := &rule{
+		label:  r.Label,
+		scopes: r.Scopes,
+	}
+	if len(compiled.scopes) == 0 {
+		compiled.scopes = []string{ScopeTitle}
+		if len(r.Keywords) == 0 && len(r.Words) == 0 && len(r.Regexes) == 0 {
+			compiled.scopes = []string{ScopeFiles}
+		}
+	}
+	for _, scope := range compiled.scopes {
+		if scope != ScopeTitle && scope != ScopeBody && scope != ScopeFiles {
+			return nil, fmt.Errorf("unknown scope %q", scope)
+		}
+		if scope != ScopeFiles && len(r.Paths) != 0 {
+			return nil, fmt.Errorf("paths only apply to scope %q", ScopeFiles)
+		}
+	}
+
+	for _, path := range r.Paths {
+		re, err := glob.Compile(path)
+		if err != nil {
+			return nil, err
+		}
+		compiled.patterns = append(compiled.patterns, re)
+	}
+
+	for _, keyword := range r.Keywords {
+		if keyword == "" {
+			return nil, fmt.Errorf("empty keyword")
+		}
+		compiled.keywords = append(compiled.keywords, strings.ToLower(keyword))
+	}
+
+	for _, word := range r.Words {
+		if word == "" {
+			return nil, fmt.Errorf("empty word")
+		}
+		// \b does not work for words starting or ending with a non-word
+		// character like ".net" or "fix:", so check the boundary manually.
+		expr := `(?i)(^|[^\pL\pN_])` + regexp.QuoteMeta(word) + `($|[^\pL\pN_])`
+		compiled.patterns = append(compiled.patterns, regexp.MustCompile(expr))
+	}
+
+	for _, expr := range r.Regexes {
+		re, err := regexp.Compile(expr)
+		if err != nil {
+			return nil, err
+		}
+		compiled.patterns = append(compiled.patterns, re)
+	}
+
+	for _, expr := range r.Excludes {
+		re, err := regexp.Compile(expr)
+		if err != nil {
+			return nil, err
+		}
+		compiled.excludes = append(compiled.excludes, re)
+	}
+
+	return compiled, nil
+}
+
+// Match returns labels whose rules apply to scope and match text.
+func (m *Matcher) Match(scope string, text string) []string {
+	var labels []string
+	for _, r := range m.rules {
+		if !utils.SliceContainsElement(r.scopes, scope) {
+			continue
+		}
+		if r.match(text) {
+			labels = append(labels, r.label)
+		}
+	}
+	return sortedUnique(labels)
+}
+
+// MatchFiles returns labels whose rules apply to changed files and match
+// any path in files.
+func (m *Matcher) MatchFiles(files []string) []string {
+	var labels []string
+	for _, file := range files {
+		labels = append(labels, m.Match(ScopeFiles, file)...)
+	}
+	return sortedUnique(labels)
+}
+
+// FileLabels returns labels whose rules only apply to changed files. They
+// are expected to follow changes of files in a pull request.
+func (m *Matcher) FileLabels() []string {
+	var labels []string
+	for _, r := range m.rules {
+		if len(r.scopes) == 1 && r.scopes[0] == ScopeFiles {
+			labels = append(labels, r.label)
+		}
+	}
+	return sortedUnique(labels)
+}
+
+func (r *rule) match(text string) bool {
+	for _, exclude := range r.excludes {
+		if exclude.MatchString(text) {
+			return false
+		}
+	}
+
+	lowerCaseText := strings.ToLower(text)
+	for _, keyword := range r.keywords {
+		if strings.Contains(lowerCaseText, keyword) {
+			return true
+		}
+	}
+	for _, pattern := range r.patterns {
+		if pattern.MatchString(text) {
+			return true
+		}
+	}
+	return false
+}
+
+func sortedUnique(labels []string) []string {
+	labels = utils.UniqueElementSlice(labels)
+	sort.Strings(labels)
+	return labels
+}
+
+var (
+	mu      sync.RWMutex
+	current *Matcher
+)
+
+func init() {
+	m, err := New(DefaultRules)
+	if err != nil {
+		panic(err)
+	}
+	current = m
+}
+
+// Current returns the matcher used by robot to generate labels.
+func Current() *Matcher {
+	mu.RLock()
+	defer mu.RUnlock()
+	return current
+}
+
+// SetCurrent replaces the matcher used by robot to generate labels.
+func SetCurrent(m *Matcher) {
+	mu.Lock()
+	defer mu.Unlock()
+	current = m
+}
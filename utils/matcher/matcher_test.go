@@ -0,0 +1,99 @@
+// Copyright 2018 The Pouch Robot Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package matcher
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/pouchcontainer/pouchrobot/config"
+)
+
+func TestMatch(t *testing.T) {
+	m, err := New([]config.LabelRule{
+		{Label: "areas/test", Words: []string{"ci", "test"}},
+		{Label: "areas/log", Words: []string{"log"}, Excludes: []string{`(?i)log\s*in`}},
+		{Label: "kind/bug", Keywords: []string{"fix:"}, Scopes: []string{ScopeTitle, ScopeBody}},
+		{Label: "os/windows", Words: []string{".net"}},
+		{Label: "areas/network", Regexes: []string{`^network/`}, Scopes: []string{ScopeFiles}},
+		{Label: "areas/docs", Paths: []string{"docs/**", "*.md"}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name  string
+		scope string
+		text  string
+		want  []string
+	}{
+		{name: "whole word", scope: ScopeTitle, text: "fix: flaky CI on master", want: []string{"areas/test", "kind/bug"}},
+		{name: "word inside another word", scope: ScopeTitle, text: "be more specific about catalog", want: nil},
+		{name: "exclusion", scope: ScopeTitle, text: "cannot log in registry", want: nil},
+		{name: "word with punctuation", scope: ScopeTitle, text: "support .net runtime", want: []string{"os/windows"}},
+		{name: "scope mismatch", scope: ScopeBody, text: "add test for ci", want: nil},
+		{name: "files scope", scope: ScopeFiles, text: "network/bridge.go", want: []string{"areas/network"}},
+		{name: "paths", scope: ScopeFiles, text: "cli/README.md", want: []string{"areas/docs"}},
+		{name: "paths in title", scope: ScopeTitle, text: "docs/api.md", want: nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := m.Match(tt.scope, tt.text); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Match() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFileLabels(t *testing.T) {
+	m, err := New([]config.LabelRule{
+		{Label: "areas/test", Words: []string{"ci"}},
+		{Label: "areas/network", Regexes: []string{`^network/`}, Scopes: []string{ScopeTitle, ScopeFiles}},
+		{Label: "dependencies", Paths: []string{"vendor/**"}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := m.FileLabels(), []string{"dependencies"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("FileLabels() = %v, want %v", got, want)
+	}
+}
+
+func TestNewInvalidRule(t *testing.T) {
+	tests := []struct {
+		name string
+		rule config.LabelRule
+	}{
+		{name: "no label", rule: config.LabelRule{Words: []string{"ci"}}},
+		{name: "no pattern", rule: config.LabelRule{Label: "areas/test"}},
+		{name: "bad regex", rule: config.LabelRule{Label: "areas/test", Regexes: []string{"("}}},
+		{name: "paths in title scope", rule: config.LabelRule{Label: "areas/docs", Paths: []string{"docs/**"}, Scopes: []string{ScopeTitle}}},
+		{name: "unknown scope", rule: config.LabelRule{Label: "areas/test", Words: []string{"ci"}, Scopes: []string{"comment"}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := New([]config.LabelRule{tt.rule}); err == nil {
+				t.Errorf("New() expected error")
+			}
+		})
+	}
+}
+
+func TestDefaultRules(t *testing.T) {
+	if _, err := New(DefaultRules); err != nil {
+		t.Errorf("DefaultRules are invalid: %v", err)
+	}
+}
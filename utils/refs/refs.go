@@ -0,0 +1,53 @@
+// Copyright 2018 The Pouch Robot Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package refs
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// fixRegex matches a closing keyword followed by an issue reference, like
+// "fixes #123", "Closes: pouchcontainer/pouch#123" or
+// "resolved https://github.com/pouchcontainer/pouch/issues/123".
+var fixRegex = regexp.MustCompile(`(?i)\b(?:close[sd]?|fix(?:e[sd])?|resolve[sd]?):?\s+(?:([\w.-]+/[\w.-]+)?#(\d+)|https?://github\.com/([\w.-]+/[\w.-]+)/issues/(\d+))\b`)
+
+// Parse returns numbers of issues in repo owner/repo which text claims to fix
+// with closing keywords, in the order they first appear. References to
+// issues of other repos are ignored.
+func Parse(owner, repo, text string) []int {
+	fullName := owner + "/" + repo
+
+	var result []int
+	seen := map[int]bool{}
+	for _, match := range fixRegex.FindAllStringSubmatch(text, -1) {
+		name, number := match[1], match[2]
+		if match[4] != "" {
+			name, number = match[3], match[4]
+		}
+		if name != "" && !strings.EqualFold(name, fullName) {
+			continue
+		}
+
+		num, err := strconv.Atoi(number)
+		if err != nil || num <= 0 || seen[num] {
+			continue
+		}
+		seen[num] = true
+		result = append(result, num)
+	}
+	return result
+}
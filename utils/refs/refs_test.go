@@ -0,0 +1,43 @@
+// Copyright 2018 The Pouch Robot Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package refs
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+		want []int
+	}{
+		{name: "none", text: "refactor network module, see #12", want: nil},
+		{name: "keywords", text: "fixes #1\nCloses #2, resolved #3 and fix: #4", want: []int{1, 2, 3, 4}},
+		{name: "same repo", text: "Fixes pouchcontainer/pouch#5", want: []int{5}},
+		{name: "other repo", text: "fixes alibaba/pouch#6", want: nil},
+		{name: "url", text: "close https://github.com/pouchcontainer/pouch/issues/7", want: []int{7}},
+		{name: "pull url", text: "close https://github.com/pouchcontainer/pouch/pull/8", want: nil},
+		{name: "duplicated", text: "fix #9, fixed #9", want: []int{9}},
+		{name: "not a keyword", text: "prefixes #10 and suffixed #11", want: nil},
+	}
+
+	for _, test := range tests {
+		if got := Parse("pouchcontainer", "pouch", test.text); !reflect.DeepEqual(got, test.want) {
+			t.Errorf("%s: Parse(%q) = %v, want %v", test.name, test.text, got, test.want)
+		}
+	}
+}
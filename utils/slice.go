@@ -14,6 +14,10 @@
 
 package utils
 
+import (
+	"strings"
+)
+
 // SliceContainsElement returns true if data is in current slice.
 func SliceContainsElement(input []string, data string) bool {
 	for _, value := range input {
@@ -24,6 +28,16 @@ func SliceContainsElement(input []string, data string) bool {
 	return false
 }
 
+// SliceContainsElementFold returns true if data is in current slice, ignoring case.
+func SliceContainsElementFold(input []string, data string) bool {
+	for _, value := range input {
+		if strings.EqualFold(value, data) {
+			return true
+		}
+	}
+	return false
+}
+
 // UniqueElementSlice returns a slice with no element duplicated.
 func UniqueElementSlice(data []string) []string {
 	if data == nil {
@@ -63,6 +63,50 @@ func TestSliceContainsElement(t *testing.T) {
 	}
 }
 
+func TestSliceContainsElementFold(t *testing.T) {
+	type args struct {
+		input []string
+		data  string
+	}
+	tests := []struct {
+		name string
+		args args
+		want bool
+	}{
+		{
+			name: "same case",
+			args: args{
+				input: []string{"kind/bug", "LGTM"},
+				data:  "LGTM",
+			},
+			want: true,
+		},
+		{
+			name: "different case",
+			args: args{
+				input: []string{"kind/bug", "LGTM"},
+				data:  "lgtm",
+			},
+			want: true,
+		},
+		{
+			name: "un-contains",
+			args: args{
+				input: []string{"kind/bug", "LGTM"},
+				data:  "kind",
+			},
+			want: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := SliceContainsElementFold(tt.args.input, tt.args.data); got != tt.want {
+				t.Errorf("SliceContainsElementFold() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
 func TestUniqueElementSlice(t *testing.T) {
 	type args struct {
 		data []string
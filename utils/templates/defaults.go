@@ -0,0 +1,273 @@
+// Copyright 2018 The Pouch Robot Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package templates
+
+import "github.com/pouchcontainer/pouchrobot/utils"
+
+// defaults contains built-in templates keyed by language and template name.
+// They are used when no template file is found in the template directory.
+var defaults = map[string]map[string]string{
+	"en": {
+		utils.IssueTitleTooShortID: `Thanks for your contribution. 🍻 @{{.Author}}
+While we thought **ISSUE TITLE** could be more specific, longer than {{.MinLength}} chars.
+Please edit issue title instead of opening a new one.
+More details, please refer to https://github.com/{{.Owner}}/{{.Repo}}/blob/master/CONTRIBUTING.md`,
+
+		utils.IssueDescriptionTooShortID: `Thanks for your contribution. 🍻 @{{.Author}}
+While we thought **ISSUE DESCRIPTION** could be more specific, longer than {{.MinLength}} chars.
+Here is a template at https://github.com/{{.Owner}}/{{.Repo}}/blob/master/.github/ISSUE_TEMPLATE.md
+Please edit this issue description instead of opening a new one.
+More details, please refer to https://github.com/{{.Owner}}/{{.Repo}}/blob/master/CONTRIBUTING.md`,
+
+		utils.IssueTemplateSectionsID: `Thanks for your report. 🍻 @{{.Author}}
+The following sections of issue template **{{.Template}}** are missing or still contain placeholder text:
+{{range .Missing}}
+- {{.}}{{end}}
+
+Please edit this issue description instead of opening a new one.`,
+
+		utils.PRTitleTooShortID: `Thanks for your contribution. 🍻  @{{.Author}}
+While we thought **PR TITLE** could be more specific, longer than {{.MinLength}} chars.
+Please edit this PR title instead of opening a new one.
+More details, please refer to https://github.com/{{.Owner}}/{{.Repo}}/blob/master/CONTRIBUTING.md`,
+
+		utils.PRDescriptionTooShortID: `Thanks for your contribution. 🍻  @{{.Author}}
+While we thought **PR Description** could be more specific, longer than {{.MinLength}} chars.
+Please edit this PR description instead of opening a new one.
+More details, please refer to https://github.com/{{.Owner}}/{{.Repo}}/blob/master/CONTRIBUTING.md`,
+
+		utils.PRTemplateSectionsID: `Thanks for your contribution. 🍻  @{{.Author}}
+The following sections of pull request template are missing or still contain placeholder text:
+{{range .Missing}}
+- {{.}}{{end}}
+
+Please edit this PR description instead of opening a new one.`,
+
+		utils.PRNeedsSignOffID: `@{{.Author}} Thanks for your contribution. 🍻
+Please sign off in each of your commits.`,
+
+		utils.IssueNeedP1CommentID: `Thanks for your report, @{{.Author}}
+😱 This is a **priority/P1** issue which is highest.
+Seems to be severe enough.
+ping @{{.Owner}}/{{.Repo}} , PTAL.
+`,
+
+		utils.FirstCommitCommentID: `We found this is your first time to contribute to {{.Repo}}, @{{.Author}}
+👏  We really appreciate it.
+Just remind that you have read the contribution guide: https://github.com/{{.Owner}}/{{.Repo}}/blob/master/CONTRIBUTING.md
+If you didn't, you should do that first. If done, welcome again and please enjoy hacking! 🍻
+`,
+
+		utils.PRConflictCommentID: `ping @{{.Author}}
+Conflict happens after merging a previous commit.
+Please rebase the branch against master and push it back again. Thanks a lot.
+`,
+
+		utils.PRGapCommentID: `ping @{{.Author}}
+We found that this PR is {{.Gap}} commits, which is more than {{.Threshold}} commits, behind master.
+Please rebase the branch against master and push it back again. Thanks a lot.
+`,
+
+		utils.CIFailsCommentID: `ping @{{.Author}}
+
+CI fails according integration system.
+Please refer to the CI failure Details button to corresponding test, and update your PR to pass CI.
+
+If this is flaky test, welcome to track this with [profiling an issue](https://github.com/{{.Owner}}/{{.Repo}}/issues/new).
+
+build url: {{.BuildURL}}
+build duration: {{.Duration}}s
+`,
+
+		utils.LifecycleStaleCommentID: `This {{.Kind}} has had no activity for {{.Days}} days, so it is marked as **lifecycle/stale**. @{{.Author}}
+It will be marked as **lifecycle/rotten** after another {{.NextDays}} days without activity.
+Any comment or update removes the stale state. Comment ` + "`/remove-lifecycle stale`" + ` to remove it explicitly.
+`,
+
+		utils.LifecycleRottenCommentID: `This {{.Kind}} has been stale for {{.Days}} days, so it is marked as **lifecycle/rotten**. @{{.Author}}
+It will be closed after another {{.NextDays}} days without activity.
+Any comment or update removes the rotten state. Comment ` + "`/remove-lifecycle rotten`" + ` to remove it explicitly.
+`,
+
+		utils.LifecycleClosedCommentID: `This {{.Kind}} has been rotten for {{.Days}} days without activity, so it is closed. @{{.Author}}
+Feel free to reopen it if it is still relevant.
+`,
+
+		utils.MoreInfoReminderCommentID: `ping @{{.Author}}
+This issue still needs more information after {{.Days}} days. Please update the title or description, or reply here.
+It will be closed if there is no response in {{.NextDays}} days.
+`,
+
+		utils.MoreInfoClosedCommentID: `There has been no response from @{{.Author}} for {{.Days}} days, so this issue is closed since more information is needed.
+Feel free to reopen it with more details.
+`,
+
+		utils.PRSizeCommentID: `Size of this PR is **{{.Label}}**, {{.Total}} lines are changed.
+
+|kind|changed lines|
+|:-|-:|
+|code|{{.Code}}|
+|tests|{{.Tests}}|
+|docs|{{.Docs}}|
+|vendored or generated (not counted)|{{.Ignored}}|
+`,
+
+		utils.OwnersApprovalCommentID: `ping @{{.Author}}
+This PR needs approval from an approver of each of the following directories:
+{{range .Missing}}
+- ` + "`{{.Name}}`" + `: {{range $i, $approver := .Approvers}}{{if $i}}, {{end}}{{$approver}}{{end}}{{end}}
+{{if .Reviewers}}
+Suggested reviewers: {{range $i, $reviewer := .Reviewers}}{{if $i}}, {{end}}@{{$reviewer}}{{end}}
+{{end}}
+This comment will be updated once approvals change.
+`,
+
+		utils.LinkedPRCommentID: `{{if .Merged}}This issue is fixed by #{{.Number}} from @{{.Author}}, which has been merged.{{else}}@{{.Author}} is working on this issue in #{{.Number}}.{{end}}
+`,
+
+		utils.PRMergedThanksID: `Merged. Thanks for your contribution, @{{.Author}} 🍻
+Feel free to pick another issue from https://github.com/{{.Owner}}/{{.Repo}}/issues if you would like to keep hacking.
+`,
+
+		utils.BugWithoutIssueID: `@{{.Author}} This PR is a bug fix, but references no issue.
+Please describe the bug in an issue and add "fixes #xxxx" to the PR description, so that the issue is closed once the PR is merged.
+`,
+	},
+	"zh": {
+		utils.IssueTitleTooShortID: `感谢你的贡献。🍻 @{{.Author}}
+我们认为 **ISSUE 标题** 可以更具体一些，长度请超过 {{.MinLength}} 个字符。
+请直接编辑该 issue 的标题，不要重新创建新的 issue。
+更多细节请参考 https://github.com/{{.Owner}}/{{.Repo}}/blob/master/CONTRIBUTING.md`,
+
+		utils.IssueDescriptionTooShortID: `感谢你的贡献。🍻 @{{.Author}}
+我们认为 **ISSUE 描述** 可以更具体一些，长度请超过 {{.MinLength}} 个字符。
+issue 模板请参考 https://github.com/{{.Owner}}/{{.Repo}}/blob/master/.github/ISSUE_TEMPLATE.md
+请直接编辑该 issue 的描述，不要重新创建新的 issue。
+更多细节请参考 https://github.com/{{.Owner}}/{{.Repo}}/blob/master/CONTRIBUTING.md`,
+
+		utils.IssueTemplateSectionsID: `感谢你的反馈。🍻 @{{.Author}}
+issue 模板 **{{.Template}}** 中的以下部分缺失或仍是模板中的占位文字：
+{{range .Missing}}
+- {{.}}{{end}}
+
+请直接编辑该 issue 的描述，不要重新创建新的 issue。`,
+
+		utils.PRTitleTooShortID: `感谢你的贡献。🍻  @{{.Author}}
+我们认为 **PR 标题** 可以更具体一些，长度请超过 {{.MinLength}} 个字符。
+请直接编辑该 PR 的标题，不要重新创建新的 PR。
+更多细节请参考 https://github.com/{{.Owner}}/{{.Repo}}/blob/master/CONTRIBUTING.md`,
+
+		utils.PRDescriptionTooShortID: `感谢你的贡献。🍻  @{{.Author}}
+我们认为 **PR 描述** 可以更具体一些，长度请超过 {{.MinLength}} 个字符。
+请直接编辑该 PR 的描述，不要重新创建新的 PR。
+更多细节请参考 https://github.com/{{.Owner}}/{{.Repo}}/blob/master/CONTRIBUTING.md`,
+
+		utils.PRTemplateSectionsID: `感谢你的贡献。🍻  @{{.Author}}
+PR 模板中的以下部分缺失或仍是模板中的占位文字：
+{{range .Missing}}
+- {{.}}{{end}}
+
+请直接编辑该 PR 的描述，不要重新创建新的 PR。`,
+
+		utils.PRNeedsSignOffID: `@{{.Author}} 感谢你的贡献。🍻
+请在每一个 commit 中签名（sign off）。`,
+
+		utils.IssueNeedP1CommentID: `感谢你的反馈，@{{.Author}}
+😱 这是一个最高优先级 **priority/P1** 的 issue，情况看起来比较严重。
+ping @{{.Owner}}/{{.Repo}} ，请尽快查看。
+`,
+
+		utils.FirstCommitCommentID: `我们发现这是你第一次为 {{.Repo}} 做贡献，@{{.Author}}
+👏  非常感谢！
+提醒一下，请确认你已经阅读过贡献指南：https://github.com/{{.Owner}}/{{.Repo}}/blob/master/CONTRIBUTING.md
+如果还没有，请先阅读。如果已经读过，再次欢迎你，祝你开发愉快！🍻
+`,
+
+		utils.PRConflictCommentID: `ping @{{.Author}}
+合并之前的提交后出现了冲突。
+请将分支 rebase 到 master 上并重新推送，非常感谢。
+`,
+
+		utils.PRGapCommentID: `ping @{{.Author}}
+我们发现该 PR 落后 master {{.Gap}} 个 commit，超过了 {{.Threshold}} 个 commit。
+请将分支 rebase 到 master 上并重新推送，非常感谢。
+`,
+
+		utils.CIFailsCommentID: `ping @{{.Author}}
+
+集成系统显示 CI 失败。
+请点击 CI 失败的 Details 按钮查看对应的测试，并更新 PR 使 CI 通过。
+
+如果这是一个不稳定的测试，欢迎 [创建 issue](https://github.com/{{.Owner}}/{{.Repo}}/issues/new) 进行跟踪。
+
+build url: {{.BuildURL}}
+build duration: {{.Duration}}s
+`,
+
+		utils.LifecycleStaleCommentID: `该 {{.Kind}} 已经 {{.Days}} 天没有任何动态，因此被标记为 **lifecycle/stale**。@{{.Author}}
+如果接下来 {{.NextDays}} 天仍没有动态，将被标记为 **lifecycle/rotten**。
+任何评论或更新都会移除该状态，也可以评论 ` + "`/remove-lifecycle stale`" + ` 直接移除。
+`,
+
+		utils.LifecycleRottenCommentID: `该 {{.Kind}} 已经处于 stale 状态 {{.Days}} 天，因此被标记为 **lifecycle/rotten**。@{{.Author}}
+如果接下来 {{.NextDays}} 天仍没有动态，将被关闭。
+任何评论或更新都会移除该状态，也可以评论 ` + "`/remove-lifecycle rotten`" + ` 直接移除。
+`,
+
+		utils.LifecycleClosedCommentID: `该 {{.Kind}} 已经处于 rotten 状态 {{.Days}} 天且没有任何动态，因此被关闭。@{{.Author}}
+如果仍然需要，请重新打开。
+`,
+
+		utils.MoreInfoReminderCommentID: `ping @{{.Author}}
+该 issue 在 {{.Days}} 天后仍然需要更多信息，请更新标题或描述，或者在这里回复。
+如果 {{.NextDays}} 天内没有回复，该 issue 将被关闭。
+`,
+
+		utils.MoreInfoClosedCommentID: `@{{.Author}} 已经 {{.Days}} 天没有回复，由于缺少必要信息，该 issue 被关闭。
+欢迎补充详细信息后重新打开。
+`,
+
+		utils.PRSizeCommentID: `该 PR 的大小为 **{{.Label}}**，共修改 {{.Total}} 行。
+
+|类型|修改行数|
+|:-|-:|
+|代码|{{.Code}}|
+|测试|{{.Tests}}|
+|文档|{{.Docs}}|
+|vendor 或自动生成（不计入）|{{.Ignored}}|
+`,
+
+		utils.OwnersApprovalCommentID: `ping @{{.Author}}
+该 PR 需要以下每个目录中至少一位 approver 的批准：
+{{range .Missing}}
+- ` + "`{{.Name}}`" + `：{{range $i, $approver := .Approvers}}{{if $i}}, {{end}}{{$approver}}{{end}}{{end}}
+{{if .Reviewers}}
+建议的 reviewer：{{range $i, $reviewer := .Reviewers}}{{if $i}}, {{end}}@{{$reviewer}}{{end}}
+{{end}}
+批准状态变化后该评论会自动更新。
+`,
+
+		utils.LinkedPRCommentID: `{{if .Merged}}该 issue 已被 @{{.Author}} 的 #{{.Number}} 修复，该 PR 已合并。{{else}}@{{.Author}} 正在 #{{.Number}} 中修复该 issue。{{end}}
+`,
+
+		utils.PRMergedThanksID: `已合并，感谢你的贡献，@{{.Author}} 🍻
+如果你愿意继续参与，欢迎从 https://github.com/{{.Owner}}/{{.Repo}}/issues 中挑选下一个 issue。
+`,
+
+		utils.BugWithoutIssueID: `@{{.Author}} 该 PR 修复了一个 bug，但没有关联任何 issue。
+请在 issue 中描述该 bug，并在 PR 描述中添加 "fixes #xxxx"，这样该 PR 合并后 issue 会被自动关闭。
+`,
+	},
+}
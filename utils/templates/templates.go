@@ -0,0 +1,178 @@
+// Copyright 2018 The Pouch Robot Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package templates
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync"
+	"text/template"
+
+	"github.com/sirupsen/logrus"
+)
+
+// DefaultLanguage is the language used when no language is configured.
+const DefaultLanguage = "en"
+
+// fileSuffix is the suffix of template files in template directory.
+const fileSuffix = ".tmpl"
+
+// Data contains the common fields which could be referred in comment templates.
+// Templates of a specific feature could be rendered with other data types as well.
+type Data struct {
+	// Author is the GitHub login of the issue or pull request author.
+	Author string
+
+	// Owner is the organization of open source project.
+	Owner string
+
+	// Repo is the repository name.
+	Repo string
+
+	// MinLength is the minimum length of a title or description.
+	MinLength int
+
+	// Gap is the number of commits a pull request is behind master.
+	Gap int
+
+	// Threshold is the number of commits behind master which needs a rebase.
+	Threshold int
+
+	// BuildURL is the link of a CI build.
+	BuildURL string
+
+	// Duration is the duration of a CI build in seconds.
+	Duration int
+}
+
+// Renderer renders robot comments via text/template.
+//
+// A template named NAME in language LANG is looked up in the following order:
+//
+//	<dir>/<LANG>/NAME.tmpl
+//	<dir>/NAME.tmpl
+//	built-in default of LANG
+//	built-in default of DefaultLanguage
+type Renderer struct {
+	sync.Mutex
+
+	// dir is the directory to load user defined templates from.
+	dir string
+
+	// language is the selected language of comments.
+	language string
+
+	// cache caches parsed templates keyed by language and name.
+	cache map[string]*template.Template
+}
+
+// New initializes a brand new template renderer.
+func New(dir, language string) *Renderer {
+	if language == "" {
+		language = DefaultLanguage
+	}
+	return &Renderer{
+		dir:      dir,
+		language: language,
+		cache:    map[string]*template.Template{},
+	}
+}
+
+// Reset switches template directory and language, and drops parsed templates
+// so that changed template files are loaded again.
+func (r *Renderer) Reset(dir, language string) {
+	if language == "" {
+		language = DefaultLanguage
+	}
+
+	r.Lock()
+	defer r.Unlock()
+	r.dir = dir
+	r.language = language
+	r.cache = map[string]*template.Template{}
+}
+
+// Language returns the language selected for comments.
+func (r *Renderer) Language() string {
+	r.Lock()
+	defer r.Unlock()
+	return r.language
+}
+
+// Render renders template name with data in the selected language.
+func (r *Renderer) Render(name string, data interface{}) (string, error) {
+	tmpl, err := r.lookup(name)
+	if err != nil {
+		return "", err
+	}
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return "", fmt.Errorf("failed to render template %s: %v", name, err)
+	}
+	return buf.String(), nil
+}
+
+func (r *Renderer) lookup(name string) (*template.Template, error) {
+	r.Lock()
+	defer r.Unlock()
+
+	key := r.language + "/" + name
+	if tmpl, ok := r.cache[key]; ok {
+		return tmpl, nil
+	}
+
+	text, err := r.load(name)
+	if err != nil {
+		return nil, err
+	}
+
+	tmpl, err := template.New(name).Option("missingkey=error").Parse(text)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse template %s: %v", name, err)
+	}
+	r.cache[key] = tmpl
+	return tmpl, nil
+}
+
+// load returns the raw text of template name.
+func (r *Renderer) load(name string) (string, error) {
+	if r.dir != "" {
+		for _, path := range []string{
+			filepath.Join(r.dir, r.language, name+fileSuffix),
+			filepath.Join(r.dir, name+fileSuffix),
+		} {
+			data, err := ioutil.ReadFile(path)
+			if err == nil {
+				logrus.Debugf("load template %s from %s", name, path)
+				return string(data), nil
+			}
+			if !os.IsNotExist(err) {
+				return "", fmt.Errorf("failed to read template file %s: %v", path, err)
+			}
+		}
+	}
+
+	if text, ok := defaults[r.language][name]; ok {
+		return text, nil
+	}
+	if text, ok := defaults[DefaultLanguage][name]; ok {
+		return text, nil
+	}
+	return "", fmt.Errorf("template %s not found", name)
+}
@@ -0,0 +1,123 @@
+// Copyright 2018 The Pouch Robot Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package templates
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/pouchcontainer/pouchrobot/utils"
+)
+
+func TestRender(t *testing.T) {
+	dir, err := ioutil.TempDir("", "templates")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	if err := os.MkdirAll(filepath.Join(dir, "zh"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := ioutil.WriteFile(filepath.Join(dir, "zh", utils.PRConflictCommentID+fileSuffix), []byte("冲突 @{{.Author}}"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := ioutil.WriteFile(filepath.Join(dir, utils.PRGapCommentID+fileSuffix), []byte("gap {{.Gap}}/{{.Threshold}}"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	data := Data{Author: "foo", Gap: 25, Threshold: 20}
+	tests := []struct {
+		name     string
+		language string
+		template string
+		want     string
+	}{
+		{
+			name:     "language variant in template dir",
+			language: "zh",
+			template: utils.PRConflictCommentID,
+			want:     "冲突 @foo",
+		},
+		{
+			name:     "template dir without language variant",
+			language: "zh",
+			template: utils.PRGapCommentID,
+			want:     "gap 25/20",
+		},
+		{
+			name:     "built-in default of language",
+			language: "zh",
+			template: utils.PRNeedsSignOffID,
+			want:     "@foo 感谢你的贡献。",
+		},
+		{
+			name:     "built-in default of unknown language",
+			language: "fr",
+			template: utils.PRConflictCommentID,
+			want:     "ping @foo\nConflict happens",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := New(dir, tt.language).Render(tt.template, data)
+			if err != nil {
+				t.Fatalf("Render() error = %v", err)
+			}
+			if !strings.HasPrefix(got, tt.want) {
+				t.Errorf("Render() = %q, want prefix %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRenderUnknownTemplate(t *testing.T) {
+	if _, err := New("", "").Render("no-such-template", Data{}); err == nil {
+		t.Errorf("Render() expected error for unknown template")
+	}
+}
+
+func TestReset(t *testing.T) {
+	dir, err := ioutil.TempDir("", "templates")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, utils.PRGapCommentID+fileSuffix)
+	if err := ioutil.WriteFile(path, []byte("gap {{.Gap}}"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	r := New(dir, "")
+	if got, err := r.Render(utils.PRGapCommentID, Data{Gap: 25}); err != nil || got != "gap 25" {
+		t.Fatalf("Render() = %q, %v, want %q", got, err, "gap 25")
+	}
+
+	// parsed templates are cached until reset.
+	if err := ioutil.WriteFile(path, []byte("behind {{.Gap}}"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	r.Reset(dir, "zh")
+	if got, err := r.Render(utils.PRGapCommentID, Data{Gap: 25}); err != nil || got != "behind 25" {
+		t.Errorf("Render() after Reset() = %q, %v, want %q", got, err, "behind 25")
+	}
+	if r.Language() != "zh" {
+		t.Errorf("Language() after Reset() = %s, want zh", r.Language())
+	}
+}
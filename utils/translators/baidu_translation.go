@@ -174,3 +174,16 @@ func (bt baiduTranslator) Translate(text string, withOrigin bool) string {
 	}
 	return ""
 }
+
+// Check translates a sample text to check whether Baidu API accepts the
+// appid and key of translator.
+func (bt baiduTranslator) Check() error {
+	trans, err := bt.translateLine("你好，世界")
+	if err != nil {
+		return err
+	}
+	if trans == "" {
+		return errors.New("got empty translation of sample text")
+	}
+	return nil
+}
@@ -18,3 +18,9 @@ package translators
 type Translator interface {
 	Translate(string, bool) string
 }
+
+// Checker is implemented by translators which could check whether they are
+// able to translate, like whether credentials are accepted by the service.
+type Checker interface {
+	Check() error
+}
@@ -15,7 +15,7 @@
 package utils
 
 import (
-	"fmt"
+	"time"
 	"unicode"
 )
 
@@ -25,141 +25,126 @@ var CIFailureLable = "CI-failure"
 // PRConflictLabel is a label which means conflict for pull request.
 var PRConflictLabel = "conflict/needs-rebase"
 
-//PRGapLabel is a label which means gap for pull request.
+// PRGapLabel is a label which means gap for pull request.
 var PRGapLabel = "gap/needs-rebase"
 
 // PriorityP1Label is a lable which represent P1 priority which is highest.
 var PriorityP1Label = "priority/P1"
 
+// LGTMLabel is a label which means pull request is approved by maintainers.
+var LGTMLabel = "LGTM"
+
+// ChangesRequestedLabel is a label which means reviewers request changes on pull request.
+var ChangesRequestedLabel = "status/changes-requested"
+
+// MoreInfoNeededLabel is a label which means more information is needed from author.
+var MoreInfoNeededLabel = "status/more-info-needed"
+
+// StaleLabel is a label which means issue or pull request has no activity for a long time.
+var StaleLabel = "lifecycle/stale"
+
+// RottenLabel is a label which means a stale issue or pull request still has no activity.
+var RottenLabel = "lifecycle/rotten"
+
+// FrozenLabel is a label which exempts issue or pull request from becoming stale.
+var FrozenLabel = "lifecycle/frozen"
+
+// BugLabel is a label which means issue or pull request is about a bug.
+var BugLabel = "kind/bug"
+
+// PRInProgressLabel is a label which means an open pull request fixes the issue.
+var PRInProgressLabel = "status/pr-in-progress"
+
+// FixedLabel is a label which means a merged pull request fixes the issue.
+var FixedLabel = "status/fixed"
+
 // SizeLabelPrefix presents the prefix of size label name.
 var SizeLabelPrefix = "size/"
 
-// IssueTitleTooShortSubStr is a sub string used to construct the comment.
-var IssueTitleTooShortSubStr = `While we thought **ISSUE TITLE** could be more specific, longer than 20 chars.
-Please edit issue title instead of opening a new one.
-More details, please refer to https://github.com/%s/%s/blob/master/CONTRIBUTING.md`
+// The following IDs identify each kind of comment posted by robot.
+// They are rendered into hidden markers by gh.CommentMarker, so that robot can
+// find its own comments again without matching on the comment text.
+// They are the names of comment templates in package utils/templates as well.
+const (
+	// IssueTitleTooShortID identifies the comment for a too short issue title.
+	IssueTitleTooShortID = "issue-title-too-short"
 
-// IssueTitleTooShort is a string used to construct the comment.
-var IssueTitleTooShort = fmt.Sprintf(
-	"Thanks for your contribution. 🍻 @%s \n%s",
-	"%s",
-	IssueTitleTooShortSubStr,
-)
+	// IssueDescriptionTooShortID identifies the comment for a too short issue description.
+	IssueDescriptionTooShortID = "issue-description-too-short"
 
-// IssueDescriptionTooShortSubStr is a sub string used to construct the comment.
-var IssueDescriptionTooShortSubStr = `While we thought **ISSUE DESCRIPTION** could be more specific, longer than 100 chars.
-Here is a template at https://github.com/%s/%s/blob/master/.github/ISSUE_TEMPLATE.md
-Please edit this issue description instead of opening a new one.
-More details, please refer to https://github.com/%s/%s/blob/master/CONTRIBUTING.md`
-
-// IssueDescriptionTooShort is a string used to construct the comment.
-var IssueDescriptionTooShort = fmt.Sprintf(
-	"Thanks for your contribution. 🍻 @%s \n%s",
-	"%s",
-	IssueDescriptionTooShortSubStr,
-)
+	// IssueTemplateSectionsID identifies the comment listing issue template sections missing from an issue.
+	IssueTemplateSectionsID = "issue-template-sections"
 
-// PRTitleTooShortSubStr is a sub string used to construct the comment.
-var PRTitleTooShortSubStr = `While we thought **PR TITLE** could be more specific, longer than 20 chars.
-Please edit this PR title instead of opening a new one.
-More details, please refer to https://github.com/%s/%s/blob/master/CONTRIBUTING.md`
+	// PRTitleTooShortID identifies the comment for a too short pull request title.
+	PRTitleTooShortID = "pr-title-too-short"
 
-// PRTitleTooShort is a string used to construct the comment.
-var PRTitleTooShort = fmt.Sprintf(
-	"Thanks for your contribution. 🍻  @%s \n%s",
-	"%s",
-	PRTitleTooShortSubStr,
-)
+	// PRDescriptionTooShortID identifies the comment for a too short pull request description.
+	PRDescriptionTooShortID = "pr-description-too-short"
 
-// PRDescriptionTooShortSubStr is a sub string used to construct the comment.
-var PRDescriptionTooShortSubStr = `While we thought **PR Description** could be more specific, longer than 100 chars.
-Please edit this PR title instead of opening a new one.
-More details, please refer to https://github.com/%s/%s/blob/master/CONTRIBUTING.md`
+	// PRTemplateSectionsID identifies the comment listing pull request template sections missing from a pull request.
+	PRTemplateSectionsID = "pr-template-sections"
 
-// PRDescriptionTooShort is a string used to construct the comment.
-var PRDescriptionTooShort = fmt.Sprintf(
-	"Thanks for your contribution. 🍻  @%s \n%s",
-	"%s",
-	PRDescriptionTooShortSubStr,
-)
+	// PRNeedsSignOffID identifies the comment reminding contributor to sign off.
+	PRNeedsSignOffID = "pr-needs-signoff"
 
-// PRNeedsSignOffStr is a string used to remind user to sign off.
-var PRNeedsSignOffStr = ` Thanks for your contribution. 🍻
-Please sign off in each of your commits.`
+	// IssueNeedP1CommentID identifies the comment on a priority/P1 issue.
+	IssueNeedP1CommentID = "issue-priority-p1"
 
-// PRNeedsSignOff is a string used to remind user to sign off.
-var PRNeedsSignOff = fmt.Sprintf(
-	"@%s %s",
-	"%s",
-	PRNeedsSignOffStr,
-)
+	// FirstCommitCommentID identifies the comment thanking a first time contributor.
+	FirstCommitCommentID = "first-contribution"
 
-// IssueNeedP1CommentSubStr is a string used to attach comment on P1 issue.
-var IssueNeedP1CommentSubStr = `😱 This is a **priority/P1** issue which is highest.
-Seems to be severe enough.
-ping @%s/%s , PTAL.
-`
-
-// IssueNeedP1Comment is a string used to attach comment on P1 issue.
-var IssueNeedP1Comment = fmt.Sprintf(
-	"Thanks for your report, @%s \n%s",
-	"%s",
-	IssueNeedP1CommentSubStr,
-)
+	// PRConflictCommentID identifies the comment on a conflicting pull request.
+	PRConflictCommentID = "pr-conflict"
 
-// FirstCommitCommentSubStr is a string which is substring of FirstCommitComment
-var FirstCommitCommentSubStr = `👏  We really appreciate it.
-Just remind that you have read the contribution guide: https://github.com/%s/%s/blob/master/CONTRIBUTING.md
-If you didn't, you should do that first. If done, welcome again and please enjoy hacking! 🍻
-`
-
-// FirstCommitComment is a comment used to thank a user's first contribution.
-var FirstCommitComment = fmt.Sprintf(
-	"We found this is your first time to contribute to %s, @%s \n%s",
-	"%s",
-	"%s",
-	FirstCommitCommentSubStr,
-)
+	// PRGapCommentID identifies the comment on a pull request far behind master.
+	PRGapCommentID = "pr-gap"
 
-// PRConflictSubStr is a substring of conflict message.
-var PRConflictSubStr = `Conflict happens after merging a previous commit.
-Please rebase the branch against master and push it back again. Thanks a lot.
-`
+	// CIFailsCommentID identifies the comment on a pull request failing CI.
+	CIFailsCommentID = "ci-failure"
 
-// PRConflictComment is a string used to attach comment on conflict PR.
-var PRConflictComment = fmt.Sprintf(
-	"ping @%s \n%s",
-	"%s",
-	PRConflictSubStr,
-)
+	// LifecycleStaleCommentID identifies the comment marking an issue or pull request stale.
+	LifecycleStaleCommentID = "lifecycle-stale"
 
-// PRGapSubStr is a substring of gap message.
-var PRGapSubStr = `commits, which is more than 10 commits, behind master.
-Please rebase the branch against master and push it back again. Thanks a lot.
-`
+	// LifecycleRottenCommentID identifies the comment marking an issue or pull request rotten.
+	LifecycleRottenCommentID = "lifecycle-rotten"
 
-// PRGapComment is a string used to attach comment on gap PR.
-var PRGapComment = fmt.Sprintf(
-	"ping @%s \nWe found that this PR is %s %s",
-	"%s", "%s",
-	PRGapSubStr,
-)
+	// LifecycleClosedCommentID identifies the comment closing a rotten issue or pull request.
+	LifecycleClosedCommentID = "lifecycle-closed"
+
+	// MoreInfoReminderCommentID identifies the comment reminding author to provide more information.
+	MoreInfoReminderCommentID = "more-info-reminder"
+
+	// MoreInfoClosedCommentID identifies the comment closing an issue lacking information.
+	MoreInfoClosedCommentID = "more-info-closed"
+
+	// PRSizeCommentID identifies the comment breaking down size of a pull request.
+	PRSizeCommentID = "pr-size"
 
-// CIFailsCommentSubStr is a substring of CI failing comments
-var CIFailsCommentSubStr = `
-CI fails according integration system.
-Please refer to the CI failure Details button to corresponding test, and update your PR to pass CI.
+	// OwnersApprovalCommentID identifies the comment summarizing approvals missing from OWNERS.
+	OwnersApprovalCommentID = "owners-approval"
 
-If this is flaky test, welcome to track this with [profiling an issue](https://github.com/%s/%s/issues/new).
-`
+	// LinkedPRCommentID identifies the comment in an issue about a pull request fixing it.
+	// Number of pull request is appended to it in comment marker, since several
+	// pull requests could fix the same issue.
+	LinkedPRCommentID = "linked-pr"
 
-// CIFailsComment is a string used to attach comment to CI failed PRs.
-var CIFailsComment = fmt.Sprintf(
-	"ping @%s \n%s",
-	"%s",
-	CIFailsCommentSubStr,
+	// PRMergedThanksID identifies the comment thanking author of a merged pull request.
+	PRMergedThanksID = "pr-merged-thanks"
+
+	// BugWithoutIssueID identifies the comment for a bug fix referencing no issue.
+	BugWithoutIssueID = "bug-without-issue"
 )
 
+// IsWeekday returns true if day is the name of a weekday, like "Friday".
+func IsWeekday(day string) bool {
+	for d := time.Sunday; d <= time.Saturday; d++ {
+		if d.String() == day {
+			return true
+		}
+	}
+	return false
+}
+
 // HasChineseChar is function return whether str has Chinese character or not
 func HasChineseChar(str string) bool {
 	for _, r := range str {